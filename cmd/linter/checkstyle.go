@@ -0,0 +1,59 @@
+package main
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+)
+
+// checkstyleReport is the root element of a Checkstyle XML report
+type checkstyleReport struct {
+	XMLName xml.Name         `xml:"checkstyle"`
+	Version string           `xml:"version,attr"`
+	Files   []checkstyleFile `xml:"file"`
+}
+
+// checkstyleFile lists the diagnostics of a file
+type checkstyleFile struct {
+	Name   string            `xml:"name,attr"`
+	Errors []checkstyleError `xml:"error"`
+}
+
+// checkstyleError is a diagnostic, positioned at its line & column when known
+type checkstyleError struct {
+	Line     int    `xml:"line,attr,omitempty"`
+	Column   int    `xml:"column,attr,omitempty"`
+	Severity string `xml:"severity,attr"`
+	Message  string `xml:"message,attr"`
+	Source   string `xml:"source,attr,omitempty"` // Lint rule which reported the diagnostic (ex. "jl.duplicate-keys")
+}
+
+// printCheckstyle prints the diagnostics recorded for each file as a Checkstyle XML report.
+// Every validated file is listed, the valid ones w/o errors.
+func printCheckstyle(w io.Writer, files []reportFile) error {
+	report := checkstyleReport{Version: "4.3"}
+	for _, file := range files {
+		reported := checkstyleFile{Name: file.path}
+		for _, record := range file.records {
+			reported.Errors = append(reported.Errors, newCheckstyleError(record))
+		}
+		report.Files = append(report.Files, reported)
+	}
+
+	out, err := xml.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "%s%s\n", xml.Header, out)
+	return err
+}
+
+// newCheckstyleError converts the recorded diagnostic, positioning it if it is a finding or a syntax error
+func newCheckstyleError(record diagRecord) checkstyleError {
+	problem := newProblem(record)
+	e := checkstyleError{Line: problem.Line, Column: problem.ColStart, Severity: problem.Severity, Message: unpositionedMessage(record, problem)}
+	if problem.Rule != "" {
+		e.Source = "jl." + problem.Rule
+	}
+	return e
+}
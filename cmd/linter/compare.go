@@ -0,0 +1,34 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/pszponder/json-linter_go/internal/args"
+	"github.com/pszponder/json-linter_go/internal/parser"
+)
+
+// compareFiles validates both files of cfg, then prints their structural differences to stdout, one per line.
+// Returns the exit code of the app: like diff, 1 if the files differ (or are invalid), 0 if they are equivalent.
+func compareFiles(ctx context.Context, cfg args.Config, stdout io.Writer, diags *diagnostics) int {
+	var roots [2]*parser.ASTNode
+	for i, filePath := range cfg.FilePaths {
+		doc, err := validate(ctx, filePath, cfg, diags.logger.Writer())
+		if err != nil {
+			diags.report(parser.SeverityError, fmt.Sprintf("%v: %v", filePath, err))
+			return 1
+		}
+		roots[i] = doc.root
+	}
+
+	diffs := parser.Diff(roots[0], roots[1])
+	for _, diff := range diffs {
+		fmt.Fprintln(stdout, diff)
+	}
+
+	if len(diffs) > 0 {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"log"
+
+	"github.com/pszponder/json-linter_go/internal/parser"
+)
+
+// diagnostics logs the errors & warnings of a run, stopping once the maximum number of errors is reached
+type diagnostics struct {
+	logger *log.Logger
+	max    int // Maximum number of errors to log (0 means no limit)
+	count  int // Number of errors logged so far
+	errors int // Number of errors reported so far, including the ones not logged once the maximum is reached
+
+	onlyErrors bool   // Drop the warnings & prefix each error w/ its file
+	file       string // File the diagnostics being reported belong to
+
+	maxPerFile int // Maximum number of errors to log per file, the warnings being dropped (0 means no limit)
+	fileCount  int // Number of errors logged so far for the file being validated
+
+	collect bool         // Also record the diagnostics logged for a file, for a report printed at the end of the run
+	records []diagRecord // Diagnostics recorded so far, in the order they were logged
+}
+
+// diagRecord is a diagnostic logged for a file, recorded for a report
+type diagRecord struct {
+	file       string
+	severity   parser.Severity
+	diagnostic interface{}
+}
+
+// report logs the diagnostic w/ a prefix matching its severity, unless the maximum number of errors has already been reached.
+// Only errors count toward the maximum, and logging the last allowed error also logs a note that the run is stopping.
+func (d *diagnostics) report(severity parser.Severity, diagnostic interface{}) {
+	isError := severity == parser.SeverityError
+	if isError {
+		d.errors++
+	} else if d.onlyErrors || d.maxPerFile > 0 {
+		return
+	}
+	if d.full() || (d.maxPerFile > 0 && d.fileCount >= d.maxPerFile) {
+		return
+	}
+
+	if d.onlyErrors && d.file != "" {
+		d.logger.Printf("Error: %s: %v", d.file, diagnostic)
+	} else if severity == parser.SeverityError {
+		d.logger.Print("Error: ", diagnostic)
+	} else {
+		d.logger.Print("Warning: ", diagnostic)
+	}
+	if isError {
+		d.count++
+		d.fileCount++
+	}
+	if d.collect && d.file != "" {
+		d.records = append(d.records, diagRecord{file: d.file, severity: severity, diagnostic: diagnostic})
+	}
+
+	if isError && d.full() {
+		d.logger.Printf("Error: too many errors (%d), stopping", d.max)
+	}
+}
+
+// startFile makes the diagnostics reported next belong to the file located at filePath
+func (d *diagnostics) startFile(filePath string) {
+	d.file = filePath
+	d.fileCount = 0
+}
+
+// resetFile gives the validation of the next file its own budget of errors, as if it started a new run (ex. when watching files)
+func (d *diagnostics) resetFile() {
+	d.count = 0
+	d.fileCount = 0
+}
+
+// valid logs that a file is valid, unless only errors are logged
+func (d *diagnostics) valid(format string, v ...interface{}) {
+	if !d.onlyErrors {
+		d.logger.Printf(format, v...)
+	}
+}
+
+// full reports whether the maximum number of errors has been reached
+func (d *diagnostics) full() bool {
+	return d.max > 0 && d.count >= d.max
+}
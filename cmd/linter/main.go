@@ -1,30 +1,543 @@
 package main
 
 import (
+	"bytes"
+	"context"
+	"errors"
 	"fmt"
+	"io"
 	"log"
 	"os"
+	"os/signal"
 
 	"github.com/pszponder/json-linter_go/internal/args"
+	"github.com/pszponder/json-linter_go/internal/input"
 	"github.com/pszponder/json-linter_go/internal/lexer"
 	"github.com/pszponder/json-linter_go/internal/parser"
+	"github.com/pszponder/json-linter_go/internal/schema"
 )
 
+// The schema rule isn't implemented by the parser, so it's registered for the rule config here
+func init() {
+	args.RegisterRule(schema.RuleSchema)
+}
+
 func main() {
-	// Retrieve filepath to the file to validate
-	filePath := args.GetFilePath()
-	fmt.Println(filePath)
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr)) // 1st arg is the app binary
+}
+
+// run executes the linter w/ the provided command-line arguments and returns the exit code of the app.
+// Regular output is written to stdout, diagnostics are logged to stderr.
+func run(argv []string, stdout io.Writer, stderr io.Writer) int {
+	logger := log.New(stderr, "", log.LstdFlags)
+
+	// Retrieve the filepaths of the files to validate
+	cfg, err := args.ParseArgs(argv)
+	if err != nil {
+		fmt.Fprintln(stdout, err)
+		return 1 // Exit the app w/ a non-zero status code to indicate an error
+	}
+	// The root types are known to the parser, so the expected type is validated here instead of by ParseArgs
+	if _, err := parser.ParseRootType(cfg.ExpectType); err != nil {
+		fmt.Fprintf(stdout, "%v: invalid -expect-type: %v\n", args.ErrUsage, err)
+		return 1
+	}
+
+	var exitCode int
+	if cfg.Pipe {
+		exitCode = pipe(cfg, os.Stdin, stdout, logger)
+	} else if cfg.Output != "" {
+		exitCode = lintToFile(cfg, logger)
+	} else {
+		exitCode = lintAll(cfg, stdout, logger)
+	}
+
+	// The findings are still printed, only the exit code is ignored
+	if cfg.ExitZero {
+		return 0
+	}
+	return exitCode
+}
+
+// lintAll validates the files of the config & prints the findings & reports requested.
+// Returns the exit code of the app.
+func lintAll(cfg args.Config, stdout io.Writer, logger *log.Logger) int {
+	if cfg.DryRun {
+		return dryRun(cfg, stdout, logger)
+	}
+
+	// Profile the run if requested
+	stopProfiling, err := startProfiling(cfg, logger)
+	if err != nil {
+		logger.Print("Error: ", err)
+		return 1
+	}
+	defer stopProfiling()
+
+	ctx, cancel := runContext(cfg)
+	defer cancel()
+
+	if cfg.Compare {
+		return compareFiles(ctx, cfg, stdout, newDiagnostics(cfg, logger))
+	}
+
+	// Validate every file (directories are scanned recursively), the run fails if any of them is invalid.
+	// The run stops early once too many diagnostics have been reported.
+	l, err := newLinter(cfg, stdout, logger)
+	if err != nil {
+		logger.Print("Error: ", err)
+		return 1
+	}
+	diags := l.diags
+
+	// Validate the documents received on the address until interrupted
+	if cfg.Serve != "" {
+		listener, err := listen(cfg.Serve)
+		if err != nil {
+			logger.Print("Error: ", err)
+			return 1
+		}
+		ctx, stop := signal.NotifyContext(ctx, os.Interrupt)
+		defer stop()
+		return l.serve(ctx, listener)
+	}
+
+	// Keep validating the files as they change until interrupted
+	if cfg.Watch {
+		ctx, stop := signal.NotifyContext(ctx, os.Interrupt)
+		defer stop()
+		return l.watch(ctx, watchInterval)
+	}
+
+	exitCode := 0
+paths:
+	for _, path := range cfg.FilePaths {
+		filePaths, err := expandPath(cfg, path, remainingFiles(cfg, len(l.results)))
+		if err != nil {
+			diags.report(parser.SeverityError, err)
+			exitCode = 1
+			continue
+		}
+
+		for _, filePath := range filePaths {
+			if diags.full() {
+				break paths
+			}
+			if cfg.MaxFiles > 0 && len(l.results) >= cfg.MaxFiles {
+				logTruncated(logger, cfg.MaxFiles)
+				break paths
+			}
+
+			errorsBefore := diags.errors
+			valid := l.lintFile(ctx, filePath) == 0
+			if !valid {
+				exitCode = 1
+			}
+			l.results = append(l.results, fileResult{Path: filePath, Valid: valid, ErrorCount: diags.errors - errorsBefore})
+		}
+	}
+
+	// Print the summary of every file
+	if cfg.SummaryJSON {
+		if err := printSummary(stdout, l.results); err != nil {
+			logger.Print("Error: ", err)
+			return 1
+		}
+	}
+
+	// Print the diagnostics of every file as a report
+	if cfg.ReportFormat != "" {
+		if err := printReport(stdout, cfg.ReportFormat, l.results, diags.records); err != nil {
+			logger.Print("Error: ", err)
+			return 1
+		}
+	}
+
+	return exitCode
+}
+
+// dryRun prints the files which would be validated, one per line, w/o validating them.
+// Returns the exit code of the app.
+func dryRun(cfg args.Config, stdout io.Writer, logger *log.Logger) int {
+	exitCode := 0
+	count := 0 // Number of files printed so far
+paths:
+	for _, path := range cfg.FilePaths {
+		filePaths, err := expandPath(cfg, path, remainingFiles(cfg, count))
+		if err != nil {
+			logger.Print("Error: ", err)
+			exitCode = 1
+			continue
+		}
+
+		for _, filePath := range filePaths {
+			if cfg.MaxFiles > 0 && count >= cfg.MaxFiles {
+				logTruncated(logger, cfg.MaxFiles)
+				break paths
+			}
+			fmt.Fprintln(stdout, filePath)
+			count++
+		}
+	}
+
+	return exitCode
+}
+
+// expandPath returns the files to validate for path, a directory being scanned for Markdown files w/ -markdown,
+// & for at most limit files (0 means no limit)
+func expandPath(cfg args.Config, path string, limit int) ([]string, error) {
+	if cfg.Markdown {
+		return input.ExpandMarkdown(path, cfg.Ignore, cfg.ModifiedSince, limit)
+	}
+	return input.ExpandModifiedSince(path, cfg.Ignore, cfg.ModifiedSince, limit)
+}
+
+// remainingFiles returns the number of files a directory is scanned for once done files are validated (0 means no limit).
+// It's 1 more than the files left for -max-files, to tell whether the run is truncated.
+func remainingFiles(cfg args.Config, done int) int {
+	if cfg.MaxFiles <= 0 {
+		return 0
+	}
+	return cfg.MaxFiles - done + 1
+}
+
+// logTruncated warns that the run stops before validating more files than the maximum, leaving the rest unchecked
+func logTruncated(logger *log.Logger, maxFiles int) {
+	logger.Printf("Warning: too many files, the run is truncated to the first %d", maxFiles)
+}
+
+// linter holds the state shared by the validation of every file of a run
+type linter struct {
+	cfg         args.Config
+	stdout      io.Writer
+	diags       *diagnostics
+	schemaDoc   interface{}  // Decoded JSON Schema the files must match (nil if there is none)
+	schemaDraft schema.Draft // Draft used to interpret schemaDoc
+	results     []fileResult
+}
+
+// runContext returns the context bounding the whole run (fetching, lexing & parsing of every file) by the timeout
+func runContext(cfg args.Config) (context.Context, context.CancelFunc) {
+	if cfg.Timeout > 0 {
+		return context.WithTimeout(context.Background(), cfg.Timeout)
+	}
+	return context.WithCancel(context.Background())
+}
+
+// newDiagnostics creates the diagnostics of a run, limited as configured
+func newDiagnostics(cfg args.Config, logger *log.Logger) *diagnostics {
+	diags := &diagnostics{logger: logger, max: cfg.MaxErrors, onlyErrors: cfg.OnlyErrors, collect: cfg.ReportFormat != ""}
+	if cfg.FirstErrorOnly {
+		diags.maxPerFile = 1
+	}
+	return diags
+}
+
+// newLinter creates the linter of a run, loading the schema (if any) once for every file
+func newLinter(cfg args.Config, stdout io.Writer, logger *log.Logger) (*linter, error) {
+	l := &linter{cfg: cfg, stdout: stdout, diags: newDiagnostics(cfg, logger)}
+	if err := l.loadSchema(); err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+// loadSchema loads the schema of the config (if any) & parses the draft interpreting it
+func (l *linter) loadSchema() error {
+	if l.cfg.Schema == "" {
+		return nil
+	}
+
+	draft, err := schema.ParseDraft(l.cfg.SchemaDraft)
+	if err != nil {
+		return err
+	}
+	schemaDoc, err := schema.Load(l.cfg.Schema)
+	if err != nil {
+		return err
+	}
+	l.schemaDoc, l.schemaDraft = schemaDoc, draft
+	return nil
+}
+
+// lintFile validates the file located at filePath and reports the findings of the lint rules.
+// Returns the exit code for the file.
+func (l *linter) lintFile(ctx context.Context, filePath string) int {
+	cfg := l.cfg
+	l.diags.startFile(filePath)
+	defer l.diags.startFile("")
+	// Report modes print only their report to l.stdout, while the errors name their file if only errors are printed
+	if !printsReport(cfg) && !cfg.OnlyErrors {
+		fmt.Fprintln(l.stdout, filePath)
+	}
+
+	// Each record of a sequence is an independent document
+	if cfg.JSONSeq {
+		return l.lintSequence(ctx, filePath)
+	}
+
+	// As is each JSON code block of a Markdown document
+	if cfg.Markdown {
+		return l.lintMarkdown(ctx, filePath)
+	}
+
+	doc, err := validate(ctx, filePath, cfg, l.diags.logger.Writer())
+	if errors.Is(err, context.DeadlineExceeded) {
+		l.diags.report(parser.SeverityError, fmt.Sprintf("validation of %v timed out after %v", filePath, cfg.Timeout))
+		return 1
+	}
+	if err != nil {
+		l.diags.report(parser.SeverityError, err)
+		return 1
+	}
+
+	if exitCode := l.lintDocument(ctx, doc); exitCode != 0 {
+		return exitCode
+	}
+	rootNode := doc.root
+
+	// Print the maximum nesting depth
+	if cfg.Depth {
+		fmt.Fprintln(l.stdout, parser.MaxDepth(rootNode))
+	}
+
+	// Print the indentation style of the document
+	if cfg.DetectIndent {
+		fmt.Fprintln(l.stdout, parser.DetectIndent(doc.tokens))
+	}
+
+	// Print the schema inferred from the document
+	if cfg.InferSchema {
+		doc, err := schema.InferDocument(rootNode)
+		if err != nil {
+			l.diags.report(parser.SeverityError, err)
+			return 1
+		}
+		fmt.Fprintln(l.stdout, string(doc))
+	}
+
+	// Print the formatted document
+	if cfg.Format {
+		formatted := rootNode
+		if cfg.NormalizeNumbers {
+			formatted = parser.NormalizeNumbers(rootNode)
+		}
+		fmt.Fprint(l.stdout, parser.Format(formatted, cfg.Indent))
+	}
+
+	// Print the canonical form of the document, w/o a trailing newline as it would change the signed bytes
+	if cfg.Canonical {
+		canonical, err := parser.Canonical(rootNode)
+		if err != nil {
+			l.diags.report(parser.SeverityError, err)
+			return 1
+		}
+		fmt.Fprint(l.stdout, canonical)
+	}
+
+	// Print the paths of the keys of the document
+	if cfg.KeysOnly {
+		for _, path := range parser.KeyPaths(rootNode) {
+			fmt.Fprintln(l.stdout, path)
+		}
+	}
+
+	// Print the tokens of the document
+	if cfg.TokensJSON {
+		if err := printTokensJSON(l.stdout, doc.tokens); err != nil {
+			l.diags.report(parser.SeverityError, err)
+			return 1
+		}
+	}
+
+	// Print the structure of the document as a graph
+	if cfg.Graph != "" {
+		if err := parser.WriteDOT(l.stdout, rootNode); err != nil {
+			l.diags.report(parser.SeverityError, err)
+			return 1
+		}
+	}
+
+	l.diags.valid("JSON file located in %v is valid", filePath)
+	return 0
+}
+
+// lintDocument reports the findings of the lint rules (& of the schema) for the valid document, then validates
+// the JSON embedded at the unwrap path. Returns the exit code for the document.
+func (l *linter) lintDocument(ctx context.Context, doc *document) int {
+	cfg := l.cfg
+	rootNode := doc.root
+
+	// Run the lint rules, duplicate keys are reported according to the policy
+	findings := parser.CheckDuplicateKeysWithPolicy(rootNode, cfg.DuplicateKeys)
+	findings = append(findings, parser.CheckRequiredKeys(rootNode, cfg.RequireKeys)...)
+	findings = append(findings, parser.CheckRootType(rootNode, cfg.ExpectType)...)
+	findings = append(findings, parser.CheckEnums(rootNode, cfg.Enums)...)
+	findings = append(findings, parser.CheckAssertEmpty(rootNode, cfg.AssertEmpty)...)
+	findings = append(findings, parser.CheckReplacements(doc.replacements)...)
+	findings = append(findings, parser.CheckNumberOverflow(rootNode)...)
+	findings = append(findings, parser.CheckLineLength(doc.longLine, cfg.MaxLineLength)...)
+	findings = append(findings, parser.CheckEmptyContainers(rootNode, cfg.ForbidEmptyObject, cfg.ForbidEmptyArray)...)
+	if cfg.HomogeneousArrays {
+		findings = append(findings, parser.CheckHomogeneousArrays(rootNode)...)
+	}
+	if cfg.RequireFinalNewline || cfg.NoFinalNewline {
+		findings = append(findings, parser.CheckFinalNewline(doc.tokens, doc.finalNewlines, cfg.RequireFinalNewline)...)
+	}
+	if cfg.PreserveTrivia {
+		findings = append(findings, parser.CheckMixedIndentation(doc.tokens)...)
+	}
+	if l.schemaDoc != nil {
+		schemaFindings, err := schema.Validate(l.schemaDoc, rootNode, l.schemaDraft)
+		if err != nil {
+			l.diags.report(parser.SeverityError, err)
+			return 1
+		}
+		findings = append(findings, schemaFindings...)
+	}
+
+	// Drop the findings disabled by directive comments, then report the rest at the level of their rule
+	findings = parser.ApplyDirectives(doc.tokens, findings)
+	findings = parser.ApplyRuleLevels(findings, cfg.Rules)
+
+	exitCode := 0
+	for _, finding := range findings {
+		l.diags.report(finding.Severity, finding)
+		if finding.Severity == parser.SeverityError {
+			exitCode = 1
+		}
+	}
+	if exitCode != 0 {
+		return exitCode
+	}
+
+	// Validate the JSON embedded (double-encoded) in the string at the unwrap path
+	if cfg.Unwrap != "" {
+		node, err := parser.Lookup(rootNode, cfg.Unwrap)
+		if err == nil {
+			_, err = parser.ParseEmbedded(ctx, node, lexerOptions(cfg))
+		}
+		if err != nil {
+			l.diags.report(parser.SeverityError, fmt.Sprintf("invalid JSON embedded at '%s': %v", cfg.Unwrap, err))
+			return 1
+		}
+	}
+
+	return 0
+}
+
+// printsReport reports whether the run prints a report about the JSON document to stdout
+func printsReport(cfg args.Config) bool {
+	return cfg.Depth || cfg.DetectIndent || cfg.InferSchema || cfg.SummaryJSON || cfg.Format || cfg.ReportFormat != "" || cfg.Graph != "" || cfg.KeysOnly || cfg.TokensJSON || cfg.Canonical
+}
+
+// document is a valid JSON document along w/ what the lexer found out about its input
+type document struct {
+	tokens        []lexer.Token
+	root          *parser.ASTNode
+	finalNewlines int                   // Number of consecutive newlines ending the input
+	replacements  []lexer.LexerPosition // Positions of the invalid UTF-8 bytes replaced by U+FFFD
+	longLine      lexer.LexerPosition   // Position of the first character past the maximum line length (zero if there is none)
+}
+
+// lexerOptions returns the lexer options configured by cfg
+func lexerOptions(cfg args.Config) lexer.Options {
+	return lexer.Options{
+		PreserveTrivia:  cfg.PreserveTrivia || cfg.DetectIndent, // The indentation is read from the trivia
+		AllowComments:   cfg.AllowComments,
+		MaxStringLength: cfg.MaxStringLength,
+		InvalidUTF8:     cfg.InvalidUTF8,
+		Relaxed:         cfg.Relaxed,
+		RelaxedKeys:     cfg.RelaxedKeys,
+		AllowNaNInf:     cfg.AllowNaNInf,
+		MaxLines:        cfg.MaxLines,
+		MaxTokenLength:  cfg.MaxTokenLength,
+		MaxNumberLength: cfg.MaxNumberLength,
+		MaxLineLength:   cfg.MaxLineLength,
+		EmitWhitespace:  cfg.EmitWhitespace,
+	}
+}
+
+// parserOptions returns the parser options configured by cfg, tracing to stderr if requested
+func parserOptions(cfg args.Config, stderr io.Writer) parser.ParseOptions {
+	opts := parser.ParseOptions{
+		DuplicateKeys:     cfg.DuplicateKeys,
+		ForbidEmptyObject: cfg.ForbidEmptyObject,
+		ForbidEmptyArray:  cfg.ForbidEmptyArray,
+		HomogeneousArrays: cfg.HomogeneousArrays,
+		MaxArrayElements:  cfg.MaxArrayElements,
+		MaxObjectMembers:  cfg.MaxObjectMembers,
+		RelaxedKeys:       cfg.RelaxedKeys,
+	}
+	if cfg.Trace {
+		opts.Trace = stderr
+	}
+	return opts
+}
+
+// validate opens the file (or URL) located at filePath, then tokenizes & parses it as configured by cfg.
+// The parser traces its decisions to stderr w/ -trace.
+// Returns the document if the JSON is valid.
+func validate(ctx context.Context, filePath string, cfg args.Config, stderr io.Writer) (*document, error) {
+	file, err := input.Open(ctx, filePath)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	return readDocument(ctx, file, filePath, input.Size(file), cfg, stderr)
+}
+
+// readDocument tokenizes & parses the input named name (ex. its filepath), of size bytes (-1 if unknown),
+// as configured by cfg. Returns the document if the JSON is valid.
+func readDocument(ctx context.Context, r io.Reader, name string, size int64, cfg args.Config, stderr io.Writer) (*document, error) {
+	// Preprocess the whole input before lexing it: decode it from base64 & UTF-16, then verify it is valid UTF-8.
+	// Positions reported for the document are within the decoded input.
+	counter := input.NewCountingReader(r)
+	var reader io.Reader = counter
+	if cfg.Progress {
+		reader = input.NewProgressReader(counter, name, size, stderr)
+	}
+	if cfg.Base64 || cfg.Encoding.IsUTF16() || cfg.CheckEncoding {
+		data, err := io.ReadAll(reader)
+		if err != nil {
+			return nil, err
+		}
+		if cfg.Base64 {
+			if data, err = input.DecodeBase64(data); err != nil {
+				return nil, err
+			}
+		}
+		if cfg.Encoding.IsUTF16() {
+			if data, err = input.DecodeUTF16(data, cfg.Encoding); err != nil {
+				return nil, err
+			}
+		}
+		if cfg.CheckEncoding {
+			if err := input.CheckUTF8(data); err != nil {
+				return nil, err
+			}
+		}
+		reader = bytes.NewReader(data)
+	}
 
 	// Pass in the file to a lexer in order to generate a token representation of the file (tokenize it)
-	tokens := lexer.Lex(filePath)
+	lxr := lexer.NewLexer(reader, lexerOptions(cfg))
+	tokens, err := lxr.ReadAll(ctx)
+	if err != nil {
+		return nil, err
+	}
 
-	// Parse the tokens and determine if the JSON is valid
-	_, err := parser.ParseJSON(tokens)
+	// Parse the tokens and determine if the JSON is valid, the errors about the document ending early pointing past its end
+	opts := parserOptions(cfg, stderr)
+	opts.End = lxr.End()
+	rootNode, err := parser.ParseJSONWithOptions(ctx, tokens, opts)
+	if cfg.Verbose {
+		fmt.Fprintf(stderr, "Metrics: %s: %d bytes, %d runes, %d lines, %d tokens\n", name, counter.Count(), lxr.RuneCount, lxr.LineCount(), len(tokens))
+	}
 	if err != nil {
-		log.Print("Error: ", err)
-		os.Exit(1)
+		return nil, err
 	}
 
-	log.Printf("JSON file located in %v is valid", filePath)
-	os.Exit(0)
+	return &document{tokens: tokens, root: rootNode, finalNewlines: lxr.FinalNewlines, replacements: lxr.Replacements, longLine: lxr.LongLine}, nil
 }
@@ -0,0 +1,2121 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/pszponder/json-linter_go/internal/args"
+	"github.com/pszponder/json-linter_go/internal/lexer"
+)
+
+// writeFile is a helper which writes the content to a file in a temporary directory and returns its path
+func writeFile(t *testing.T, name string, content string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("Failed to write %s: %v", path, err)
+	}
+	return path
+}
+
+func TestRun(t *testing.T) {
+	// Define test cases using the fixtures in the tests directory
+	testCases := []struct {
+		argv             []string
+		expectedExitCode int
+	}{
+		{argv: []string{"../../tests/step1/valid.json"}, expectedExitCode: 0},
+		{argv: []string{"../../tests/step1/invalid.json"}, expectedExitCode: 1},
+		{argv: []string{"../../tests/step2/valid2.json"}, expectedExitCode: 0},
+		{argv: []string{"../../tests/step2/invalid2.json"}, expectedExitCode: 1},
+		{argv: []string{"../../tests/step3/valid.json"}, expectedExitCode: 0},
+		{argv: []string{"../../tests/step3/invalid.json"}, expectedExitCode: 1},
+		{argv: []string{"../../tests/step4/valid2.json"}, expectedExitCode: 0},
+		{argv: []string{"../../tests/step4/invalid.json"}, expectedExitCode: 1},
+		{argv: []string{"../../tests/does-not-exist.json"}, expectedExitCode: 1},
+		{argv: []string{}, expectedExitCode: 1},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(strings.Join(testCase.argv, " "), func(t *testing.T) {
+			if exitCode := run(testCase.argv, io.Discard, io.Discard); exitCode != testCase.expectedExitCode {
+				t.Errorf("Expected exit code %d, got %d", testCase.expectedExitCode, exitCode)
+			}
+		})
+	}
+}
+
+func TestRunTimeout(t *testing.T) {
+	// The server sends the start of the document, then stalls until the client gives up
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, `[1, 2, `)
+		w.(http.Flusher).Flush()
+		<-r.Context().Done()
+	}))
+	defer server.Close()
+
+	var stderr bytes.Buffer
+	exitCode := run([]string{"-timeout=50ms", server.URL}, io.Discard, &stderr)
+
+	if exitCode == 0 {
+		t.Errorf("Expected a non-zero exit code, got %d", exitCode)
+	}
+	if !strings.Contains(stderr.String(), "timed out after 50ms") {
+		t.Errorf("Expected a timeout error, got %q", stderr.String())
+	}
+}
+
+func TestRunJSONCDirectives(t *testing.T) {
+	path := writeFile(t, "settings.jsonc", `{
+	"a": 1,
+	// linter-disable duplicate-keys
+	"a": 2,
+	"b": 1,
+	"b": 2
+}`)
+
+	// Comments are rejected unless -jsonc is set
+	if exitCode := run([]string{path}, io.Discard, io.Discard); exitCode != 1 {
+		t.Errorf("Expected exit code 1 w/o -jsonc, got %d", exitCode)
+	}
+
+	// Only the duplicate key w/o a directive is reported
+	var stderr bytes.Buffer
+	if exitCode := run([]string{"-jsonc", "-duplicate-keys=warn", path}, io.Discard, &stderr); exitCode != 0 {
+		t.Errorf("Expected exit code 0, got %d", exitCode)
+	}
+	if strings.Contains(stderr.String(), "'a'") {
+		t.Errorf("Expected the duplicate key 'a' to be suppressed, got %q", stderr.String())
+	}
+	if !strings.Contains(stderr.String(), "Warning: Duplicate key 'b' at line 6") {
+		t.Errorf("Expected a warning for the duplicate key 'b', got %q", stderr.String())
+	}
+
+	// Disallowing duplicate keys turns the remaining warning into an error
+	stderr.Reset()
+	if exitCode := run([]string{"-jsonc", "-disallow-duplicate-keys", path}, io.Discard, &stderr); exitCode != 1 {
+		t.Errorf("Expected exit code 1, got %d", exitCode)
+	}
+	if !strings.Contains(stderr.String(), "Error: Duplicate key 'b' at line 6") {
+		t.Errorf("Expected an error for the duplicate key 'b', got %q", stderr.String())
+	}
+}
+
+func TestRunDuplicateKeyPolicy(t *testing.T) {
+	path := writeFile(t, "duplicates.json", `{"a": 1, "a": 2}`)
+
+	// Define test cases
+	testCases := []struct {
+		policy           string
+		expectedExitCode int
+		expectedStderr   string // Empty if no finding is expected
+	}{
+		{policy: "error", expectedExitCode: 1, expectedStderr: "Error: Duplicate key 'a'"},
+		{policy: "warn", expectedExitCode: 0, expectedStderr: "Warning: Duplicate key 'a'"},
+		{policy: "keep-last", expectedExitCode: 0},
+		// An empty policy selects the default, which doesn't report duplicate keys
+		{policy: "", expectedExitCode: 0},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.policy, func(t *testing.T) {
+			var stderr bytes.Buffer
+			if exitCode := run([]string{"-duplicate-keys=" + testCase.policy, path}, io.Discard, &stderr); exitCode != testCase.expectedExitCode {
+				t.Errorf("Expected exit code %d, got %d", testCase.expectedExitCode, exitCode)
+			}
+
+			if testCase.expectedStderr == "" {
+				if strings.Contains(stderr.String(), "Duplicate key") {
+					t.Errorf("Expected no finding, got %q", stderr.String())
+				}
+			} else if !strings.Contains(stderr.String(), testCase.expectedStderr) {
+				t.Errorf("Expected %q, got %q", testCase.expectedStderr, stderr.String())
+			}
+		})
+	}
+}
+
+func TestRunDepth(t *testing.T) {
+	// Define test cases
+	testCases := []struct {
+		content          string
+		expectedStdout   string
+		expectedExitCode int
+	}{
+		{content: `"scalar"`, expectedStdout: "0\n"},
+		{content: `{}`, expectedStdout: "1\n"},
+		{content: `[[1]]`, expectedStdout: "2\n"},
+		{content: `{"a": [{"b": {}}]}`, expectedStdout: "4\n"},
+		{content: `[[1]`, expectedStdout: "", expectedExitCode: 1},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.content, func(t *testing.T) {
+			path := writeFile(t, "depth.json", testCase.content)
+
+			var stdout bytes.Buffer
+			exitCode := run([]string{"-depth", path}, &stdout, io.Discard)
+
+			if exitCode != testCase.expectedExitCode {
+				t.Errorf("Expected exit code %d, got %d", testCase.expectedExitCode, exitCode)
+			}
+			if stdout.String() != testCase.expectedStdout {
+				t.Errorf("Expected stdout %q, got %q", testCase.expectedStdout, stdout.String())
+			}
+		})
+	}
+}
+
+func TestRunProfiles(t *testing.T) {
+	dir := t.TempDir()
+	cpuProfile := filepath.Join(dir, "cpu.out")
+	memProfile := filepath.Join(dir, "mem.out")
+
+	argv := []string{"-cpuprofile=" + cpuProfile, "-memprofile=" + memProfile, "../../tests/step4/valid2.json"}
+	if exitCode := run(argv, io.Discard, io.Discard); exitCode != 0 {
+		t.Fatalf("Expected exit code 0, got %d", exitCode)
+	}
+
+	for _, path := range []string{cpuProfile, memProfile} {
+		info, err := os.Stat(path)
+		if err != nil {
+			t.Fatalf("Expected the profile %s to be written, got %v", path, err)
+		}
+		if info.Size() == 0 {
+			t.Errorf("Expected the profile %s to be non-empty", path)
+		}
+	}
+}
+
+func TestRunManifest(t *testing.T) {
+	valid := writeFile(t, "valid.json", `{"a": 1}`)
+	invalid := writeFile(t, "invalid.json", `{"a": 1,}`)
+
+	// Define test cases
+	testCases := []struct {
+		name             string
+		manifest         string
+		expectedExitCode int
+	}{
+		{name: "valid entries", manifest: "# comment\n" + valid + "\n\n" + valid + "\n", expectedExitCode: 0},
+		{name: "mixed entries", manifest: valid + "\n# comment\n" + invalid + "\n", expectedExitCode: 1},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			manifest := writeFile(t, "list.txt", testCase.manifest)
+
+			var stdout bytes.Buffer
+			exitCode := run([]string{"@" + manifest}, &stdout, io.Discard)
+
+			if exitCode != testCase.expectedExitCode {
+				t.Errorf("Expected exit code %d, got %d", testCase.expectedExitCode, exitCode)
+			}
+			// Every listed file is validated, even after an invalid one
+			if lines := strings.Count(stdout.String(), "\n"); lines != 2 {
+				t.Errorf("Expected 2 files to be validated, got %q", stdout.String())
+			}
+		})
+	}
+
+	// Manifests combine w/ directly supplied filepaths
+	manifest := writeFile(t, "list.txt", valid+"\n")
+	if exitCode := run([]string{"@" + manifest, invalid}, io.Discard, io.Discard); exitCode != 1 {
+		t.Errorf("Expected exit code 1, got %d", exitCode)
+	}
+}
+
+func TestRunDirectoryIgnore(t *testing.T) {
+	root := t.TempDir()
+	for name, content := range map[string]string{
+		"valid.json":                   `{"a": 1}`,
+		"nested/valid.json":            `[1, 2]`,
+		"node_modules/pkg/broken.json": `{"a": 1,}`,
+		"fixtures/invalid.json":        `[1, 2`,
+	} {
+		path := filepath.Join(root, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatalf("Failed to create %s: %v", filepath.Dir(path), err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatalf("Failed to write %s: %v", path, err)
+		}
+	}
+
+	// The invalid files fail the run unless they are ignored
+	if exitCode := run([]string{root}, io.Discard, io.Discard); exitCode != 1 {
+		t.Errorf("Expected exit code 1, got %d", exitCode)
+	}
+
+	var stdout bytes.Buffer
+	if exitCode := run([]string{"-ignore=node_modules", "-ignore=fixtures/*.json", root}, &stdout, io.Discard); exitCode != 0 {
+		t.Errorf("Expected exit code 0, got %d", exitCode)
+	}
+	if strings.Contains(stdout.String(), "broken.json") || strings.Contains(stdout.String(), "invalid.json") {
+		t.Errorf("Expected the ignored files not to be validated, got %q", stdout.String())
+	}
+	if lines := strings.Count(stdout.String(), "\n"); lines != 2 {
+		t.Errorf("Expected 2 files to be validated, got %q", stdout.String())
+	}
+}
+
+func TestRunInferSchema(t *testing.T) {
+	path := writeFile(t, "config.json", `{"name": "jl", "ports": [80, 443]}`)
+
+	var stdout bytes.Buffer
+	if exitCode := run([]string{"-infer-schema", path}, &stdout, io.Discard); exitCode != 0 {
+		t.Fatalf("Expected exit code 0, got %d", exitCode)
+	}
+
+	expectedStdout := `{
+  "$schema": "https://json-schema.org/draft/2020-12/schema",
+  "properties": {
+    "name": {
+      "type": "string"
+    },
+    "ports": {
+      "items": {
+        "type": "integer"
+      },
+      "type": "array"
+    }
+  },
+  "required": [
+    "name",
+    "ports"
+  ],
+  "type": "object"
+}
+`
+	if stdout.String() != expectedStdout {
+		t.Errorf("Expected stdout %q, got %q", expectedStdout, stdout.String())
+	}
+}
+
+func TestRunMaxStringLength(t *testing.T) {
+	path := writeFile(t, "strings.json", `{"name": "abcdefgh"}`)
+
+	if exitCode := run([]string{"-max-string-length=8", path}, io.Discard, io.Discard); exitCode != 0 {
+		t.Errorf("Expected exit code 0 at the limit, got %d", exitCode)
+	}
+
+	var stderr bytes.Buffer
+	if exitCode := run([]string{"-max-string-length=7", path}, io.Discard, &stderr); exitCode != 1 {
+		t.Errorf("Expected exit code 1 over the limit, got %d", exitCode)
+	}
+	if !strings.Contains(stderr.String(), "exceeds the maximum length of 7 characters at line 1, Column 10:19") {
+		t.Errorf("Expected a string length error, got %q", stderr.String())
+	}
+}
+
+func TestRunMaxValues(t *testing.T) {
+	path := writeFile(t, "values.json", `{"ids": [1, 2, 3], "name": "jl", "version": 2}`)
+
+	if exitCode := run([]string{"-max-array-elements=3", "-max-object-members=3", path}, io.Discard, io.Discard); exitCode != 0 {
+		t.Errorf("Expected exit code 0 at the limits, got %d", exitCode)
+	}
+
+	var stderr bytes.Buffer
+	if exitCode := run([]string{"-max-array-elements=2", path}, io.Discard, &stderr); exitCode != 1 {
+		t.Errorf("Expected exit code 1 over the array limit, got %d", exitCode)
+	}
+	if !strings.Contains(stderr.String(), "Error: Array exceeds the maximum of 2 elements at line 1, Column 9:9") {
+		t.Errorf("Expected an array size error, got %q", stderr.String())
+	}
+
+	stderr.Reset()
+	if exitCode := run([]string{"-max-object-members=2", path}, io.Discard, &stderr); exitCode != 1 {
+		t.Errorf("Expected exit code 1 over the object limit, got %d", exitCode)
+	}
+	if !strings.Contains(stderr.String(), "Error: Object exceeds the maximum of 2 members at line 1, Column 1:1") {
+		t.Errorf("Expected an object size error, got %q", stderr.String())
+	}
+}
+
+func TestRunMaxLines(t *testing.T) {
+	path := writeFile(t, "events.json", "[\n  1,\n  2,\n  3\n]\n")
+
+	if exitCode := run([]string{"-max-lines=5", path}, io.Discard, io.Discard); exitCode != 0 {
+		t.Errorf("Expected exit code 0 at the limit, got %d", exitCode)
+	}
+
+	var stderr bytes.Buffer
+	if exitCode := run([]string{"-max-lines=3", path}, io.Discard, &stderr); exitCode != 1 {
+		t.Errorf("Expected exit code 1 over the limit, got %d", exitCode)
+	}
+	if !strings.Contains(stderr.String(), "Error: too many lines: line 4 exceeds the maximum of 3 lines") {
+		t.Errorf("Expected a line count error, got %q", stderr.String())
+	}
+}
+
+func TestRunMaxTokenLength(t *testing.T) {
+	path := writeFile(t, "data.json", `{"id": 12345, "name": "json-linter"}`)
+
+	if exitCode := run([]string{"-max-token-length=11", path}, io.Discard, io.Discard); exitCode != 0 {
+		t.Errorf("Expected exit code 0 at the limit, got %d", exitCode)
+	}
+
+	// The first token over the limit is reported at its start
+	var stderr bytes.Buffer
+	if exitCode := run([]string{"-max-token-length=4", path}, io.Discard, &stderr); exitCode != 1 {
+		t.Errorf("Expected exit code 1 over the limit, got %d", exitCode)
+	}
+	if expected := "Error: Invalid JSON value '1', token too long: exceeds the maximum length of 4 characters at line 1, Column 8:12"; !strings.Contains(stderr.String(), expected) {
+		t.Errorf("Expected stderr to contain %q, got %q", expected, stderr.String())
+	}
+}
+
+func TestRunMaxNumberLength(t *testing.T) {
+	path := writeFile(t, "data.json", `{"id": 12345, "ratio": 0.5}`)
+
+	if exitCode := run([]string{"-max-number-length=5", path}, io.Discard, io.Discard); exitCode != 0 {
+		t.Errorf("Expected exit code 0 at the limit, got %d", exitCode)
+	}
+
+	// The first number over the limit is reported at its start
+	var stderr bytes.Buffer
+	if exitCode := run([]string{"-max-number-length=4", path}, io.Discard, &stderr); exitCode != 1 {
+		t.Errorf("Expected exit code 1 over the limit, got %d", exitCode)
+	}
+	if expected := "number literal too long: exceeds the maximum length of 4 characters at line 1, Column 8:12"; !strings.Contains(stderr.String(), expected) {
+		t.Errorf("Expected stderr to contain %q, got %q", expected, stderr.String())
+	}
+
+	// A negative length disables the default limit
+	path = writeFile(t, "long.json", "["+strings.Repeat("1", lexer.DefaultMaxNumberLength+1)+"]")
+	if exitCode := run([]string{path}, io.Discard, io.Discard); exitCode != 1 {
+		t.Errorf("Expected exit code 1 over the default limit, got %d", exitCode)
+	}
+	if exitCode := run([]string{"-max-number-length=-1", path}, io.Discard, io.Discard); exitCode != 0 {
+		t.Errorf("Expected exit code 0 w/o a limit, got %d", exitCode)
+	}
+}
+
+func TestRunRequireKeys(t *testing.T) {
+	path := writeFile(t, "package.json", `{"name": "jl"}`)
+
+	if exitCode := run([]string{"-require-keys=name", path}, io.Discard, io.Discard); exitCode != 0 {
+		t.Errorf("Expected exit code 0, got %d", exitCode)
+	}
+
+	var stderr bytes.Buffer
+	if exitCode := run([]string{"-require-keys=name,version", path}, io.Discard, &stderr); exitCode != 1 {
+		t.Errorf("Expected exit code 1, got %d", exitCode)
+	}
+	if !strings.Contains(stderr.String(), "Error: Missing required key 'version' at line 1, Column 1:1") {
+		t.Errorf("Expected a missing key error, got %q", stderr.String())
+	}
+}
+
+func TestRunExpectType(t *testing.T) {
+	path := writeFile(t, "list.json", "[1, 2]")
+
+	if exitCode := run([]string{"-expect-type=array", path}, io.Discard, io.Discard); exitCode != 0 {
+		t.Errorf("Expected exit code 0, got %d", exitCode)
+	}
+
+	var stderr bytes.Buffer
+	if exitCode := run([]string{"-expect-type=object", path}, io.Discard, &stderr); exitCode != 1 {
+		t.Errorf("Expected exit code 1, got %d", exitCode)
+	}
+	if !strings.Contains(stderr.String(), "Error: Expected the root to be object, got array at line 1, Column 1:1") {
+		t.Errorf("Expected a root type error, got %q", stderr.String())
+	}
+
+	// Unknown types are rejected before validating anything
+	var stdout bytes.Buffer
+	if exitCode := run([]string{"-expect-type=list", path}, &stdout, io.Discard); exitCode != 1 {
+		t.Errorf("Expected exit code 1 for an unknown type, got %d", exitCode)
+	}
+	if !strings.Contains(stdout.String(), `invalid -expect-type: unknown root type "list", expected one of object, array, string, number, boolean, null`) {
+		t.Errorf("Expected an unknown type error, got %q", stdout.String())
+	}
+}
+
+func TestRunEnum(t *testing.T) {
+	path := writeFile(t, "service.json", `{"status": "stopped", "tier": "free"}`)
+
+	// Define test cases
+	testCases := []struct {
+		name             string
+		argv             []string
+		expectedExitCode int
+		expectedStderr   string
+	}{
+		{name: "matching values", argv: []string{"-enum=status=running,stopped", "-enum=tier=free,pro", path}, expectedExitCode: 0},
+		{
+			name:             "non-matching value",
+			argv:             []string{"-enum=status=active,inactive,pending", path},
+			expectedExitCode: 1,
+			expectedStderr:   "Error: Value \"stopped\" at 'status' is not one of the allowed values (active, inactive, pending) at line 1, Column 12:20",
+		},
+		{
+			name:             "missing path",
+			argv:             []string{"-enum=owner=team-a", path},
+			expectedExitCode: 1,
+			expectedStderr:   "Error: No value at path 'owner'",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			var stderr bytes.Buffer
+			if exitCode := run(testCase.argv, io.Discard, &stderr); exitCode != testCase.expectedExitCode {
+				t.Errorf("Expected exit code %d, got %d: %q", testCase.expectedExitCode, exitCode, stderr.String())
+			}
+			if !strings.Contains(stderr.String(), testCase.expectedStderr) {
+				t.Errorf("Expected stderr to contain %q, got %q", testCase.expectedStderr, stderr.String())
+			}
+		})
+	}
+}
+
+func TestRunForbidEmptyContainers(t *testing.T) {
+	path := writeFile(t, "config.json", `{"a": {}, "b": [[]]}`)
+
+	// Define test cases
+	testCases := []struct {
+		argv             []string
+		expectedWarnings []string
+	}{
+		{argv: []string{path}},
+		{argv: []string{"-forbid-empty-object", path}, expectedWarnings: []string{"Warning: Empty object not allowed at line 1, Column 7:7"}},
+		{argv: []string{"-forbid-empty-array", path}, expectedWarnings: []string{"Warning: Empty array not allowed at line 1, Column 17:17"}},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(strings.Join(testCase.argv[:len(testCase.argv)-1], " "), func(t *testing.T) {
+			var stderr bytes.Buffer
+			if exitCode := run(testCase.argv, io.Discard, &stderr); exitCode != 0 {
+				t.Errorf("Expected exit code 0, got %d", exitCode)
+			}
+
+			if count := strings.Count(stderr.String(), "Warning: "); count != len(testCase.expectedWarnings) {
+				t.Errorf("Expected %d warnings, got %q", len(testCase.expectedWarnings), stderr.String())
+			}
+			for _, warning := range testCase.expectedWarnings {
+				if !strings.Contains(stderr.String(), warning) {
+					t.Errorf("Expected warning %q, got %q", warning, stderr.String())
+				}
+			}
+		})
+	}
+}
+
+func TestRunHomogeneousArrays(t *testing.T) {
+	path := writeFile(t, "config.json", `{"ports": [80, "443"], "hosts": ["a", "b"]}`)
+
+	var stderr bytes.Buffer
+	if exitCode := run([]string{path}, io.Discard, &stderr); exitCode != 0 || strings.Contains(stderr.String(), "Warning: ") {
+		t.Errorf("Expected exit code 0 w/o warnings, got %d: %q", exitCode, stderr.String())
+	}
+
+	stderr.Reset()
+	if exitCode := run([]string{"-homogeneous-arrays", path}, io.Discard, &stderr); exitCode != 0 {
+		t.Errorf("Expected exit code 0, got %d", exitCode)
+	}
+	expected := "Warning: Array mixes element types, expected number like the 1st element, got string at line 1, Column 16:20"
+	if !strings.Contains(stderr.String(), expected) || strings.Count(stderr.String(), "Warning: ") != 1 {
+		t.Errorf("Expected a single warning %q, got %q", expected, stderr.String())
+	}
+}
+
+func TestRunAssertEmpty(t *testing.T) {
+	path := writeFile(t, "prod.json", `{"overrides": {}, "flags": ["debug"]}`)
+	rules := writeFile(t, "rules.json", `{"assert-empty": "warn"}`)
+
+	// Define test cases
+	testCases := []struct {
+		name             string
+		argv             []string
+		expectedExitCode int
+		expectedStderr   string
+	}{
+		{name: "empty value", argv: []string{"-assert-empty=overrides", path}, expectedExitCode: 0},
+		{name: "absent value", argv: []string{"-assert-empty=secrets", path}, expectedExitCode: 0},
+		{
+			name:             "non-empty value",
+			argv:             []string{"-assert-empty=overrides", "-assert-empty=flags", path},
+			expectedExitCode: 1,
+			expectedStderr:   "Error: Value at 'flags' must be an empty object or array, got [\"debug\"] at line 1, Column 28:28",
+		},
+		{
+			name:             "rules config",
+			argv:             []string{"-rules=" + rules, "-assert-empty=flags", path},
+			expectedExitCode: 0,
+			expectedStderr:   "Warning: Value at 'flags' must be an empty object or array",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			var stderr bytes.Buffer
+			if exitCode := run(testCase.argv, io.Discard, &stderr); exitCode != testCase.expectedExitCode {
+				t.Errorf("Expected exit code %d, got %d: %q", testCase.expectedExitCode, exitCode, stderr.String())
+			}
+			if testCase.expectedStderr == "" {
+				if strings.Contains(stderr.String(), "Error: ") {
+					t.Errorf("Expected no error, got %q", stderr.String())
+				}
+			} else if !strings.Contains(stderr.String(), testCase.expectedStderr) {
+				t.Errorf("Expected stderr to contain %q, got %q", testCase.expectedStderr, stderr.String())
+			}
+		})
+	}
+}
+
+func TestRunMaxLineLength(t *testing.T) {
+	pretty := writeFile(t, "pretty.json", "{\n  \"name\": \"jl\",\n  \"tags\": [\"json\", \"lint\"]\n}\n")
+	minified := writeFile(t, "minified.json", `{"name":"jl","tags":["json","lint"]}`)
+
+	var stderr bytes.Buffer
+	if exitCode := run([]string{"-max-line-length=30", pretty}, io.Discard, &stderr); exitCode != 0 || strings.Contains(stderr.String(), "Warning: ") {
+		t.Errorf("Expected exit code 0 w/o warnings, got %d: %q", exitCode, stderr.String())
+	}
+
+	stderr.Reset()
+	if exitCode := run([]string{"-max-line-length=30", minified}, io.Discard, &stderr); exitCode != 0 {
+		t.Errorf("Expected exit code 0, got %d", exitCode)
+	}
+	expected := "Warning: Line 1 is longer than 30 characters, the file may be minified at line 1, Column 31:31"
+	if !strings.Contains(stderr.String(), expected) || strings.Count(stderr.String(), "Warning: ") != 1 {
+		t.Errorf("Expected a single warning %q, got %q", expected, stderr.String())
+	}
+
+	// The warning can be turned into an error w/ the rules config
+	rules := writeFile(t, "rules.json", `{"max-line-length": "error"}`)
+	stderr.Reset()
+	if exitCode := run([]string{"-rules=" + rules, "-max-line-length=30", minified}, io.Discard, &stderr); exitCode != 1 {
+		t.Errorf("Expected exit code 1, got %d: %q", exitCode, stderr.String())
+	}
+	if !strings.Contains(stderr.String(), "Error: Line 1 is longer than 30 characters") {
+		t.Errorf("Expected an error, got %q", stderr.String())
+	}
+}
+
+func TestRunNumberOverflow(t *testing.T) {
+	path := writeFile(t, "config.json", `{"zero": 0e0, "one": 1E+000, "big": 1e400}`)
+
+	var stderr bytes.Buffer
+	if exitCode := run([]string{path}, io.Discard, &stderr); exitCode != 0 {
+		t.Errorf("Expected exit code 0, got %d: %q", exitCode, stderr.String())
+	}
+	expected := "Warning: Number '1e400' overflows a 64-bit float at line 1, Column 37:41"
+	if !strings.Contains(stderr.String(), expected) || strings.Count(stderr.String(), "Warning: ") != 1 {
+		t.Errorf("Expected a single warning %q, got %q", expected, stderr.String())
+	}
+
+	// The warning can be turned into an error, or off, w/ the rules config
+	rules := writeFile(t, "rules.json", `{"number-overflow": "error"}`)
+	stderr.Reset()
+	if exitCode := run([]string{"-rules=" + rules, path}, io.Discard, &stderr); exitCode != 1 {
+		t.Errorf("Expected exit code 1, got %d: %q", exitCode, stderr.String())
+	}
+	expected = "Error: Number '1e400' overflows a 64-bit float at line 1, Column 37:41"
+	if !strings.Contains(stderr.String(), expected) {
+		t.Errorf("Expected error %q, got %q", expected, stderr.String())
+	}
+
+	rulesOff := writeFile(t, "rules-off.json", `{"number-overflow": "off"}`)
+	stderr.Reset()
+	if exitCode := run([]string{"-rules=" + rulesOff, path}, io.Discard, &stderr); exitCode != 0 || strings.Contains(stderr.String(), "Warning: ") {
+		t.Errorf("Expected exit code 0 w/o warnings, got %d: %q", exitCode, stderr.String())
+	}
+}
+
+func TestRunRules(t *testing.T) {
+	path := writeFile(t, "config.json", `{"ports": [80, "443"], "hosts": [], "tags": {}}`)
+	rules := writeFile(t, "rules.json", `{"homogeneous-arrays": "error", "empty-container": "warn"}`)
+	rulesOff := writeFile(t, "rules-off.json", `{"homogeneous-arrays": "off", "empty-container": "off"}`)
+
+	// Define test cases
+	testCases := []struct {
+		name             string
+		argv             []string
+		expectedExitCode int
+		expectedStderr   []string
+	}{
+		{
+			name:             "config enables rules",
+			argv:             []string{"-rules", rules, path},
+			expectedExitCode: 1,
+			expectedStderr: []string{
+				"Error: Array mixes element types, expected number like the 1st element, got string at line 1, Column 16:20",
+				"Warning: Empty array not allowed at line 1, Column 33:33",
+				"Warning: Empty object not allowed at line 1, Column 45:45",
+			},
+		},
+		{name: "config disables rules", argv: []string{"-rules", rulesOff, path}, expectedExitCode: 0},
+		{
+			name:             "flag overrides config",
+			argv:             []string{"-rules", rulesOff, "-forbid-empty-array", path},
+			expectedExitCode: 0,
+			expectedStderr:   []string{"Warning: Empty array not allowed at line 1, Column 33:33"},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			var stderr bytes.Buffer
+			exitCode := run(testCase.argv, io.Discard, &stderr)
+
+			if exitCode != testCase.expectedExitCode {
+				t.Errorf("Expected exit code %d, got %d: %q", testCase.expectedExitCode, exitCode, stderr.String())
+			}
+			for _, expected := range testCase.expectedStderr {
+				if !strings.Contains(stderr.String(), expected) {
+					t.Errorf("Expected stderr to contain %q, got %q", expected, stderr.String())
+				}
+			}
+			if count := strings.Count(stderr.String(), "Warning: ") + strings.Count(stderr.String(), "Error: "); count != len(testCase.expectedStderr) {
+				t.Errorf("Expected %d findings, got %q", len(testCase.expectedStderr), stderr.String())
+			}
+		})
+	}
+}
+
+func TestRunUnwrap(t *testing.T) {
+	// Define test cases
+	testCases := []struct {
+		name             string
+		content          string
+		expectedExitCode int
+		expectedStderr   string
+	}{
+		{name: "valid payload", content: `{"event": {"body": "{\"id\": 1}"}}`, expectedExitCode: 0},
+		{
+			name:             "malformed payload",
+			content:          `{"event": {"body": "{\"id\": 1,}"}}`,
+			expectedExitCode: 1,
+			expectedStderr:   "Error: invalid JSON embedded at 'event.body': Invalid JSON Object, trailing comma not allowed at Line 1, Column 31:31",
+		},
+		{
+			name:             "missing payload",
+			content:          `{"event": {}}`,
+			expectedExitCode: 1,
+			expectedStderr:   "has no key 'body'",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			path := writeFile(t, "event.json", testCase.content)
+
+			var stderr bytes.Buffer
+			if exitCode := run([]string{"-unwrap=event.body", path}, io.Discard, &stderr); exitCode != testCase.expectedExitCode {
+				t.Errorf("Expected exit code %d, got %d", testCase.expectedExitCode, exitCode)
+			}
+			if !strings.Contains(stderr.String(), testCase.expectedStderr) {
+				t.Errorf("Expected stderr containing %q, got %q", testCase.expectedStderr, stderr.String())
+			}
+		})
+	}
+}
+
+func TestRunMaxErrors(t *testing.T) {
+	// An object w/ 10 duplicates of the same key
+	path := writeFile(t, "duplicates.json", duplicateKeys(10))
+
+	// Below the cap, every diagnostic is reported
+	var stderr bytes.Buffer
+	if exitCode := run([]string{"-disallow-duplicate-keys", path}, io.Discard, &stderr); exitCode != 1 {
+		t.Errorf("Expected exit code 1, got %d", exitCode)
+	}
+	if count := strings.Count(stderr.String(), "Error: "); count != 10 {
+		t.Errorf("Expected 10 errors, got %d", count)
+	}
+
+	// Over the cap, the diagnostics are truncated w/ a note
+	stderr.Reset()
+	var stdout bytes.Buffer
+	other := writeFile(t, "other.json", `{}`)
+	if exitCode := run([]string{"-disallow-duplicate-keys", "-max-errors=3", path, other}, &stdout, &stderr); exitCode != 1 {
+		t.Errorf("Expected exit code 1, got %d", exitCode)
+	}
+	if count := strings.Count(stderr.String(), "Error: Duplicate key"); count != 3 {
+		t.Errorf("Expected 3 errors, got %d", count)
+	}
+	if !strings.Contains(stderr.String(), "Error: too many errors (3), stopping") {
+		t.Errorf("Expected a truncation note, got %q", stderr.String())
+	}
+	// The files after the cap is reached are not validated
+	if strings.Contains(stdout.String(), other) {
+		t.Errorf("Expected %s not to be validated, got %q", other, stdout.String())
+	}
+}
+
+func TestRunMaxErrorsWarningsOnly(t *testing.T) {
+	// The warnings of a valid file don't count toward the cap, nor change the exit code
+	path := writeFile(t, "duplicates.json", duplicateKeys(150))
+
+	var stderr bytes.Buffer
+	if exitCode := run([]string{"-duplicate-keys=warn", path}, io.Discard, &stderr); exitCode != 0 {
+		t.Errorf("Expected exit code 0, got %d", exitCode)
+	}
+	if count := strings.Count(stderr.String(), "Warning: "); count != 150 {
+		t.Errorf("Expected 150 warnings, got %d", count)
+	}
+	if strings.Contains(stderr.String(), "too many errors") || !strings.Contains(stderr.String(), "is valid") {
+		t.Errorf("Expected the file to be valid w/o a truncation note, got %q", stderr.String())
+	}
+}
+
+// duplicateKeys returns an object w/ n duplicates of the same key
+func duplicateKeys(n int) string {
+	var sb strings.Builder
+	sb.WriteString(`{"a": 0`)
+	for i := 1; i <= n; i++ {
+		sb.WriteString(`, "a": 0`)
+	}
+	sb.WriteString(`}`)
+	return sb.String()
+}
+
+func TestRunFirstErrorOnly(t *testing.T) {
+	// Each file has 2 errors
+	missingKeys := writeFile(t, "missing.json", `{}`)
+	duplicates := writeFile(t, "duplicates.json", `{"a": 0, "b": 0, "a": 1, "a": 2}`)
+	argv := []string{"-require-keys=a,b", "-duplicate-keys=error", missingKeys, duplicates}
+
+	var stderr bytes.Buffer
+	if exitCode := run(argv, io.Discard, &stderr); exitCode != 1 {
+		t.Errorf("Expected exit code 1, got %d", exitCode)
+	}
+	if count := strings.Count(stderr.String(), "Error: "); count != 4 {
+		t.Errorf("Expected 4 errors, got %d: %q", count, stderr.String())
+	}
+
+	// Only the 1st error of each file is reported, but every file is still validated
+	stderr.Reset()
+	var stdout bytes.Buffer
+	if exitCode := run(append([]string{"-first-error-only"}, argv...), &stdout, &stderr); exitCode != 1 {
+		t.Errorf("Expected exit code 1, got %d", exitCode)
+	}
+	expected := []string{"Missing required key 'a'", "Duplicate key 'a' at line 1, Column 18:20"}
+	errs := strings.Split(strings.TrimSuffix(stderr.String(), "\n"), "\n")
+	if len(errs) != len(expected) {
+		t.Fatalf("Expected %d errors, got %q", len(expected), stderr.String())
+	}
+	for i, err := range errs {
+		if !strings.Contains(err, expected[i]) {
+			t.Errorf("Expected error %d to contain %q, got %q", i+1, expected[i], err)
+		}
+	}
+	if stdout.String() != missingKeys+"\n"+duplicates+"\n" {
+		t.Errorf("Expected both files to be validated, got %q", stdout.String())
+	}
+
+	// A warning reported before the 1st error is dropped
+	path := writeFile(t, "warning.json", `{"a": 1, "a": 2}`)
+	stderr.Reset()
+	if exitCode := run([]string{"-first-error-only", "-duplicate-keys=warn", "-require-keys=name", path}, io.Discard, &stderr); exitCode != 1 {
+		t.Errorf("Expected exit code 1, got %d", exitCode)
+	}
+	if strings.Contains(stderr.String(), "Warning: ") || !strings.Contains(stderr.String(), "Error: Missing required key 'name'") {
+		t.Errorf("Expected only the missing key error, got %q", stderr.String())
+	}
+}
+
+func TestRunSchemaDraft(t *testing.T) {
+	// The first element must be an integer & the others strings, but only when interpreted as 2020-12
+	schemaPath := writeFile(t, "schema.json", `{"prefixItems": [{"type": "integer"}], "items": {"type": "string"}}`)
+	path := writeFile(t, "tuple.json", `[1, "a"]`)
+
+	if exitCode := run([]string{"-schema=" + schemaPath, "-schema-draft=2020-12", path}, io.Discard, io.Discard); exitCode != 0 {
+		t.Errorf("Expected exit code 0 w/ draft 2020-12, got %d", exitCode)
+	}
+
+	// draft-07 (the default) ignores "prefixItems", so every element must be a string
+	var stderr bytes.Buffer
+	if exitCode := run([]string{"-schema=" + schemaPath, path}, io.Discard, &stderr); exitCode != 1 {
+		t.Errorf("Expected exit code 1 w/ draft-07, got %d", exitCode)
+	}
+	if !strings.Contains(stderr.String(), "Error: Value of $[0] must be of type 'string', got Number at line 1, Column 2:2") {
+		t.Errorf("Expected a schema error, got %q", stderr.String())
+	}
+
+	// Unknown drafts are rejected when loading the schema
+	stderr.Reset()
+	if exitCode := run([]string{"-schema=" + schemaPath, "-schema-draft=draft-04", path}, io.Discard, &stderr); exitCode != 1 {
+		t.Errorf("Expected exit code 1 for an unknown draft, got %d", exitCode)
+	}
+	if !strings.Contains(stderr.String(), `Error: unknown schema draft "draft-04"`) {
+		t.Errorf("Expected an unknown draft error, got %q", stderr.String())
+	}
+}
+
+func TestRunDryRun(t *testing.T) {
+	root := t.TempDir()
+	for _, name := range []string{"a.json", "invalid.json", "nested/b.jsonc", "nested/notes.txt", "node_modules/pkg/package.json"} {
+		path := filepath.Join(root, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatalf("Failed to create %s: %v", filepath.Dir(path), err)
+		}
+		// The files are never lexed, so their content doesn't matter
+		if err := os.WriteFile(path, []byte(`{,}`), 0o644); err != nil {
+			t.Fatalf("Failed to write %s: %v", path, err)
+		}
+	}
+	manifest := writeFile(t, "list.txt", "extra.json\n")
+
+	var stdout bytes.Buffer
+	if exitCode := run([]string{"-dry-run", "-ignore=node_modules", root, "@" + manifest}, &stdout, io.Discard); exitCode != 0 {
+		t.Errorf("Expected exit code 0, got %d", exitCode)
+	}
+
+	expectedStdout := strings.Join([]string{
+		filepath.Join(root, "a.json"),
+		filepath.Join(root, "invalid.json"),
+		filepath.Join(root, "nested/b.jsonc"),
+		"extra.json",
+	}, "\n") + "\n"
+	if stdout.String() != expectedStdout {
+		t.Errorf("Expected stdout %q, got %q", expectedStdout, stdout.String())
+	}
+}
+
+func TestRunMaxFiles(t *testing.T) {
+	root := t.TempDir()
+	for _, name := range []string{"a.json", "b.json", "nested/c.json"} {
+		path := filepath.Join(root, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatalf("Failed to create %s: %v", filepath.Dir(path), err)
+		}
+		if err := os.WriteFile(path, []byte(`{"a": 1}`), 0o644); err != nil {
+			t.Fatalf("Failed to write %s: %v", path, err)
+		}
+	}
+	invalid := writeFile(t, "invalid.json", `[1, 2`)
+	warning := "Warning: too many files, the run is truncated to the first 3"
+
+	// The files past the cap aren't validated, the invalid one included
+	var stderr bytes.Buffer
+	if exitCode := run([]string{"-max-files=3", root, invalid}, io.Discard, &stderr); exitCode != 0 {
+		t.Errorf("Expected exit code 0, got %d: %q", exitCode, stderr.String())
+	}
+	if !strings.Contains(stderr.String(), warning) {
+		t.Errorf("Expected stderr to contain %q, got %q", warning, stderr.String())
+	}
+	if valid := strings.Count(stderr.String(), "is valid"); valid != 3 {
+		t.Errorf("Expected 3 valid files, got %d: %q", valid, stderr.String())
+	}
+
+	// The run isn't truncated if there are no more files than the cap
+	stderr.Reset()
+	if exitCode := run([]string{"-max-files=3", root}, io.Discard, &stderr); exitCode != 0 {
+		t.Errorf("Expected exit code 0, got %d: %q", exitCode, stderr.String())
+	}
+	if strings.Contains(stderr.String(), "Warning") {
+		t.Errorf("Expected no warning, got %q", stderr.String())
+	}
+
+	// The files listed by a dry run are capped as well
+	var stdout bytes.Buffer
+	stderr.Reset()
+	if exitCode := run([]string{"-dry-run", "-max-files=2", root}, &stdout, &stderr); exitCode != 0 {
+		t.Errorf("Expected exit code 0, got %d", exitCode)
+	}
+	if expected := filepath.Join(root, "a.json") + "\n" + filepath.Join(root, "b.json") + "\n"; stdout.String() != expected {
+		t.Errorf("Expected stdout %q, got %q", expected, stdout.String())
+	}
+	if expected := "Warning: too many files, the run is truncated to the first 2"; !strings.Contains(stderr.String(), expected) {
+		t.Errorf("Expected stderr to contain %q, got %q", expected, stderr.String())
+	}
+}
+
+func TestRunSummaryJSON(t *testing.T) {
+	valid := writeFile(t, "valid.json", `{"a": 1}`)
+	invalid := writeFile(t, "invalid.json", `[1, 2`)
+	duplicates := writeFile(t, "duplicates.json", `{"a": 1, "a": 2, "a": 3}`)
+
+	var stdout bytes.Buffer
+	argv := []string{"-summary-json", "-disallow-duplicate-keys", valid, invalid, duplicates}
+	if exitCode := run(argv, &stdout, io.Discard); exitCode != 1 {
+		t.Errorf("Expected exit code 1, got %d", exitCode)
+	}
+
+	var actual summary
+	if err := json.Unmarshal(stdout.Bytes(), &actual); err != nil {
+		t.Fatalf("Expected a single JSON object, got %q", stdout.String())
+	}
+
+	expected := summary{
+		Total:   3,
+		Valid:   1,
+		Invalid: 2,
+		Files: []fileResult{
+			{Path: valid, Valid: true, ErrorCount: 0},
+			{Path: invalid, Valid: false, ErrorCount: 1},
+			{Path: duplicates, Valid: false, ErrorCount: 2},
+		},
+	}
+	if !reflect.DeepEqual(expected, actual) {
+		t.Errorf("Expected summary %+v, got %+v", expected, actual)
+	}
+}
+
+func TestRunCheckstyle(t *testing.T) {
+	valid := writeFile(t, "valid.json", `{"a": 1}`)
+	duplicates := writeFile(t, "duplicates.json", "{\"<a&b>\": 1, \"<a&b>\": 2,\n \"x\": 1, \"x\": 2}")
+	invalid := writeFile(t, "invalid.json", `[1, 2`)
+
+	var stdout bytes.Buffer
+	argv := []string{"-report-format=checkstyle", "-disallow-duplicate-keys", valid, duplicates, invalid}
+	if exitCode := run(argv, &stdout, io.Discard); exitCode != 1 {
+		t.Errorf("Expected exit code 1, got %d", exitCode)
+	}
+
+	// XML special characters of the messages are escaped
+	if !strings.HasPrefix(stdout.String(), `<?xml version="1.0" encoding="UTF-8"?>`) || !strings.Contains(stdout.String(), "&lt;a&amp;b&gt;") {
+		t.Errorf("Expected an XML document w/ escaped messages, got %q", stdout.String())
+	}
+
+	var actual checkstyleReport
+	if err := xml.Unmarshal(stdout.Bytes(), &actual); err != nil {
+		t.Fatalf("Expected a single XML document, got %v: %q", err, stdout.String())
+	}
+	expected := checkstyleReport{
+		XMLName: xml.Name{Local: "checkstyle"},
+		Version: "4.3",
+		Files: []checkstyleFile{
+			{Name: valid},
+			{Name: duplicates, Errors: []checkstyleError{
+				{Line: 1, Column: 14, Severity: "error", Message: "Duplicate key '<a&b>'", Source: "jl.duplicate-keys"},
+				{Line: 2, Column: 10, Severity: "error", Message: "Duplicate key 'x'", Source: "jl.duplicate-keys"},
+			}},
+			{Name: invalid, Errors: []checkstyleError{
+				{Line: 1, Column: 6, Severity: "error", Message: "Expected ',' or ']' after array element, got 'EOF' at line 1, Column 6:6"},
+			}},
+		},
+	}
+	if !reflect.DeepEqual(expected, actual) {
+		t.Errorf("Expected report %+v, got %+v", expected, actual)
+	}
+}
+
+func TestRunOutput(t *testing.T) {
+	valid := writeFile(t, "valid.json", `{"a": [1, 2]}`)
+	invalid := writeFile(t, "invalid.json", `[1, 2`)
+
+	// Define test cases
+	testCases := []struct {
+		name             string
+		argv             []string
+		expectedExitCode int
+		expectedReport   string
+		expectedStderr   string
+		expectedStatus   string
+	}{
+		{
+			name:             "diagnostics",
+			argv:             []string{valid, invalid},
+			expectedExitCode: 1,
+			expectedReport: fmt.Sprintf("%s\nJSON file located in %s is valid\n%s\nError: Expected ',' or ']' after array element, got 'EOF' at line 1, Column 6:6\n",
+				valid, valid, invalid),
+			expectedStatus: "problems were found",
+		},
+		{
+			name:             "errors only",
+			argv:             []string{"-only-errors", valid, invalid},
+			expectedExitCode: 1,
+			expectedReport:   fmt.Sprintf("Error: %s: Expected ',' or ']' after array element, got 'EOF' at line 1, Column 6:6\n", invalid),
+			expectedStatus:   "problems were found",
+		},
+		{
+			name:             "summary",
+			argv:             []string{"-summary-json", valid},
+			expectedExitCode: 0,
+			expectedReport:   fmt.Sprintf("{\n  \"total\": 1,\n  \"valid\": 1,\n  \"invalid\": 0,\n  \"files\": [\n    {\n      \"path\": %q,\n      \"valid\": true,\n      \"errorCount\": 0\n    }\n  ]\n}\n", valid),
+			expectedStatus:   "no problems found",
+		},
+		{
+			name:             "formatted document",
+			argv:             []string{"-format", valid},
+			expectedExitCode: 0,
+			expectedReport:   "{\n  \"a\": [\n    1,\n    2\n  ]\n}\n",
+			expectedStatus:   "no problems found",
+		},
+		{
+			// The diagnostics of a report flag stay on stderr
+			name:             "formatted invalid document",
+			argv:             []string{"-format", invalid},
+			expectedExitCode: 1,
+			expectedReport:   "",
+			expectedStderr:   "Error: Expected ',' or ']' after array element, got 'EOF' at line 1, Column 6:6",
+			expectedStatus:   "problems were found",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			// The parent directories are created
+			output := filepath.Join(t.TempDir(), "reports", "ci", "report.txt")
+
+			var stdout, stderr bytes.Buffer
+			argv := append([]string{"-output", output}, testCase.argv...)
+			if exitCode := run(argv, &stdout, &stderr); exitCode != testCase.expectedExitCode {
+				t.Errorf("Expected exit code %d, got %d: %q", testCase.expectedExitCode, exitCode, stderr.String())
+			}
+
+			report, err := os.ReadFile(output)
+			if err != nil {
+				t.Fatalf("Expected the report to be written, got %v", err)
+			}
+			if string(report) != testCase.expectedReport {
+				t.Errorf("Expected report %q, got %q", testCase.expectedReport, string(report))
+			}
+			if stdout.Len() != 0 {
+				t.Errorf("Expected nothing on stdout, got %q", stdout.String())
+			}
+			if !strings.Contains(stderr.String(), testCase.expectedStderr) {
+				t.Errorf("Expected stderr to contain %q, got %q", testCase.expectedStderr, stderr.String())
+			}
+			expectedStatus := fmt.Sprintf("Report written to %s, %s", output, testCase.expectedStatus)
+			if !strings.Contains(stderr.String(), expectedStatus) {
+				t.Errorf("Expected the status line %q, got %q", expectedStatus, stderr.String())
+			}
+		})
+	}
+}
+
+func TestRunOutputReportFormats(t *testing.T) {
+	valid := writeFile(t, "valid.json", `{"a": [1, 2]}`)
+	duplicates := writeFile(t, "duplicates.json", `{"x": 1, "x": 2}`)
+	invalid := writeFile(t, "invalid.json", `[1, 2`)
+	syntaxErr := "Expected ',' or ']' after array element, got 'EOF' at line 1, Column 6:6"
+
+	// Define test cases, the report is decoded from the file named after its format
+	testCases := []struct {
+		format         string
+		output         string
+		decode         func(data []byte) (interface{}, error)
+		expectedReport interface{}
+	}{
+		{
+			format: args.FormatCheckstyle,
+			output: "report.xml",
+			decode: func(data []byte) (interface{}, error) {
+				var report checkstyleReport
+				err := xml.Unmarshal(data, &report)
+				return report, err
+			},
+			expectedReport: checkstyleReport{
+				XMLName: xml.Name{Local: "checkstyle"},
+				Version: "4.3",
+				Files: []checkstyleFile{
+					{Name: valid},
+					{Name: duplicates, Errors: []checkstyleError{{Line: 1, Column: 10, Severity: "warning", Message: "Duplicate key 'x'", Source: "jl.duplicate-keys"}}},
+					{Name: invalid, Errors: []checkstyleError{{Line: 1, Column: 6, Severity: "error", Message: syntaxErr}}},
+				},
+			},
+		},
+		{
+			format: args.FormatJSON,
+			output: "report.json",
+			decode: func(data []byte) (interface{}, error) {
+				var report jsonReport
+				err := json.Unmarshal(data, &report)
+				return report, err
+			},
+			expectedReport: jsonReport{Files: []jsonReportFile{
+				{Path: valid, Valid: true, Problems: []serveProblem{}},
+				{Path: duplicates, Valid: true, Problems: []serveProblem{
+					{Severity: "warning", Rule: "duplicate-keys", Message: "Duplicate key 'x' at line 1, Column 10:12", Line: 1, ColStart: 10, ColEnd: 12, Path: "$.x"},
+				}},
+				{Path: invalid, Valid: false, Problems: []serveProblem{
+					{Severity: "error", Message: syntaxErr, Line: 1, ColStart: 6, ColEnd: 6, Path: "$"},
+				}},
+			}},
+		},
+		{
+			format: args.FormatSARIF,
+			output: "report.sarif",
+			decode: func(data []byte) (interface{}, error) {
+				var report sarifLog
+				err := json.Unmarshal(data, &report)
+				return report, err
+			},
+			expectedReport: sarifLog{Version: "2.1.0", Schema: "https://json.schemastore.org/sarif-2.1.0.json", Runs: []sarifRun{{
+				Tool: sarifTool{Driver: sarifDriver{Name: "jl"}},
+				Results: []sarifResult{
+					{RuleID: "duplicate-keys", Level: "warning", Message: sarifMessage{Text: "Duplicate key 'x'"}, Locations: []sarifLocation{{
+						PhysicalLocation: sarifPhysicalLocation{ArtifactLocation: sarifArtifactLocation{URI: duplicates}, Region: &sarifRegion{StartLine: 1, StartColumn: 10, EndColumn: 13}},
+					}}},
+					{Level: "error", Message: sarifMessage{Text: syntaxErr}, Locations: []sarifLocation{{
+						PhysicalLocation: sarifPhysicalLocation{ArtifactLocation: sarifArtifactLocation{URI: invalid}, Region: &sarifRegion{StartLine: 1, StartColumn: 6, EndColumn: 7}},
+					}}},
+				},
+			}}},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.format, func(t *testing.T) {
+			output := filepath.Join(t.TempDir(), "reports", testCase.output)
+
+			var stdout, stderr bytes.Buffer
+			argv := []string{"-output", output, "-report-format", testCase.format, "-duplicate-keys=warn", valid, duplicates, invalid}
+			if exitCode := run(argv, &stdout, &stderr); exitCode != 1 {
+				t.Errorf("Expected exit code 1, got %d: %q", exitCode, stderr.String())
+			}
+			if stdout.Len() != 0 {
+				t.Errorf("Expected nothing on stdout, got %q", stdout.String())
+			}
+			expectedStatus := fmt.Sprintf("Report written to %s, problems were found", output)
+			if !strings.Contains(stderr.String(), expectedStatus) {
+				t.Errorf("Expected the status line %q, got %q", expectedStatus, stderr.String())
+			}
+
+			data, err := os.ReadFile(output)
+			if err != nil {
+				t.Fatalf("Expected the report to be written, got %v", err)
+			}
+			report, err := testCase.decode(data)
+			if err != nil {
+				t.Fatalf("Expected a %s report, got %v: %q", testCase.format, err, data)
+			}
+			if !reflect.DeepEqual(testCase.expectedReport, report) {
+				t.Errorf("Expected report %+v, got %+v", testCase.expectedReport, report)
+			}
+		})
+	}
+}
+
+func TestRunCompare(t *testing.T) {
+	a := writeFile(t, "a.json", `{"name": "jl", "version": 1, "tags": ["cli"]}`)
+	b := writeFile(t, "b.json", "{\n\t\"tags\": [\"cli\"],\n\t\"version\": 2,\n\t\"license\": \"MIT\"\n}")
+	invalid := writeFile(t, "invalid.json", `{"a": 1,}`)
+
+	// Define test cases
+	testCases := []struct {
+		name             string
+		files            []string
+		expectedExitCode int
+		expectedStdout   string
+	}{
+		{name: "same file", files: []string{a, a}, expectedExitCode: 0},
+		{
+			name:             "different files",
+			files:            []string{a, b},
+			expectedExitCode: 1,
+			expectedStdout:   "removed $.name: \"jl\"\nchanged $.version: 1 -> 2\nadded $.license: \"MIT\"\n",
+		},
+		{name: "invalid file", files: []string{a, invalid}, expectedExitCode: 1},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			var stdout bytes.Buffer
+			if exitCode := run(append([]string{"-compare"}, testCase.files...), &stdout, io.Discard); exitCode != testCase.expectedExitCode {
+				t.Errorf("Expected exit code %d, got %d", testCase.expectedExitCode, exitCode)
+			}
+			if stdout.String() != testCase.expectedStdout {
+				t.Errorf("Expected stdout %q, got %q", testCase.expectedStdout, stdout.String())
+			}
+		})
+	}
+}
+
+func TestRunFormat(t *testing.T) {
+	path := writeFile(t, "config.json", `{"name":"jl","ports":[80,443]}`)
+
+	var stdout bytes.Buffer
+	if exitCode := run([]string{"-format", "-indent=tab", path}, &stdout, io.Discard); exitCode != 0 {
+		t.Errorf("Expected exit code 0, got %d", exitCode)
+	}
+
+	// Only the formatted document is printed
+	expected := "{\n\t\"name\": \"jl\",\n\t\"ports\": [\n\t\t80,\n\t\t443\n\t]\n}\n"
+	if stdout.String() != expected {
+		t.Errorf("Expected stdout %q, got %q", expected, stdout.String())
+	}
+}
+
+func TestRunFormatNormalizeNumbers(t *testing.T) {
+	path := writeFile(t, "config.json", `[1.0, 1.50E5, 2e-05, -0.0, 10]`)
+
+	// Define test cases
+	testCases := []struct {
+		argv     []string
+		expected string
+	}{
+		{argv: []string{"-format", "-indent=0", path}, expected: "[\n1.0,\n1.50E5,\n2e-05,\n-0.0,\n10\n]\n"},
+		{argv: []string{"-format", "-indent=0", "-normalize-numbers", path}, expected: "[\n1,\n1.5e5,\n2e-5,\n-0,\n10\n]\n"},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(strings.Join(testCase.argv[:len(testCase.argv)-1], " "), func(t *testing.T) {
+			var stdout bytes.Buffer
+			if exitCode := run(testCase.argv, &stdout, io.Discard); exitCode != 0 {
+				t.Errorf("Expected exit code 0, got %d", exitCode)
+			}
+			if stdout.String() != testCase.expected {
+				t.Errorf("Expected stdout %q, got %q", testCase.expected, stdout.String())
+			}
+		})
+	}
+}
+
+func TestRunKeysOnly(t *testing.T) {
+	valid := writeFile(t, "config.json", `{"server": {"port": 80, "host": "localhost"}, "users": [{"name": "a"}, {"email": "b"}]}`)
+	invalid := writeFile(t, "invalid.json", `{"server": {"port": 80,}}`)
+
+	var stdout bytes.Buffer
+	if exitCode := run([]string{"-keys-only", valid}, &stdout, io.Discard); exitCode != 0 {
+		t.Errorf("Expected exit code 0, got %d", exitCode)
+	}
+
+	// Only the sorted key paths are printed
+	expected := "server\nserver.host\nserver.port\nusers\nusers[].email\nusers[].name\n"
+	if stdout.String() != expected {
+		t.Errorf("Expected stdout %q, got %q", expected, stdout.String())
+	}
+
+	// An invalid document is reported instead
+	stdout.Reset()
+	var stderr bytes.Buffer
+	if exitCode := run([]string{"-keys-only", invalid}, &stdout, &stderr); exitCode != 1 {
+		t.Errorf("Expected exit code 1, got %d", exitCode)
+	}
+	if stdout.Len() != 0 || !strings.Contains(stderr.String(), "Error: ") {
+		t.Errorf("Expected only the parse error, got stdout %q & stderr %q", stdout.String(), stderr.String())
+	}
+}
+
+func TestRunTokensJSON(t *testing.T) {
+	path := writeFile(t, "config.json", "{\"a\":\n  [1, true]}")
+
+	var stdout bytes.Buffer
+	if exitCode := run([]string{"-tokens-json", path}, &stdout, io.Discard); exitCode != 0 {
+		t.Errorf("Expected exit code 0, got %d", exitCode)
+	}
+
+	// Only the tokens are printed
+	var actual []map[string]interface{}
+	if err := json.Unmarshal(stdout.Bytes(), &actual); err != nil {
+		t.Fatalf("Expected a JSON array, got %q (%v)", stdout.String(), err)
+	}
+	expected := []map[string]interface{}{
+		{"type": "LBRACE", "lexeme": "{", "line": 1.0, "colStart": 1.0, "colEnd": 1.0},
+		{"type": "STR", "lexeme": "a", "line": 1.0, "colStart": 2.0, "colEnd": 4.0},
+		{"type": "COLON", "lexeme": ":", "line": 1.0, "colStart": 5.0, "colEnd": 5.0},
+		{"type": "LBRACKET", "lexeme": "[", "line": 2.0, "colStart": 3.0, "colEnd": 3.0},
+		{"type": "NUM", "lexeme": "1", "line": 2.0, "colStart": 4.0, "colEnd": 4.0},
+		{"type": "COMMA", "lexeme": ",", "line": 2.0, "colStart": 5.0, "colEnd": 5.0},
+		{"type": "TRUE", "lexeme": "true", "line": 2.0, "colStart": 7.0, "colEnd": 10.0},
+		{"type": "RBRACKET", "lexeme": "]", "line": 2.0, "colStart": 11.0, "colEnd": 11.0},
+		{"type": "RBRACE", "lexeme": "}", "line": 2.0, "colStart": 12.0, "colEnd": 12.0},
+	}
+	if !reflect.DeepEqual(expected, actual) {
+		t.Errorf("Expected tokens %v, got %v", expected, actual)
+	}
+
+	// The whitespace & newlines are printed as tokens too w/ -emit-whitespace
+	stdout.Reset()
+	if exitCode := run([]string{"-tokens-json", "-emit-whitespace", path}, &stdout, io.Discard); exitCode != 0 {
+		t.Errorf("Expected exit code 0, got %d", exitCode)
+	}
+	actual = nil
+	if err := json.Unmarshal(stdout.Bytes(), &actual); err != nil {
+		t.Fatalf("Expected a JSON array, got %q (%v)", stdout.String(), err)
+	}
+	expected = append(expected[:3:3],
+		map[string]interface{}{"type": "NEWLINE", "lexeme": "\n", "line": 1.0, "colStart": 6.0, "colEnd": 6.0},
+		map[string]interface{}{"type": "WS", "lexeme": "  ", "line": 2.0, "colStart": 1.0, "colEnd": 2.0},
+		expected[3], expected[4], expected[5],
+		map[string]interface{}{"type": "WS", "lexeme": " ", "line": 2.0, "colStart": 6.0, "colEnd": 6.0},
+		expected[6], expected[7], expected[8],
+	)
+	if !reflect.DeepEqual(expected, actual) {
+		t.Errorf("Expected tokens %v, got %v", expected, actual)
+	}
+}
+
+func TestRunCanonical(t *testing.T) {
+	path := writeFile(t, "config.json", "{\n  \"version\": 2.50,\n  \"name\": \"jl\",\n  \"limits\": {\"max\": 1E3, \"min\": -0}\n}\n")
+
+	var stdout bytes.Buffer
+	if exitCode := run([]string{"-canonical", path}, &stdout, io.Discard); exitCode != 0 {
+		t.Errorf("Expected exit code 0, got %d", exitCode)
+	}
+
+	// Only the canonical document is printed, w/o a trailing newline
+	expected := `{"limits":{"max":1000,"min":0},"name":"jl","version":2.5}`
+	if stdout.String() != expected {
+		t.Errorf("Expected stdout %q, got %q", expected, stdout.String())
+	}
+}
+
+func TestRunFinalNewline(t *testing.T) {
+	// Define test cases
+	testCases := []struct {
+		name            string
+		content         string
+		flag            string
+		expectedWarning string // Empty if no warning is expected
+	}{
+		{name: "required, zero", content: `{}`, flag: "-require-final-newline", expectedWarning: "Warning: Missing final newline"},
+		{name: "required, one", content: "{}\n", flag: "-require-final-newline"},
+		{name: "required, multiple", content: "{}\n\n", flag: "-require-final-newline", expectedWarning: "Warning: Expected a single final newline, got 2"},
+		{name: "forbidden, zero", content: `{}`, flag: "-no-final-newline"},
+		{name: "forbidden, one", content: "{}\n", flag: "-no-final-newline", expectedWarning: "Warning: Unexpected final newline"},
+		{name: "forbidden, multiple", content: "{}\n\n\n", flag: "-no-final-newline", expectedWarning: "Warning: Unexpected final newline"},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			path := writeFile(t, "newline.json", testCase.content)
+
+			// A warning doesn't make the file invalid
+			var stderr bytes.Buffer
+			if exitCode := run([]string{testCase.flag, path}, io.Discard, &stderr); exitCode != 0 {
+				t.Errorf("Expected exit code 0, got %d", exitCode)
+			}
+
+			if testCase.expectedWarning == "" {
+				if strings.Contains(stderr.String(), "Warning:") {
+					t.Errorf("Expected no warning, got %q", stderr.String())
+				}
+			} else if !strings.Contains(stderr.String(), testCase.expectedWarning) {
+				t.Errorf("Expected warning %q, got %q", testCase.expectedWarning, stderr.String())
+			}
+		})
+	}
+}
+
+func TestRunMixedIndentation(t *testing.T) {
+	tabs := writeFile(t, "tabs.json", "{\n\t\"a\": [\n\t\t1\n\t]\n}\n")
+	mixed := writeFile(t, "mixed.json", "{\n\t\"a\": [\n        1\n\t]\n}\n")
+
+	var stderr bytes.Buffer
+	if exitCode := run([]string{"-preserve-trivia", tabs}, io.Discard, &stderr); exitCode != 0 {
+		t.Errorf("Expected exit code 0, got %d", exitCode)
+	}
+	if strings.Contains(stderr.String(), "Warning:") {
+		t.Errorf("Expected no warning, got %q", stderr.String())
+	}
+
+	// Mixed indentation is only a warning
+	stderr.Reset()
+	if exitCode := run([]string{"-preserve-trivia", mixed}, io.Discard, &stderr); exitCode != 0 {
+		t.Errorf("Expected exit code 0, got %d", exitCode)
+	}
+	expected := "Warning: Mixed indentation, line indented w/ spaces while line 2 is indented w/ tabs at line 3, Column 1:8"
+	if !strings.Contains(stderr.String(), expected) {
+		t.Errorf("Expected warning %q, got %q", expected, stderr.String())
+	}
+
+	// The check only runs when the trivia is preserved
+	stderr.Reset()
+	run([]string{mixed}, io.Discard, &stderr)
+	if strings.Contains(stderr.String(), "Warning:") {
+		t.Errorf("Expected no warning w/o -preserve-trivia, got %q", stderr.String())
+	}
+}
+
+func TestRunExitZero(t *testing.T) {
+	path := writeFile(t, "invalid.json", `{"a": 1,}`)
+
+	var stderr bytes.Buffer
+	if exitCode := run([]string{"-exit-zero", path}, io.Discard, &stderr); exitCode != 0 {
+		t.Errorf("Expected exit code 0, got %d", exitCode)
+	}
+
+	// The error is still printed
+	if !strings.Contains(stderr.String(), "Error:") {
+		t.Errorf("Expected the error to be printed, got %q", stderr.String())
+	}
+}
+
+func TestRunOnlyErrors(t *testing.T) {
+	valid := writeFile(t, "valid.json", `{"a": 1, "a": 2}`)
+	invalid := writeFile(t, "invalid.json", `{"a": 1,}`)
+
+	// Nothing is printed when every file is valid, not even the warnings
+	var stdout, stderr bytes.Buffer
+	if exitCode := run([]string{"-only-errors", valid, valid}, &stdout, &stderr); exitCode != 0 {
+		t.Errorf("Expected exit code 0, got %d", exitCode)
+	}
+	if stdout.Len() != 0 || stderr.Len() != 0 {
+		t.Errorf("Expected no output, got stdout %q & stderr %q", stdout.String(), stderr.String())
+	}
+
+	// Only the error of the invalid file is printed, prefixed w/ the file
+	stdout.Reset()
+	stderr.Reset()
+	if exitCode := run([]string{"-only-errors", valid, invalid}, &stdout, &stderr); exitCode != 1 {
+		t.Errorf("Expected exit code 1, got %d", exitCode)
+	}
+	if stdout.Len() != 0 {
+		t.Errorf("Expected no output to stdout, got %q", stdout.String())
+	}
+	if lines := strings.Split(strings.TrimSuffix(stderr.String(), "\n"), "\n"); len(lines) != 1 || !strings.Contains(lines[0], "Error: "+invalid+": ") {
+		t.Errorf("Expected a single error prefixed w/ %s, got %q", invalid, stderr.String())
+	}
+}
+
+func TestRunProgress(t *testing.T) {
+	path := writeFile(t, "big.json", "["+strings.Repeat(`{"a": [1, 2, 3]}, `, 1000)+"null]")
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, `{"a": 1}`)
+	}))
+	defer server.Close()
+
+	// Define test cases
+	testCases := []struct {
+		name             string
+		path             string
+		expectedProgress bool
+	}{
+		{name: "file", path: path, expectedProgress: true},
+		// The size of a response is unknown, like stdin
+		{name: "url", path: server.URL, expectedProgress: false},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			var stderr bytes.Buffer
+			if exitCode := run([]string{"-progress", testCase.path}, io.Discard, &stderr); exitCode != 0 {
+				t.Fatalf("Expected exit code 0, got %d: %s", exitCode, stderr.String())
+			}
+
+			if progress := strings.Contains(stderr.String(), "Progress: "+testCase.path+" 100%"); progress != testCase.expectedProgress {
+				t.Errorf("Expected progress %v, got %q", testCase.expectedProgress, stderr.String())
+			}
+			if !testCase.expectedProgress && strings.Contains(stderr.String(), "Progress:") {
+				t.Errorf("Expected no progress, got %q", stderr.String())
+			}
+		})
+	}
+}
+
+func TestRunTrace(t *testing.T) {
+	path := writeFile(t, "config.json", `{"a": [1 2]}`)
+
+	// Normal runs are quiet
+	var stderr bytes.Buffer
+	run([]string{path}, io.Discard, &stderr)
+	if strings.Contains(stderr.String(), "trace:") {
+		t.Errorf("Expected no trace w/o -trace, got %q", stderr.String())
+	}
+
+	stderr.Reset()
+	if exitCode := run([]string{"-trace", path}, io.Discard, &stderr); exitCode != 1 {
+		t.Errorf("Expected exit code 1, got %d", exitCode)
+	}
+	if expected := "trace: expect ',' or ']' $.a, at NUM '2' at line 1, Column 10:10"; !strings.Contains(stderr.String(), expected) {
+		t.Errorf("Expected the trace to contain %q, got %q", expected, stderr.String())
+	}
+}
+
+func TestRunRelaxed(t *testing.T) {
+	path := writeFile(t, "colors.json", `{"red": 0xFF0000}`)
+
+	// Strict JSON rejects hexadecimal numbers
+	if exitCode := run([]string{path}, io.Discard, io.Discard); exitCode != 1 {
+		t.Errorf("Expected exit code 1, got %d", exitCode)
+	}
+
+	if exitCode := run([]string{"-relaxed", path}, io.Discard, io.Discard); exitCode != 0 {
+		t.Errorf("Expected exit code 0 w/ -relaxed, got %d", exitCode)
+	}
+}
+
+func TestRunRelaxedKeys(t *testing.T) {
+	// Define test cases
+	testCases := []struct {
+		name             string
+		content          string
+		expectedExitCode int
+		expectedStderr   string
+	}{
+		{name: "unquoted keys", content: `{name: "jl", max_size: 10}`, expectedExitCode: 0},
+		{name: "single-quoted keys", content: `{'name': "jl", 'max size': 10}`, expectedExitCode: 0},
+		{name: "double-quoted keys", content: `{"name": "jl"}`, expectedExitCode: 0},
+		{
+			name:             "single-quoted value",
+			content:          `{name: 'jl'}`,
+			expectedExitCode: 1,
+			expectedStderr:   "Error: Invalid JSON value ''jl'', single-quoted strings are only allowed as object keys at line 1, Column 8:11",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			path := writeFile(t, "config.json", testCase.content)
+
+			var stderr bytes.Buffer
+			if exitCode := run([]string{"-relaxed-keys", path}, io.Discard, &stderr); exitCode != testCase.expectedExitCode {
+				t.Errorf("Expected exit code %d, got %d: %q", testCase.expectedExitCode, exitCode, stderr.String())
+			}
+			if !strings.Contains(stderr.String(), testCase.expectedStderr) {
+				t.Errorf("Expected stderr to contain %q, got %q", testCase.expectedStderr, stderr.String())
+			}
+		})
+	}
+
+	// The keys are formatted as strict JSON
+	path := writeFile(t, "config.json", `{name: "jl", 'it\'s': 1}`)
+	var stdout bytes.Buffer
+	if exitCode := run([]string{"-relaxed-keys", "-format", path}, &stdout, io.Discard); exitCode != 0 {
+		t.Errorf("Expected exit code 0, got %d", exitCode)
+	}
+	if expected := "{\n  \"name\": \"jl\",\n  \"it's\": 1\n}\n"; stdout.String() != expected {
+		t.Errorf("Expected formatted document %q, got %q", expected, stdout.String())
+	}
+}
+
+func TestRunAllowNaNInf(t *testing.T) {
+	path := writeFile(t, "data.json", `{"min": -Infinity, "max": Infinity, "mean": NaN}`)
+
+	// Strict JSON rejects NaN & Infinity
+	var stderr bytes.Buffer
+	if exitCode := run([]string{path}, io.Discard, &stderr); exitCode != 1 {
+		t.Errorf("Expected exit code 1, got %d", exitCode)
+	}
+	if expected := "Invalid JSON value '-Infinity', invalid number: unexpected character 'I' at line 1, Column 9:17"; !strings.Contains(stderr.String(), expected) {
+		t.Errorf("Expected stderr to contain %q, got %q", expected, stderr.String())
+	}
+
+	var stdout bytes.Buffer
+	if exitCode := run([]string{"-allow-nan-inf", "-format", path}, &stdout, io.Discard); exitCode != 0 {
+		t.Errorf("Expected exit code 0 w/ -allow-nan-inf, got %d", exitCode)
+	}
+	if expected := "{\n  \"min\": -Infinity,\n  \"max\": Infinity,\n  \"mean\": NaN\n}\n"; stdout.String() != expected {
+		t.Errorf("Expected formatted document %q, got %q", expected, stdout.String())
+	}
+}
+
+func TestRunDetectIndent(t *testing.T) {
+	// Define test cases
+	testCases := []struct {
+		name           string
+		content        string
+		expectedStdout string
+	}{
+		{name: "2 spaces", content: "{\n  \"a\": [\n    1\n  ]\n}\n", expectedStdout: "2 spaces\n"},
+		{name: "4 spaces", content: "{\n    \"a\": [\n        1\n    ]\n}\n", expectedStdout: "4 spaces\n"},
+		{name: "tabs", content: "{\n\t\"a\": [\n\t\t1\n\t]\n}\n", expectedStdout: "tabs\n"},
+		{name: "mixed", content: "{\n\t\"a\": [\n    1\n\t]\n}\n", expectedStdout: "mixed\n"},
+		{name: "minified", content: `{"a":[1]}`, expectedStdout: "unknown\n"},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			path := writeFile(t, "data.json", testCase.content)
+
+			var stdout bytes.Buffer
+			if exitCode := run([]string{"-detect-indent", path}, &stdout, io.Discard); exitCode != 0 {
+				t.Errorf("Expected exit code 0, got %d", exitCode)
+			}
+			if stdout.String() != testCase.expectedStdout {
+				t.Errorf("Expected stdout %q, got %q", testCase.expectedStdout, stdout.String())
+			}
+		})
+	}
+}
+
+func TestRunVerbose(t *testing.T) {
+	// Define test cases
+	testCases := []struct {
+		name             string
+		content          string
+		expectedExitCode int
+		expectedMetrics  string
+	}{
+		{name: "valid document", content: "{\"é\": [1, 2]}\n", expectedExitCode: 0, expectedMetrics: "15 bytes, 14 runes, 1 lines, 9 tokens"},
+		{name: "several lines", content: "[\n  true\n]", expectedExitCode: 0, expectedMetrics: "10 bytes, 10 runes, 3 lines, 3 tokens"},
+		{name: "invalid document", content: "[1,]\n\n", expectedExitCode: 1, expectedMetrics: "6 bytes, 6 runes, 2 lines, 4 tokens"},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			path := writeFile(t, "data.json", testCase.content)
+
+			var stderr bytes.Buffer
+			if exitCode := run([]string{"-verbose", path}, io.Discard, &stderr); exitCode != testCase.expectedExitCode {
+				t.Errorf("Expected exit code %d, got %d: %q", testCase.expectedExitCode, exitCode, stderr.String())
+			}
+			if expected := fmt.Sprintf("Metrics: %s: %s\n", path, testCase.expectedMetrics); !strings.Contains(stderr.String(), expected) {
+				t.Errorf("Expected stderr to contain %q, got %q", expected, stderr.String())
+			}
+		})
+	}
+}
+
+// syncBuffer is a bytes.Buffer safe to write & read concurrently
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+func TestWatch(t *testing.T) {
+	path := writeFile(t, "config.json", `{"a": 1}`)
+
+	var stderr syncBuffer
+	l := &linter{
+		cfg:    args.Config{FilePaths: []string{path}},
+		stdout: io.Discard,
+		diags:  &diagnostics{logger: log.New(&stderr, "", 0), max: 1}, // Each validation may report an error
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	exitCode := make(chan int)
+	go func() { exitCode <- l.watch(ctx, 10*time.Millisecond) }()
+
+	// waitFor waits until stderr contains the expected output count times
+	waitFor := func(expected string, count int) {
+		t.Helper()
+		deadline := time.Now().Add(5 * time.Second)
+		for strings.Count(stderr.String(), expected) < count {
+			if time.Now().After(deadline) {
+				t.Fatalf("Expected %q %d time(s) in the output, got %q", expected, count, stderr.String())
+			}
+			time.Sleep(5 * time.Millisecond)
+		}
+	}
+
+	// The file is validated once, then on every change
+	waitFor("is valid", 1)
+	if err := os.WriteFile(path, []byte(`{"a": 1,}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	waitFor("Error: Invalid JSON Object, trailing comma not allowed", 1)
+
+	// A deleted file is validated again once recreated
+	if err := os.Remove(path); err != nil {
+		t.Fatal(err)
+	}
+	waitFor("Error: "+path+" was deleted, waiting for it to be recreated", 1)
+	if err := os.WriteFile(path, []byte(`{"a": 2}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	waitFor("is valid", 2)
+
+	// Interrupting the watch returns the exit code of the last validation
+	cancel()
+	if code := <-exitCode; code != 0 {
+		t.Errorf("Expected exit code 0, got %d", code)
+	}
+}
+
+func TestServe(t *testing.T) {
+	// Define test cases
+	testCases := []struct {
+		name     string
+		addr     string
+		cfg      args.Config
+		document string
+		expected serveResult
+	}{
+		{name: "valid over TCP", addr: "127.0.0.1:0", document: `{"a": [1, 2]}`, expected: serveResult{Valid: true, Problems: []serveProblem{}}},
+		{
+			name:     "invalid over TCP",
+			addr:     "127.0.0.1:0",
+			document: "{\"a\": [1, 2,]}",
+			expected: serveResult{Problems: []serveProblem{{
+				Severity: "error",
+				Message:  "Invalid JSON Array, trailing comma not allowed at Line 1, Column 12:12",
+				Line:     1,
+				ColStart: 12,
+				ColEnd:   12,
+				Path:     "$.a",
+			}}},
+		},
+		{
+			name:     "warning over a Unix socket",
+			addr:     "unix:" + filepath.Join(t.TempDir(), "jl.sock"),
+			cfg:      args.Config{DuplicateKeys: "warn"},
+			document: `{"a": 1, "a": 2}`,
+			expected: serveResult{Valid: true, Problems: []serveProblem{{
+				Severity: "warning",
+				Rule:     "duplicate-keys",
+				Message:  "Duplicate key 'a' at line 1, Column 10:12",
+				Line:     1,
+				ColStart: 10,
+				ColEnd:   12,
+				Path:     "$.a",
+			}}},
+		},
+		// The lint rules apply as to a file
+		{
+			name:     "missing key over TCP",
+			addr:     "127.0.0.1:0",
+			cfg:      args.Config{RequireKeys: []string{"name"}},
+			document: `{"a": 1}`,
+			expected: serveResult{Problems: []serveProblem{{
+				Severity: "error",
+				Rule:     "required-keys",
+				Message:  "Missing required key 'name' at line 1, Column 1:1",
+				Line:     1,
+				ColStart: 1,
+				ColEnd:   1,
+				Path:     "$",
+			}}},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			listener, err := listen(testCase.addr)
+			if err != nil {
+				t.Fatalf("Failed to listen on %s: %v", testCase.addr, err)
+			}
+
+			l := &linter{cfg: testCase.cfg, stdout: io.Discard, diags: &diagnostics{logger: log.New(io.Discard, "", 0)}}
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			exitCode := make(chan int)
+			go func() { exitCode <- l.serve(ctx, listener) }()
+
+			// Send the document, then read the result once the server is done w/ the connection
+			conn, err := net.Dial(listener.Addr().Network(), listener.Addr().String())
+			if err != nil {
+				t.Fatalf("Failed to connect to %s: %v", listener.Addr(), err)
+			}
+			defer conn.Close()
+			if _, err := io.WriteString(conn, testCase.document); err != nil {
+				t.Fatalf("Failed to send the document: %v", err)
+			}
+			if err := conn.(interface{ CloseWrite() error }).CloseWrite(); err != nil {
+				t.Fatalf("Failed to close the write side: %v", err)
+			}
+
+			var actual serveResult
+			if err := json.NewDecoder(conn).Decode(&actual); err != nil {
+				t.Fatalf("Failed to decode the result: %v", err)
+			}
+			if !reflect.DeepEqual(testCase.expected, actual) {
+				t.Errorf("Expected result %+v, got %+v", testCase.expected, actual)
+			}
+
+			// Interrupting the server stops it w/o an error
+			cancel()
+			if code := <-exitCode; code != 0 {
+				t.Errorf("Expected exit code 0, got %d", code)
+			}
+		})
+	}
+}
+
+func TestRunJSONSeq(t *testing.T) {
+	valid := writeFile(t, "valid.json-seq", "\x1e{\"a\": 1}\n\x1e [1, 2] \n\x1e\"x\"\n")
+	invalid := writeFile(t, "invalid.json-seq", "\x1e{\"a\": 1}\n\x1e{\"b\": [1,]}\n\x1e{\"c\": 3}\n")
+
+	var stderr bytes.Buffer
+	if exitCode := run([]string{"-json-seq", valid}, io.Discard, &stderr); exitCode != 0 {
+		t.Errorf("Expected exit code 0, got %d", exitCode)
+	}
+	if !strings.Contains(stderr.String(), "is valid (3 records)") {
+		t.Errorf("Expected the 3 records to be valid, got %q", stderr.String())
+	}
+
+	// Only the malformed record is reported, at its position within the file
+	stderr.Reset()
+	if exitCode := run([]string{"-json-seq", invalid}, io.Discard, &stderr); exitCode != 1 {
+		t.Errorf("Expected exit code 1, got %d", exitCode)
+	}
+	expected := "Error: record 2: Invalid JSON Array, trailing comma not allowed at Line 2, Column 10:10"
+	if !strings.Contains(stderr.String(), expected) || strings.Count(stderr.String(), "Error:") != 1 {
+		t.Errorf("Expected a single error %q, got %q", expected, stderr.String())
+	}
+
+	// The lint rules apply to each record, the findings being positioned within the file
+	duplicates := writeFile(t, "duplicates.json-seq", "\x1e{\"a\": 1}\n\x1e{\"a\": 1, \"a\": 2}\n")
+	stderr.Reset()
+	if exitCode := run([]string{"-json-seq", "-duplicate-keys=error", duplicates}, io.Discard, &stderr); exitCode != 1 {
+		t.Errorf("Expected exit code 1, got %d", exitCode)
+	}
+	expected = "Error: Duplicate key 'a' at line 2, Column 11:13"
+	if !strings.Contains(stderr.String(), expected) || strings.Count(stderr.String(), "Error:") != 1 {
+		t.Errorf("Expected a single error %q, got %q", expected, stderr.String())
+	}
+}
+
+func TestRunMarkdown(t *testing.T) {
+	valid := writeFile(t, "valid.md", "# Config\n\n```json\n{\"a\": 1}\n```\n\n```sh\njl -markdown docs\n```\n")
+	invalid := writeFile(t, "invalid.md", "# Config\n\n```json\n{\"a\": 1}\n```\n\nOr:\n\n```json\n{\n  \"b\": [1,]\n}\n```\n")
+
+	var stderr bytes.Buffer
+	if exitCode := run([]string{"-markdown", valid}, io.Discard, &stderr); exitCode != 0 {
+		t.Errorf("Expected exit code 0, got %d: %q", exitCode, stderr.String())
+	}
+	if !strings.Contains(stderr.String(), "are valid (1 block)") {
+		t.Errorf("Expected the json block to be valid, got %q", stderr.String())
+	}
+
+	// Only the malformed block is reported, at its position within the Markdown file
+	stderr.Reset()
+	if exitCode := run([]string{"-markdown", invalid}, io.Discard, &stderr); exitCode != 1 {
+		t.Errorf("Expected exit code 1, got %d", exitCode)
+	}
+	expected := "Error: json block 2: Invalid JSON Array, trailing comma not allowed at Line 11, Column 10:10"
+	if !strings.Contains(stderr.String(), expected) || strings.Count(stderr.String(), "Error:") != 1 {
+		t.Errorf("Expected a single error %q, got %q", expected, stderr.String())
+	}
+
+	// The lint rules apply to each block, the findings being positioned within the Markdown file
+	duplicates := writeFile(t, "duplicates.md", "```json\n{\"a\": 1}\n```\n\n```json\n{\"a\": 1, \"a\": 2}\n```\n")
+	stderr.Reset()
+	if exitCode := run([]string{"-markdown", "-duplicate-keys=error", duplicates}, io.Discard, &stderr); exitCode != 1 {
+		t.Errorf("Expected exit code 1, got %d", exitCode)
+	}
+	expected = "Error: Duplicate key 'a' at line 6, Column 10:12"
+	if !strings.Contains(stderr.String(), expected) || strings.Count(stderr.String(), "Error:") != 1 {
+		t.Errorf("Expected a single error %q, got %q", expected, stderr.String())
+	}
+
+	// Directories are scanned for Markdown files
+	var stdout bytes.Buffer
+	if exitCode := run([]string{"-markdown", "-dry-run", filepath.Dir(valid)}, &stdout, io.Discard); exitCode != 0 {
+		t.Errorf("Expected exit code 0, got %d", exitCode)
+	}
+	if stdout.String() != valid+"\n" {
+		t.Errorf("Expected stdout %q, got %q", valid+"\n", stdout.String())
+	}
+}
+
+func TestRunCheckEncoding(t *testing.T) {
+	valid := writeFile(t, "valid.json", `{"name": "café"}`)
+	latin1 := writeFile(t, "latin1.json", "{\"name\": \"caf\xe9\"}")
+
+	if exitCode := run([]string{"-check-encoding", valid}, io.Discard, io.Discard); exitCode != 0 {
+		t.Errorf("Expected exit code 0, got %d", exitCode)
+	}
+
+	var stderr bytes.Buffer
+	if exitCode := run([]string{"-check-encoding", latin1}, io.Discard, &stderr); exitCode != 1 {
+		t.Errorf("Expected exit code 1, got %d", exitCode)
+	}
+	if !strings.Contains(stderr.String(), "Error: invalid UTF-8 at byte offset 13 (0xE9)") {
+		t.Errorf("Expected an encoding error, got %q", stderr.String())
+	}
+}
+
+func TestRunBase64(t *testing.T) {
+	// Define test cases
+	testCases := []struct {
+		name             string
+		content          string
+		expectedExitCode int
+		expectedError    string // Empty if the file is valid
+	}{
+		// {"a": [1, 2]}
+		{name: "valid JSON", content: "eyJhIjogWzEsIDJdfQ==\n", expectedExitCode: 0},
+		// {"a": [1, 2,]}, the position is within the decoded JSON
+		{name: "invalid JSON", content: "eyJhIjogWzEsIDIsXX0=", expectedExitCode: 1, expectedError: "Error: Invalid JSON Array, trailing comma not allowed at Line 1, Column 12:12"},
+		{name: "malformed base64", content: `{"a": 1}`, expectedExitCode: 1, expectedError: "Error: invalid base64 input: illegal base64 data at input byte 0"},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			path := writeFile(t, "config.b64", testCase.content)
+
+			var stderr bytes.Buffer
+			if exitCode := run([]string{"-base64", path}, io.Discard, &stderr); exitCode != testCase.expectedExitCode {
+				t.Errorf("Expected exit code %d, got %d", testCase.expectedExitCode, exitCode)
+			}
+			if !strings.Contains(stderr.String(), testCase.expectedError) {
+				t.Errorf("Expected error %q, got %q", testCase.expectedError, stderr.String())
+			}
+		})
+	}
+}
+
+func TestRunEncodingUTF16(t *testing.T) {
+	// {"é": [1, 2,]} w/ a byte order mark, the position is within the decoded runes
+	invalidLE := []byte{0xFF, 0xFE}
+	invalidBE := []byte{0xFE, 0xFF}
+	for _, r := range `{"é": [1, 2,]}` {
+		invalidLE = append(invalidLE, byte(r), byte(r>>8))
+		invalidBE = append(invalidBE, byte(r>>8), byte(r))
+	}
+	validLE := []byte{0xFF, 0xFE, '[', 0, '1', 0, ']', 0}
+	validBE := []byte{0xFE, 0xFF, 0, '[', 0, '1', 0, ']'}
+
+	// Define test cases
+	testCases := []struct {
+		name             string
+		content          []byte
+		encoding         string
+		expectedExitCode int
+		expectedError    string // Empty if the file is valid
+	}{
+		{name: "valid LE", content: validLE, encoding: "utf16", expectedExitCode: 0},
+		{name: "valid BE", content: validBE, encoding: "utf16", expectedExitCode: 0},
+		{name: "invalid LE", content: invalidLE, encoding: "utf16", expectedExitCode: 1, expectedError: "Error: Invalid JSON Array, trailing comma not allowed at Line 1, Column 12:12"},
+		{name: "invalid BE", content: invalidBE, encoding: "utf16be", expectedExitCode: 1, expectedError: "Error: Invalid JSON Array, trailing comma not allowed at Line 1, Column 12:12"},
+		{name: "no BOM", content: validLE[2:], encoding: "utf16", expectedExitCode: 1, expectedError: "Error: UTF-16 input w/o a byte order mark"},
+		{name: "no BOM w/ endianness", content: validLE[2:], encoding: "utf16le", expectedExitCode: 0},
+		// Without the option, the input isn't valid JSON
+		{name: "utf8", content: validLE, encoding: "utf8", expectedExitCode: 1, expectedError: "Error: "},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			path := writeFile(t, "config.json", string(testCase.content))
+
+			var stderr bytes.Buffer
+			if exitCode := run([]string{"-encoding=" + testCase.encoding, path}, io.Discard, &stderr); exitCode != testCase.expectedExitCode {
+				t.Errorf("Expected exit code %d, got %d: %s", testCase.expectedExitCode, exitCode, stderr.String())
+			}
+			if !strings.Contains(stderr.String(), testCase.expectedError) {
+				t.Errorf("Expected error %q, got %q", testCase.expectedError, stderr.String())
+			}
+		})
+	}
+}
+
+func TestRunInvalidUTF8(t *testing.T) {
+	path := writeFile(t, "latin1.json", "{\"name\": \"caf\xe9\"}")
+
+	// Define test cases
+	testCases := []struct {
+		policy             string
+		expectedExitCode   int
+		expectedDiagnostic string // Empty if no diagnostic is expected
+	}{
+		{policy: "accept", expectedExitCode: 0},
+		{policy: "reject", expectedExitCode: 1, expectedDiagnostic: "Error: Invalid JSON value 'caf\ufffd', invalid UTF-8 at line 1, Column 10:15"},
+		{policy: "replace", expectedExitCode: 0, expectedDiagnostic: "Warning: Invalid UTF-8 byte replaced by U+FFFD at line 1, Column 14:14"},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.policy, func(t *testing.T) {
+			var stderr bytes.Buffer
+			if exitCode := run([]string{"-invalid-utf8=" + testCase.policy, path}, io.Discard, &stderr); exitCode != testCase.expectedExitCode {
+				t.Errorf("Expected exit code %d, got %d", testCase.expectedExitCode, exitCode)
+			}
+
+			if testCase.expectedDiagnostic == "" {
+				if strings.Contains(stderr.String(), "Warning:") || strings.Contains(stderr.String(), "Error:") {
+					t.Errorf("Expected no diagnostic, got %q", stderr.String())
+				}
+			} else if !strings.Contains(stderr.String(), testCase.expectedDiagnostic) {
+				t.Errorf("Expected diagnostic %q, got %q", testCase.expectedDiagnostic, stderr.String())
+			}
+		})
+	}
+}
+
+func TestRunGraph(t *testing.T) {
+	path := writeFile(t, "config.json", `{"ports": [80]}`)
+
+	var stdout bytes.Buffer
+	if exitCode := run([]string{"-graph=dot", path}, &stdout, io.Discard); exitCode != 0 {
+		t.Errorf("Expected exit code 0, got %d", exitCode)
+	}
+
+	// Only the graph is printed
+	expected := "digraph json {\n\tn0 [label=\"{}\"];\n\tn1 [label=\"[]\"];\n\tn2 [label=\"80\"];\n\tn1 -> n2 [label=\"0\"];\n\tn0 -> n1 [label=\"ports\"];\n}\n"
+	if stdout.String() != expected {
+		t.Errorf("Expected stdout %q, got %q", expected, stdout.String())
+	}
+}
+
+func TestPipe(t *testing.T) {
+	// Define test cases
+	testCases := []struct {
+		name             string
+		cfg              args.Config
+		stdin            string
+		expectedExitCode int
+		expectedStdout   string
+		expectedStderr   string
+	}{
+		{name: "valid passthrough", stdin: "{\n  \"a\": [1, 2.50]\n}\n", expectedExitCode: 0, expectedStdout: "{\n  \"a\": [1, 2.50]\n}\n"},
+		{name: "invalid rejection", stdin: `{"a": [1, 2,]}`, expectedExitCode: 1, expectedStderr: "Error: Invalid JSON Array, trailing comma not allowed at Line 1, Column 12:12\n"},
+		{name: "empty", stdin: "", expectedExitCode: 1, expectedStderr: "Error: "},
+		// The input is passed through as is, comments included
+		{name: "comments", cfg: args.Config{AllowComments: true}, stdin: "// config\n[true]", expectedExitCode: 0, expectedStdout: "// config\n[true]"},
+		// The lint rules apply as to a file
+		{name: "duplicate keys", cfg: args.Config{DuplicateKeys: "error"}, stdin: `{"a": 1, "a": 2}`, expectedExitCode: 1, expectedStderr: "Error: Duplicate key 'a' at line 1, Column 10:12"},
+		{name: "missing key", cfg: args.Config{RequireKeys: []string{"name"}}, stdin: `{"a": 1}`, expectedExitCode: 1, expectedStderr: "Error: Missing required key 'name'"},
+		{name: "warning", cfg: args.Config{DuplicateKeys: "warn"}, stdin: `{"a": 1, "a": 2}`, expectedExitCode: 0, expectedStdout: `{"a": 1, "a": 2}`, expectedStderr: "Warning: Duplicate key 'a'"},
+		// The diagnostics are limited as for the files
+		{name: "first error only", cfg: args.Config{FirstErrorOnly: true, DuplicateKeys: "error"}, stdin: `{"a": 1, "a": 2, "a": 3}`, expectedExitCode: 1, expectedStderr: "Error: Duplicate key 'a' at line 1, Column 10:12\n"},
+		{name: "missing schema", cfg: args.Config{Schema: "missing.schema.json"}, stdin: `{"a": 1}`, expectedExitCode: 1, expectedStderr: "Error: "},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			exitCode := pipe(testCase.cfg, strings.NewReader(testCase.stdin), &stdout, log.New(&stderr, "", 0))
+			if exitCode != testCase.expectedExitCode {
+				t.Errorf("Expected exit code %d, got %d: %q", testCase.expectedExitCode, exitCode, stderr.String())
+			}
+			if stdout.String() != testCase.expectedStdout {
+				t.Errorf("Expected stdout %q, got %q", testCase.expectedStdout, stdout.String())
+			}
+			if !strings.HasPrefix(stderr.String(), testCase.expectedStderr) || (testCase.expectedStderr == "") != (stderr.Len() == 0) {
+				t.Errorf("Expected stderr %q, got %q", testCase.expectedStderr, stderr.String())
+			}
+		})
+	}
+}
+
+func TestPipeProgress(t *testing.T) {
+	content := `{"a": [1, 2]}`
+	file, err := os.Open(writeFile(t, "stdin.json", content))
+	if err != nil {
+		t.Fatalf("Failed to open the file: %v", err)
+	}
+	defer file.Close()
+
+	// Stdin redirected from a file has a known size, so the percentage read is reported
+	var stdout, stderr bytes.Buffer
+	if exitCode := pipe(args.Config{Progress: true}, file, &stdout, log.New(&stderr, "", 0)); exitCode != 0 {
+		t.Errorf("Expected exit code 0, got %d: %q", exitCode, stderr.String())
+	}
+	if stdout.String() != content || stderr.String() != "Progress: stdin 100%\n" {
+		t.Errorf("Expected the document & its progress, got %q & %q", stdout.String(), stderr.String())
+	}
+
+	// Unlike stdin fed by a pipe
+	pipeReader, pipeWriter, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("Failed to create a pipe: %v", err)
+	}
+	defer pipeReader.Close()
+	go func() {
+		io.WriteString(pipeWriter, content)
+		pipeWriter.Close()
+	}()
+	stdout.Reset()
+	stderr.Reset()
+	if exitCode := pipe(args.Config{Progress: true}, pipeReader, &stdout, log.New(&stderr, "", 0)); exitCode != 0 {
+		t.Errorf("Expected exit code 0, got %d: %q", exitCode, stderr.String())
+	}
+	if stdout.String() != content || stderr.Len() != 0 {
+		t.Errorf("Expected the document w/o progress, got %q & %q", stdout.String(), stderr.String())
+	}
+}
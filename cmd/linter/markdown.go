@@ -0,0 +1,52 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/pszponder/json-linter_go/internal/input"
+	"github.com/pszponder/json-linter_go/internal/parser"
+)
+
+// lintMarkdown validates each json code block of the Markdown document located at filePath as an independent document.
+// The errors are reported w/ the number of their block & positions within the whole Markdown document.
+// Returns the exit code for the file.
+func (l *linter) lintMarkdown(ctx context.Context, filePath string) int {
+	file, err := input.Open(ctx, filePath)
+	if err != nil {
+		l.diags.report(parser.SeverityError, err)
+		return 1
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(file)
+	if err != nil {
+		l.diags.report(parser.SeverityError, err)
+		return 1
+	}
+
+	blocks := input.SplitMarkdown(data)
+	exitCode := 0
+	for i, block := range blocks {
+		doc, err := l.validateRecord(ctx, block)
+		if err != nil {
+			l.diags.report(parser.SeverityError, fmt.Sprintf("json block %d: %v", i+1, err))
+			exitCode = 1
+			continue
+		}
+		// The lint rules apply to each block as to a file
+		if l.lintDocument(ctx, doc) != 0 {
+			exitCode = 1
+		}
+	}
+
+	if exitCode == 0 {
+		noun := "blocks"
+		if len(blocks) == 1 {
+			noun = "block"
+		}
+		l.diags.valid("JSON code blocks of the Markdown located in %v are valid (%d %s)", filePath, len(blocks), noun)
+	}
+	return exitCode
+}
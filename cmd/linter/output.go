@@ -0,0 +1,45 @@
+package main
+
+import (
+	"log"
+	"os"
+	"path/filepath"
+
+	"github.com/pszponder/json-linter_go/internal/args"
+)
+
+// lintToFile runs lintAll, writing the report to the file at cfg.Output (creating its parent directories) instead of the terminal.
+// The report is the output printed to stdout if the run prints one (ex. the -summary-json summary),
+// else the files validated & their diagnostics, which are then replaced by a status line on stderr.
+// The diagnostics of a run printing a report stay on stderr, so the report of an invalid file is empty.
+// Returns the exit code of the app.
+func lintToFile(cfg args.Config, logger *log.Logger) int {
+	if err := os.MkdirAll(filepath.Dir(cfg.Output), 0o755); err != nil {
+		logger.Print("Error: ", err)
+		return 1
+	}
+	file, err := os.Create(cfg.Output)
+	if err != nil {
+		logger.Print("Error: ", err)
+		return 1
+	}
+
+	var exitCode int
+	if printsReport(cfg) {
+		exitCode = lintAll(cfg, file, logger)
+	} else {
+		// The diagnostics are part of the report, w/o the timestamps meant for the terminal
+		exitCode = lintAll(cfg, file, log.New(file, "", 0))
+	}
+	if err := file.Close(); err != nil {
+		logger.Print("Error: ", err)
+		return 1
+	}
+
+	if exitCode != 0 {
+		logger.Printf("Report written to %s, problems were found", cfg.Output)
+	} else {
+		logger.Printf("Report written to %s, no problems found", cfg.Output)
+	}
+	return exitCode
+}
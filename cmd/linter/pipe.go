@@ -0,0 +1,58 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+
+	"github.com/pszponder/json-linter_go/internal/args"
+	"github.com/pszponder/json-linter_go/internal/input"
+	"github.com/pszponder/json-linter_go/internal/parser"
+)
+
+// pipe validates the document read from stdin & writes it unchanged to stdout if it's valid, nothing otherwise,
+// so the linter can gate a shell pipeline (ex. producer | jl -pipe | consumer).
+// The document is linted like a file, the errors & warnings being logged to stderr. Returns the exit code of the app.
+func pipe(cfg args.Config, stdin io.Reader, stdout io.Writer, logger *log.Logger) int {
+	ctx, cancel := runContext(cfg)
+	defer cancel()
+
+	l, err := newLinter(cfg, io.Discard, logger)
+	if err != nil {
+		logger.Print("Error: ", err)
+		return 1
+	}
+
+	// The whole document is buffered, as nothing may be written before knowing whether it's valid.
+	// The progress is reported while reading stdin, if it's redirected from a file (a pipe has no known size).
+	reader := stdin
+	if cfg.Progress {
+		reader = input.NewProgressReader(stdin, "stdin", input.Size(stdin), logger.Writer())
+	}
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		logger.Print("Error: ", err)
+		return 1
+	}
+
+	doc, err := readDocument(ctx, bytes.NewReader(data), "stdin", -1, cfg, logger.Writer())
+	if errors.Is(err, context.DeadlineExceeded) {
+		err = fmt.Errorf("validation of stdin timed out after %v", cfg.Timeout)
+	}
+	if err != nil {
+		l.diags.report(parser.SeverityError, err)
+		return 1
+	}
+	if exitCode := l.lintDocument(ctx, doc); exitCode != 0 {
+		return exitCode
+	}
+
+	if _, err := stdout.Write(data); err != nil {
+		logger.Print("Error: ", err)
+		return 1
+	}
+	return 0
+}
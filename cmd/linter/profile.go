@@ -0,0 +1,59 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"runtime"
+	"runtime/pprof"
+
+	"github.com/pszponder/json-linter_go/internal/args"
+)
+
+// startProfiling starts the CPU profile requested by cfg.
+// The returned function stops the CPU profile & writes the heap profile, it must be called at the end of the run.
+// Both profiles are no-ops when their flag is unset.
+func startProfiling(cfg args.Config, logger *log.Logger) (func(), error) {
+	var cpuFile *os.File
+	if cfg.CPUProfile != "" {
+		file, err := os.Create(cfg.CPUProfile)
+		if err != nil {
+			return nil, fmt.Errorf("Could not create CPU profile: %v", err)
+		}
+		if err := pprof.StartCPUProfile(file); err != nil {
+			file.Close()
+			return nil, fmt.Errorf("Could not start CPU profile: %v", err)
+		}
+		cpuFile = file
+	}
+
+	stop := func() {
+		if cpuFile != nil {
+			pprof.StopCPUProfile()
+			cpuFile.Close()
+		}
+
+		if cfg.MemProfile != "" {
+			if err := writeHeapProfile(cfg.MemProfile); err != nil {
+				logger.Print("Error: ", err)
+			}
+		}
+	}
+
+	return stop, nil
+}
+
+// writeHeapProfile writes a profile of the live heap to the file located at path
+func writeHeapProfile(path string) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("Could not create memory profile: %v", err)
+	}
+	defer file.Close()
+
+	runtime.GC() // Get up-to-date statistics
+	if err := pprof.WriteHeapProfile(file); err != nil {
+		return fmt.Errorf("Could not write memory profile: %v", err)
+	}
+	return nil
+}
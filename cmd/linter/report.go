@@ -0,0 +1,118 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/pszponder/json-linter_go/internal/args"
+	"github.com/pszponder/json-linter_go/internal/lexer"
+	"github.com/pszponder/json-linter_go/internal/parser"
+)
+
+// reportFile is a file listed by a report, w/ the diagnostics recorded for it
+type reportFile struct {
+	path    string
+	valid   bool
+	records []diagRecord
+}
+
+// jsonReport is the report of -report-format=json, the problems of each file being those written back by -serve
+type jsonReport struct {
+	Files []jsonReportFile `json:"files"`
+}
+
+// jsonReportFile lists the problems of a file
+type jsonReportFile struct {
+	Path     string         `json:"path"`
+	Valid    bool           `json:"valid"`
+	Problems []serveProblem `json:"problems"`
+}
+
+// printReport prints the diagnostics recorded for each file as a report in the format (one of the -report-format values)
+func printReport(w io.Writer, format string, results []fileResult, records []diagRecord) error {
+	files := reportFiles(results, records)
+	switch format {
+	case args.FormatJSON:
+		return printJSONReport(w, files)
+	case args.FormatSARIF:
+		return printSARIF(w, files)
+	}
+	return printCheckstyle(w, files)
+}
+
+// reportFiles groups the recorded diagnostics by file.
+// Every validated file is listed in order, the valid ones w/o diagnostics.
+func reportFiles(results []fileResult, records []diagRecord) []reportFile {
+	files := make([]reportFile, 0, len(results))
+	index := make(map[string]int, len(results))
+	for _, result := range results {
+		index[result.Path] = len(files)
+		files = append(files, reportFile{path: result.Path, valid: result.Valid})
+	}
+
+	for _, record := range records {
+		i, ok := index[record.file]
+		if !ok {
+			// The file is being validated when the run stops (ex. timed out)
+			i = len(files)
+			index[record.file] = i
+			files = append(files, reportFile{path: record.file})
+		}
+		files[i].records = append(files[i].records, record)
+	}
+	return files
+}
+
+// printJSONReport prints the problems of each file as a JSON object
+func printJSONReport(w io.Writer, files []reportFile) error {
+	report := jsonReport{Files: make([]jsonReportFile, 0, len(files))}
+	for _, file := range files {
+		reported := jsonReportFile{Path: file.path, Valid: file.valid, Problems: []serveProblem{}}
+		for _, record := range file.records {
+			reported.Problems = append(reported.Problems, newProblem(record))
+		}
+		report.Files = append(report.Files, reported)
+	}
+
+	doc, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(w, string(doc))
+	return err
+}
+
+// newProblem converts the recorded diagnostic, positioning it if it is a finding or a syntax error
+func newProblem(record diagRecord) serveProblem {
+	problem := serveProblem{Severity: record.severity.String(), Message: fmt.Sprint(record.diagnostic)}
+
+	var pos lexer.TokenPosition
+	var syntaxErr *parser.SyntaxError
+	var lexErr lexer.LexError
+	switch diagnostic := record.diagnostic.(type) {
+	case parser.ParseError:
+		pos = diagnostic.Pos
+		problem.Rule = diagnostic.Rule
+		problem.Path = diagnostic.Path
+	case error:
+		if errors.As(diagnostic, &syntaxErr) {
+			pos = syntaxErr.Pos
+			problem.Path = syntaxErr.Path
+		} else if errors.As(diagnostic, &lexErr) {
+			pos = lexErr.Pos
+		}
+	}
+	problem.Line, problem.ColStart, problem.ColEnd = pos.Line, pos.ColStart, pos.ColEnd
+	return problem
+}
+
+// unpositionedMessage returns the message of the recorded diagnostic w/o its position if it's a finding of a lint rule,
+// for the reports giving the position separately. The message of a syntax error already includes the position.
+func unpositionedMessage(record diagRecord, problem serveProblem) string {
+	if finding, ok := record.diagnostic.(parser.ParseError); ok && finding.Rule != "" {
+		return finding.Message
+	}
+	return problem.Message
+}
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// sarifVersion & sarifSchema identify the version of SARIF (Static Analysis Results Interchange Format) of the reports
+const (
+	sarifVersion = "2.1.0"
+	sarifSchema  = "https://json.schemastore.org/sarif-2.1.0.json"
+)
+
+// sarifLog is the root object of a SARIF report, w/ a single run of the linter
+type sarifLog struct {
+	Version string     `json:"version"`
+	Schema  string     `json:"$schema"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+// sarifRun lists the results of a run of the tool
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+// sarifTool describes the linter
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+// sarifDriver names the linter
+type sarifDriver struct {
+	Name string `json:"name"`
+}
+
+// sarifResult is a diagnostic, located in its file
+type sarifResult struct {
+	RuleID    string          `json:"ruleId,omitempty"` // Lint rule which reported the diagnostic (ex. "duplicate-keys")
+	Level     string          `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations"`
+}
+
+// sarifMessage is the text of a diagnostic
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+// sarifLocation is the location of a diagnostic
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+// sarifPhysicalLocation is the file of a diagnostic & its region within the file when known
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           *sarifRegion          `json:"region,omitempty"`
+}
+
+// sarifArtifactLocation identifies a file
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+// sarifRegion is the span of a diagnostic on its line, the end column being past its last character
+type sarifRegion struct {
+	StartLine   int `json:"startLine"`
+	StartColumn int `json:"startColumn"`
+	EndColumn   int `json:"endColumn"`
+}
+
+// printSARIF prints the diagnostics recorded for each file as a SARIF report (ex. for GitHub code scanning).
+// Valid files have no results.
+func printSARIF(w io.Writer, files []reportFile) error {
+	run := sarifRun{Tool: sarifTool{Driver: sarifDriver{Name: "jl"}}, Results: []sarifResult{}}
+	for _, file := range files {
+		for _, record := range file.records {
+			run.Results = append(run.Results, newSARIFResult(file.path, record))
+		}
+	}
+
+	doc, err := json.MarshalIndent(sarifLog{Version: sarifVersion, Schema: sarifSchema, Runs: []sarifRun{run}}, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(w, string(doc))
+	return err
+}
+
+// newSARIFResult converts the diagnostic recorded for the file, positioning it if it is a finding or a syntax error
+func newSARIFResult(path string, record diagRecord) sarifResult {
+	problem := newProblem(record)
+	location := sarifLocation{PhysicalLocation: sarifPhysicalLocation{ArtifactLocation: sarifArtifactLocation{URI: path}}}
+	if problem.Line > 0 {
+		location.PhysicalLocation.Region = &sarifRegion{StartLine: problem.Line, StartColumn: problem.ColStart, EndColumn: problem.ColEnd + 1}
+	}
+
+	return sarifResult{
+		RuleID:    problem.Rule,
+		Level:     problem.Severity,
+		Message:   sarifMessage{Text: unpositionedMessage(record, problem)},
+		Locations: []sarifLocation{location},
+	}
+}
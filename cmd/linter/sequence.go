@@ -0,0 +1,70 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/pszponder/json-linter_go/internal/input"
+	"github.com/pszponder/json-linter_go/internal/lexer"
+	"github.com/pszponder/json-linter_go/internal/parser"
+)
+
+// lintSequence validates each record of the JSON text sequence (RFC 7464) located at filePath as an independent document.
+// The errors are reported w/ the number of their record & positions within the whole file.
+// Returns the exit code for the file.
+func (l *linter) lintSequence(ctx context.Context, filePath string) int {
+	file, err := input.Open(ctx, filePath)
+	if err != nil {
+		l.diags.report(parser.SeverityError, err)
+		return 1
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(file)
+	if err != nil {
+		l.diags.report(parser.SeverityError, err)
+		return 1
+	}
+
+	records := input.SplitSequence(data)
+	exitCode := 0
+	for i, record := range records {
+		doc, err := l.validateRecord(ctx, record)
+		if err != nil {
+			l.diags.report(parser.SeverityError, fmt.Sprintf("record %d: %v", i+1, err))
+			exitCode = 1
+			continue
+		}
+		// The lint rules apply to each record as to a file
+		if l.lintDocument(ctx, doc) != 0 {
+			exitCode = 1
+		}
+	}
+
+	if exitCode == 0 {
+		l.diags.valid("JSON text sequence located in %v is valid (%d records)", filePath, len(records))
+	}
+	return exitCode
+}
+
+// validateRecord tokenizes & parses a record of a JSON text sequence (or a code block of a Markdown document),
+// positioning the tokens within the whole file. Returns the document if the JSON is valid.
+func (l *linter) validateRecord(ctx context.Context, record input.Record) (*document, error) {
+	lxr := lexer.NewLexer(bytes.NewReader(record.Data), lexerOptions(l.cfg))
+	lxr.StartAt(record.Line, record.Column, record.Offset)
+	tokens, err := lxr.ReadAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := parserOptions(l.cfg, l.diags.logger.Writer())
+	opts.End = lxr.End()
+	rootNode, err := parser.ParseJSONWithOptions(ctx, tokens, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return &document{tokens: tokens, root: rootNode, finalNewlines: lxr.FinalNewlines, replacements: lxr.Replacements, longLine: lxr.LongLine}, nil
+}
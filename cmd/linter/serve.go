@@ -0,0 +1,129 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"strings"
+	"sync"
+
+	"github.com/pszponder/json-linter_go/internal/parser"
+)
+
+// maxPayloadSize is the maximum size in bytes of a document received by -serve
+const maxPayloadSize = 64 << 20
+
+// payloadName names a document received by -serve in its diagnostics
+const payloadName = "payload"
+
+// serveResult is the outcome of validating a document received by -serve, written back as JSON
+type serveResult struct {
+	Valid    bool           `json:"valid"`
+	Problems []serveProblem `json:"problems"`
+}
+
+// serveProblem is an error or warning found in a document received by -serve, or in a file of the JSON report
+type serveProblem struct {
+	Severity string `json:"severity"`
+	Rule     string `json:"rule,omitempty"` // Empty for syntax errors
+	Message  string `json:"message"`
+	Line     int    `json:"line,omitempty"` // 0 if the problem has no position (ex. a read failure)
+	ColStart int    `json:"colStart,omitempty"`
+	ColEnd   int    `json:"colEnd,omitempty"`
+	Path     string `json:"path,omitempty"`
+}
+
+// listen listens on the address, either a Unix socket (ex. "unix:/tmp/jl.sock") or a TCP address (ex. "localhost:7070")
+func listen(addr string) (net.Listener, error) {
+	if path, ok := strings.CutPrefix(addr, "unix:"); ok {
+		return net.Listen("unix", path)
+	}
+	return net.Listen("tcp", addr)
+}
+
+// serve accepts connections on the listener until the context is done (ex. on interrupt).
+// Each connection sends a single JSON document & closes its write side, then the result is written back as JSON.
+// Returns the exit code of the app.
+func (l *linter) serve(ctx context.Context, listener net.Listener) int {
+	l.diags.logger.Printf("Listening on %s", listener.Addr())
+
+	// Closing the listener unblocks Accept
+	go func() {
+		<-ctx.Done()
+		listener.Close()
+	}()
+
+	// The connections being handled write back their result before returning
+	var conns sync.WaitGroup
+	defer conns.Wait()
+
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			if ctx.Err() != nil {
+				return 0
+			}
+			l.diags.logger.Print("Error: ", err)
+			return 1
+		}
+		conns.Add(1)
+		go func() {
+			defer conns.Done()
+			l.handleConn(ctx, conn)
+		}()
+	}
+}
+
+// handleConn validates the document received on the connection & writes back the result
+func (l *linter) handleConn(ctx context.Context, conn net.Conn) {
+	defer conn.Close()
+
+	var result serveResult
+	data, err := io.ReadAll(io.LimitReader(conn, maxPayloadSize+1))
+	switch {
+	case err != nil:
+		result = failedResult(err)
+	case len(data) > maxPayloadSize:
+		result = failedResult(fmt.Errorf("document larger than %d bytes", maxPayloadSize))
+	default:
+		result = l.validatePayload(ctx, data)
+	}
+
+	if err := json.NewEncoder(conn).Encode(result); err != nil {
+		l.diags.logger.Print("Error: ", err)
+	}
+}
+
+// validatePayload validates the document & reports the findings of the lint rules (& of the schema) as for a file.
+// Each document gets its own diagnostics, recorded for the result instead of logged, as connections are handled concurrently.
+func (l *linter) validatePayload(ctx context.Context, data []byte) serveResult {
+	diags := newDiagnostics(l.cfg, log.New(io.Discard, "", 0))
+	diags.collect = true
+	diags.startFile(payloadName)
+	payload := &linter{cfg: l.cfg, stdout: io.Discard, diags: diags, schemaDoc: l.schemaDoc, schemaDraft: l.schemaDraft}
+
+	doc, err := readDocument(ctx, bytes.NewReader(data), payloadName, int64(len(data)), l.cfg, io.Discard)
+	if err != nil {
+		diags.report(parser.SeverityError, err)
+	} else {
+		payload.lintDocument(ctx, doc)
+	}
+
+	result := serveResult{Valid: true, Problems: []serveProblem{}}
+	for _, record := range diags.records {
+		if record.severity == parser.SeverityError {
+			result.Valid = false
+		}
+		result.Problems = append(result.Problems, newProblem(record))
+	}
+	return result
+}
+
+// failedResult returns the result of a document which couldn't be validated because of err
+func failedResult(err error) serveResult {
+	return serveResult{Problems: []serveProblem{{Severity: parser.SeverityError.String(), Message: err.Error()}}}
+}
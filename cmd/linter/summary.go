@@ -0,0 +1,44 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// fileResult is the outcome of validating a single file
+type fileResult struct {
+	Path       string `json:"path"`
+	Valid      bool   `json:"valid"`
+	ErrorCount int    `json:"errorCount"`
+}
+
+// summary aggregates the results of every file validated by a run
+type summary struct {
+	Total   int          `json:"total"`
+	Valid   int          `json:"valid"`
+	Invalid int          `json:"invalid"`
+	Files   []fileResult `json:"files"`
+}
+
+// printSummary prints the summary of the results as a JSON object
+func printSummary(w io.Writer, results []fileResult) error {
+	s := summary{Total: len(results), Files: results}
+	if s.Files == nil {
+		s.Files = []fileResult{}
+	}
+	for _, result := range results {
+		if result.Valid {
+			s.Valid++
+		} else {
+			s.Invalid++
+		}
+	}
+
+	doc, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(w, string(doc))
+	return err
+}
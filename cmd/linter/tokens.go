@@ -0,0 +1,39 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/pszponder/json-linter_go/internal/lexer"
+)
+
+// tokenJSON is the machine-readable representation of a token printed by -tokens-json
+type tokenJSON struct {
+	Type     string `json:"type"`
+	Lexeme   string `json:"lexeme"`
+	Line     int    `json:"line"`
+	ColStart int    `json:"colStart"`
+	ColEnd   int    `json:"colEnd"`
+}
+
+// printTokensJSON prints the tokens as a JSON array of objects
+func printTokensJSON(w io.Writer, tokens []lexer.Token) error {
+	list := make([]tokenJSON, 0, len(tokens))
+	for _, tok := range tokens {
+		list = append(list, tokenJSON{
+			Type:     tok.TokType.String(),
+			Lexeme:   tok.Lexeme,
+			Line:     tok.TokPos.Line,
+			ColStart: tok.TokPos.ColStart,
+			ColEnd:   tok.TokPos.ColEnd,
+		})
+	}
+
+	doc, err := json.MarshalIndent(list, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(w, string(doc))
+	return err
+}
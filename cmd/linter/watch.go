@@ -0,0 +1,82 @@
+package main
+
+import (
+	"context"
+	"os"
+	"time"
+
+	"github.com/pszponder/json-linter_go/internal/parser"
+)
+
+// watchInterval is how often -watch polls the files for changes
+const watchInterval = 500 * time.Millisecond
+
+// fileState is what -watch knows about a file to detect changes, the zero value is a missing file
+type fileState struct {
+	exists  bool
+	modTime time.Time
+	size    int64
+}
+
+// statFile returns the current state of the file located at filePath
+func statFile(filePath string) fileState {
+	info, err := os.Stat(filePath)
+	if err != nil {
+		return fileState{}
+	}
+	return fileState{exists: true, modTime: info.ModTime(), size: info.Size()}
+}
+
+// watch validates the files of the config, then polls them every interval & re-validates each file which changed
+// until the context is done (ex. on interrupt). A deleted file is reported & validated again once recreated.
+// The files are listed once, so files added to a directory later aren't watched.
+// Returns the exit code of the last validation of the files.
+func (l *linter) watch(ctx context.Context, interval time.Duration) int {
+	var filePaths []string
+	for _, path := range l.cfg.FilePaths {
+		expanded, err := expandPath(l.cfg, path, 0)
+		if err != nil {
+			l.diags.report(parser.SeverityError, err)
+			return 1
+		}
+		filePaths = append(filePaths, expanded...)
+	}
+
+	states := make(map[string]fileState, len(filePaths))
+	exitCodes := make(map[string]int, len(filePaths))
+	for _, filePath := range filePaths {
+		states[filePath] = statFile(filePath)
+		exitCodes[filePath] = l.lintFile(ctx, filePath)
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			exitCode := 0
+			for _, code := range exitCodes {
+				exitCode = max(exitCode, code)
+			}
+			return exitCode
+		case <-ticker.C:
+		}
+
+		for _, filePath := range filePaths {
+			state := statFile(filePath)
+			if state == states[filePath] {
+				continue
+			}
+			states[filePath] = state
+
+			// Each validation gets its own budget of diagnostics
+			l.diags.resetFile()
+			if !state.exists {
+				l.diags.report(parser.SeverityError, filePath+" was deleted, waiting for it to be recreated")
+				exitCodes[filePath] = 1
+				continue
+			}
+			exitCodes[filePath] = l.lintFile(ctx, filePath)
+		}
+	}
+}
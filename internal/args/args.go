@@ -1,19 +1,396 @@
+// Package args is responsible for parsing the command-line arguments passed to the linter.
 package args
 
 import (
+	"errors"
+	"flag"
 	"fmt"
+	"io"
 	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pszponder/json-linter_go/internal/input"
+	"github.com/pszponder/json-linter_go/internal/lexer"
+	"github.com/pszponder/json-linter_go/internal/parser"
 )
 
-// GetFilePath parses and returns passed in filepath
+// usage describes how to invoke the linter
+const usage = "Usage: jl [flags] <filepath|@manifest>... (or set LINTER_FILE)"
+
+// EnvFilePath is the environment variable holding the filepath to validate when no filepath argument is passed
+const EnvFilePath = "LINTER_FILE"
+
+// DefaultMaxErrors is the default maximum number of diagnostics reported by a run
+const DefaultMaxErrors = 100
+
+// DefaultIndent is the default indentation of a nesting level in the formatted document
+const DefaultIndent = "  "
+
+// FormatCheckstyle, FormatJSON & FormatSARIF are the -report-format values printing the diagnostics as Checkstyle XML,
+// as a JSON object & as SARIF 2.1.0 (JSON) respectively
+const (
+	FormatCheckstyle = "checkstyle"
+	FormatJSON       = "json"
+	FormatSARIF      = "sarif"
+)
+
+// reportFormats lists the values of -report-format
+var reportFormats = []string{FormatCheckstyle, FormatJSON, FormatSARIF}
+
+// ErrUsage is returned by ParseArgs when the arguments do not match the expected usage
+var ErrUsage = errors.New(usage)
+
+// Config holds the settings for a single run of the linter
+type Config struct {
+	FilePaths             []string      // Paths (or http/https URLs) of the JSON files (or directories) to validate
+	DisallowDuplicateKeys bool          // Report objects containing the same key more than once as invalid
+	Timeout               time.Duration // Maximum duration of the whole run (0 means no timeout)
+	AllowComments         bool          // Accept "//" & "/* */" comments (JSONC)
+	Depth                 bool          // Print the maximum nesting depth of the document
+	CPUProfile            string        // Write a CPU profile of the run to this file (empty means no profile)
+	MemProfile            string        // Write a heap profile at the end of the run to this file (empty means no profile)
+	Ignore                []string      // Globs of the files & directories to skip when scanning directories
+	InferSchema           bool          // Print a JSON Schema inferred from the document
+	MaxStringLength       int           // Reject strings longer than this many characters (0 means no limit)
+	RequireKeys           []string      // Top-level keys the root object must contain
+	Unwrap                string        // Path of a string containing embedded JSON to validate as well (empty means none)
+	MaxErrors             int           // Stop the run after reporting this many errors, the warnings not counting (0 means no limit)
+	Schema                string        // Path of a JSON Schema the documents must match (empty means none)
+	SchemaDraft           string        // Draft used to interpret the schema (ex. "2020-12"), validated when loading it (empty means schema.DefaultDraft)
+	DryRun                bool          // List the files which would be validated instead of validating them
+	SummaryJSON           bool          // Print a JSON summary of the results of every file
+	Compare               bool          // Print the structural differences between the 2 files instead of linting them
+	Format                bool          // Print the formatted document
+	ReportFormat          string        // Print the diagnostics as a report in this format (one of reportFormats), empty means no report
+	Indent                string        // Indentation of a nesting level in the formatted document (ex. "\t" or "  ")
+	RequireFinalNewline   bool          // Warn if the document doesn't end w/ exactly one newline
+	NoFinalNewline        bool          // Warn if the document ends w/ a newline
+	CheckEncoding         bool          // Verify the whole input is valid UTF-8 before lexing it
+	Base64                bool          // Decode the input from base64 before lexing it
+	Graph                 string        // Print the document structure as a graph in this format (only "dot"), empty means no graph
+	PreserveTrivia        bool          // Keep the whitespace & comments around the tokens, warning about mixed indentation
+	ExitZero              bool          // Exit w/ 0 even if a file is invalid, the findings are still printed
+	ExpectType            string        // Type the root must be (see parser.ParseRootType), validated when linting, empty means any type
+	KeysOnly              bool          // Print the distinct paths of the object keys of the document
+	Trace                 bool          // Log each decision of the parser to stderr
+	Relaxed               bool          // Accept syntax beyond strict JSON (ex. hexadecimal numbers)
+	TokensJSON            bool          // Print the tokens of the document as a JSON array
+	EmitWhitespace        bool          // Also print the whitespace & newline tokens w/ -tokens-json
+	Watch                 bool          // Re-validate the files whenever they change until interrupted
+	JSONSeq               bool          // Validate each record of a JSON text sequence (RFC 7464) as an independent document
+	Canonical             bool          // Print the canonical form of the document (JCS, RFC 8785)
+	ModifiedSince         time.Time     // Skip the files of scanned directories last modified before this time (zero means no filter)
+	OnlyErrors            bool          // Print only the errors, prefixed w/ their file, staying silent on success
+	Progress              bool          // Report the percentage of each file read to stderr
+	FirstErrorOnly        bool          // Report only the 1st error of each file (dropping the warnings), still validating every file
+	ForbidEmptyObject     bool          // Warn about each empty object ({})
+	ForbidEmptyArray      bool          // Warn about each empty array ([])
+	HomogeneousArrays     bool          // Warn about each array whose elements aren't all of the same type
+	NormalizeNumbers      bool          // Normalize the number literals of the formatted document (ex. 1.50E5 -> 1.5e5)
+	Serve                 string        // Address to listen on for documents to validate (ex. "localhost:7070" or "unix:/tmp/jl.sock"), empty means no server
+	MaxArrayElements      int           // Reject arrays w/ more elements than this (0 means no limit)
+	MaxObjectMembers      int           // Reject objects w/ more members than this (0 means no limit)
+	Enums                 []parser.Enum // Strings allowed for the values at paths of the documents
+	RelaxedKeys           bool          // Accept unquoted & single-quoted object keys, the rest of the document being strict JSON
+	AllowNaNInf           bool          // Accept NaN, Infinity & -Infinity as numbers
+	MaxTokenLength        int           // Reject any string, number, literal or comment longer than this many characters (0 means no limit)
+	MaxNumberLength       int           // Reject number literals longer than this many characters (0 means lexer.DefaultMaxNumberLength, negative means no limit)
+	Markdown              bool          // Validate each json code block of Markdown files as an independent document
+	MaxFiles              int           // Stop the run before validating more files than this, warning that it's truncated (0 means no limit)
+	MaxLines              int           // Stop reading a file past this many lines, failing its validation (0 means no limit)
+	DetectIndent          bool          // Print the indentation style of the document (ex. "2 spaces" or "tabs")
+	Verbose               bool          // Print the bytes, runes, lines & tokens of each file to stderr after validating it
+	Pipe                  bool          // Validate the document read from stdin & write it unchanged to stdout if valid, instead of validating files
+	AssertEmpty           []string      // Paths whose values must be empty objects or arrays (or absent)
+	MaxLineLength         int           // Warn about the first line longer than this many characters (0 means no limit)
+	Output                string        // Write the report to this file instead of the terminal, empty means no file
+
+	// Policies & levels parsed into the types of the packages applying them
+	InvalidUTF8   lexer.UTF8Policy            // How to handle invalid UTF-8 bytes (empty means lexer.DefaultUTF8Policy)
+	DuplicateKeys parser.DuplicateKeyPolicy   // How to report duplicate keys (empty means parser.DefaultDuplicateKeyPolicy)
+	Encoding      input.Encoding              // Character encoding of the input (empty means input.DefaultEncoding)
+	Rules         map[string]parser.RuleLevel // Level of each lint rule configured by the -rules config, the flags take precedence
+}
+
+// ParseArgs parses the command-line arguments into a Config.
+// argv should not include the program name (i.e. pass os.Args[1:]).
+//
+// Returns:
+// Config containing the parsed settings, or ErrUsage if the arguments are invalid
+func ParseArgs(argv []string) (Config, error) {
+	cfg := Config{Indent: DefaultIndent}
+
+	fs := flag.NewFlagSet("jl", flag.ContinueOnError)
+	fs.SetOutput(io.Discard) // Errors are returned to the caller instead of being printed
+	fs.BoolVar(&cfg.DisallowDuplicateKeys, "disallow-duplicate-keys", false, "report duplicate keys within an object as invalid")
+	fs.DurationVar(&cfg.Timeout, "timeout", 0, "cancel the run if it takes longer than this duration (ex. 30s)")
+	fs.BoolVar(&cfg.AllowComments, "jsonc", false, "accept comments (JSONC), enabling \"// linter-disable <rule>\" directives")
+	fs.BoolVar(&cfg.Depth, "depth", false, "print the maximum nesting depth of objects & arrays in a valid document")
+	fs.StringVar(&cfg.CPUProfile, "cpuprofile", "", "write a CPU profile of the run to `file`")
+	fs.StringVar(&cfg.MemProfile, "memprofile", "", "write a memory (heap) profile at the end of the run to `file`")
+	fs.Var((*stringList)(&cfg.Ignore), "ignore", "skip files & directories matching the `glob` when scanning directories (repeatable)")
+	fs.BoolVar(&cfg.InferSchema, "infer-schema", false, "print a best-effort JSON Schema inferred from a valid document")
+	fs.IntVar(&cfg.MaxStringLength, "max-string-length", 0, "reject strings longer than `n` characters (0 means no limit)")
+	fs.Func("require-keys", "comma-separated `keys` the root object must contain (ex. name,version)", func(value string) error {
+		for _, key := range strings.Split(value, ",") {
+			if key = strings.TrimSpace(key); key != "" {
+				cfg.RequireKeys = append(cfg.RequireKeys, key)
+			}
+		}
+		return nil
+	})
+	fs.StringVar(&cfg.Unwrap, "unwrap", "", "also validate the JSON embedded in the string at `path` (ex. event.records[0].body)")
+	fs.IntVar(&cfg.MaxErrors, "max-errors", DefaultMaxErrors, "stop after reporting `n` errors, the warnings not counting (0 means no limit)")
+	fs.StringVar(&cfg.Schema, "schema", "", "validate the documents against the JSON Schema located at `file`")
+	fs.StringVar(&cfg.SchemaDraft, "schema-draft", "", "interpret the schema according to the `draft` (draft-07 or 2020-12, default draft-07)")
+	fs.BoolVar(&cfg.DryRun, "dry-run", false, "list the files which would be validated (after expanding manifests, directories & ignores) w/o validating them")
+	fs.BoolVar(&cfg.SummaryJSON, "summary-json", false, "print a JSON summary of the run w/ the total, valid & invalid counts and the result of each file")
+	fs.BoolVar(&cfg.Compare, "compare", false, "print the structural differences (w/ their paths) between 2 valid files, ignoring formatting & key order")
+	fs.BoolVar(&cfg.Format, "format", false, "print the formatted document, w/ each member & element on its own line")
+	fs.Func("indent", "indent each nesting level of the formatted document by `n` spaces or a tab (\"tab\"), default 2", func(value string) error {
+		indent, err := parseIndent(value)
+		cfg.Indent = indent
+		return err
+	})
+	fs.BoolVar(&cfg.RequireFinalNewline, "require-final-newline", false, "warn if the document doesn't end w/ exactly one newline")
+	fs.BoolVar(&cfg.NoFinalNewline, "no-final-newline", false, "warn if the document ends w/ a newline")
+	fs.BoolVar(&cfg.CheckEncoding, "check-encoding", false, "verify the whole input is valid UTF-8 before lexing it, reporting the byte offset of the first invalid sequence")
+	fs.BoolVar(&cfg.Base64, "base64", false, "decode the input from base64 before validating it (positions are within the decoded JSON)")
+	fs.Func("invalid-utf8", fmt.Sprintf("handle invalid UTF-8 bytes according to the `policy` (%s, %s or %s, default %s)",
+		lexer.UTF8Accept, lexer.UTF8Reject, lexer.UTF8Replace, lexer.DefaultUTF8Policy), func(value string) error {
+		policy, err := lexer.ParseUTF8Policy(value)
+		cfg.InvalidUTF8 = policy
+		return err
+	})
+	fs.StringVar(&cfg.Graph, "graph", "", "print the structure of a valid document as a graph in the `format` (only dot, for Graphviz)")
+	fs.BoolVar(&cfg.PreserveTrivia, "preserve-trivia", false, "keep the whitespace & comments around the tokens, warning if the indentation mixes tabs & spaces")
+	fs.BoolVar(&cfg.ExitZero, "exit-zero", false, "exit w/ 0 even if a file is invalid, the findings are still printed")
+	fs.StringVar(&cfg.ExpectType, "expect-type", "", "report the document as invalid unless the root is of the `type` (object, array, string, number, boolean or null)")
+	fs.BoolVar(&cfg.KeysOnly, "keys-only", false, "print the distinct paths of the object keys of a valid document, sorted w/ array indices collapsed to []")
+	fs.Func("duplicate-keys", fmt.Sprintf("report duplicate keys within an object according to the `policy` (%s, %s or %s, default %s)",
+		parser.DuplicateKeyError, parser.DuplicateKeyWarn, parser.DuplicateKeyKeepLast, parser.DefaultDuplicateKeyPolicy), func(value string) error {
+		policy, err := parser.ParseDuplicateKeyPolicy(value)
+		cfg.DuplicateKeys = policy
+		return err
+	})
+	fs.BoolVar(&cfg.Trace, "trace", false, "log each decision of the parser (ex. entering an object or reading a key) w/ the current token & its position to stderr")
+	fs.BoolVar(&cfg.Relaxed, "relaxed", false, "accept syntax beyond strict JSON, as found in JSON5: hexadecimal integers (ex. 0x1F)")
+	fs.BoolVar(&cfg.TokensJSON, "tokens-json", false, "print the tokens of a valid document as a JSON array of {type, lexeme, line, colStart, colEnd} objects")
+	fs.BoolVar(&cfg.Watch, "watch", false, "validate the files, then re-validate each file whenever it changes until interrupted")
+	fs.BoolVar(&cfg.JSONSeq, "json-seq", false, "validate each record of a JSON text sequence (RFC 7464), separated by the RS byte (0x1E), as an independent document")
+	fs.BoolVar(&cfg.Canonical, "canonical", false, "print the canonical form (RFC 8785) of a valid document, w/o a trailing newline, ex. for signing")
+	fs.Func("modified-since", "skip files of scanned directories last modified before the `time` (ex. 2024-01-01 or 2024-01-01T12:00:00Z)", func(value string) error {
+		since, err := parseTime(value)
+		cfg.ModifiedSince = since
+		return err
+	})
+	fs.BoolVar(&cfg.OnlyErrors, "only-errors", false, "print only the errors, each prefixed w/ its file, staying silent for valid files (warnings are dropped)")
+	fs.BoolVar(&cfg.Progress, "progress", false, "report the percentage of each file read to stderr, every 10% (not for inputs of unknown size, ex. URLs)")
+	fs.BoolVar(&cfg.FirstErrorOnly, "first-error-only", false, "report only the 1st error of each file & drop the warnings, still validating every file")
+	fs.BoolVar(&cfg.ForbidEmptyObject, "forbid-empty-object", false, "warn about each empty object ({}) at any nesting level")
+	fs.BoolVar(&cfg.ForbidEmptyArray, "forbid-empty-array", false, "warn about each empty array ([]) at any nesting level")
+	fs.Func("encoding", fmt.Sprintf("decode the input from the `encoding` before validating it (%s, %s w/ a byte order mark, %s or %s, default %s)",
+		input.EncodingUTF8, input.EncodingUTF16, input.EncodingUTF16LE, input.EncodingUTF16BE, input.DefaultEncoding), func(value string) error {
+		encoding, err := input.ParseEncoding(value)
+		cfg.Encoding = encoding
+		return err
+	})
+	fs.BoolVar(&cfg.HomogeneousArrays, "homogeneous-arrays", false, "warn about each array mixing value types (ex. numbers & strings), objects of any shape are of the same type")
+	fs.BoolVar(&cfg.NormalizeNumbers, "normalize-numbers", false, "normalize the numbers of the formatted document w/o changing their value (ex. 1.50E5 -> 1.5e5), requires -format")
+	fs.StringVar(&cfg.Serve, "serve", "", "listen on the `address` (host:port or unix:path) & validate the document sent on each connection, writing back a JSON result")
+	fs.IntVar(&cfg.MaxArrayElements, "max-array-elements", 0, "reject arrays w/ more than `n` elements (0 means no limit)")
+	fs.IntVar(&cfg.MaxObjectMembers, "max-object-members", 0, "reject objects w/ more than `n` members, duplicate keys included (0 means no limit)")
+	fs.Func("enum", "require the value at a path to be one of the comma-separated strings, as `path=values` (ex. status=active,inactive) (repeatable)", func(value string) error {
+		enum, err := parseEnum(value)
+		if err != nil {
+			return err
+		}
+		cfg.Enums = append(cfg.Enums, enum)
+		return nil
+	})
+	fs.BoolVar(&cfg.RelaxedKeys, "relaxed-keys", false, "accept unquoted (ex. max_size) & single-quoted (ex. 'name') object keys, values remain strict JSON")
+	fs.BoolVar(&cfg.AllowNaNInf, "allow-nan-inf", false, "accept NaN, Infinity & -Infinity as numbers, as some decoders do (ex. Python's json.loads)")
+	fs.IntVar(&cfg.MaxTokenLength, "max-token-length", 0, "reject any string, number, literal or comment longer than `n` characters w/o buffering it (0 means no limit)")
+	fs.IntVar(&cfg.MaxNumberLength, "max-number-length", 0, "reject number literals longer than `n` characters (0 means 1048576, negative means no limit)")
+	fs.BoolVar(&cfg.Markdown, "markdown", false, "validate each json fenced code block of Markdown files as an independent document, scanning directories for .md files")
+	fs.IntVar(&cfg.MaxFiles, "max-files", 0, "stop the run after validating `n` files, warning that it's truncated (0 means no limit)")
+	fs.IntVar(&cfg.MaxLines, "max-lines", 0, "stop reading a file past `n` lines, reporting it as an error (0 means no limit)")
+	fs.BoolVar(&cfg.DetectIndent, "detect-indent", false, "print the indentation style of a valid document: tabs, n spaces (ex. 2 spaces), mixed or unknown")
+	fs.BoolVar(&cfg.Verbose, "verbose", false, "print the number of bytes, runes, lines & tokens of each file to stderr after validating it")
+	fs.BoolVar(&cfg.Pipe, "pipe", false, "read a document from stdin & write it unchanged to stdout if valid (nothing otherwise), to gate a shell pipeline")
+	fs.Var((*stringList)(&cfg.AssertEmpty), "assert-empty", "require the value at the `path` (ex. overrides) to be an empty object or array, or absent (repeatable)")
+	fs.IntVar(&cfg.MaxLineLength, "max-line-length", 0, "warn about the first line longer than `n` characters, ex. of a minified file (0 means no limit)")
+	fs.StringVar(&cfg.Output, "output", "", "write the report to `file`, creating its parent directories: the output of -summary-json, -format, ... (the diagnostics staying on stderr), or else the files validated & their diagnostics")
+	rulesPath := fs.String("rules", "", "read the level (off, warn or error) of each lint rule from the JSON object in `file`, the flags take precedence")
+	fs.StringVar(&cfg.ReportFormat, "report-format", "", fmt.Sprintf("print the diagnostics of every file as a report in the `format` (%s)", strings.Join(reportFormats, ", ")))
+	fs.BoolVar(&cfg.EmitWhitespace, "emit-whitespace", false, "also print the runs of whitespace (WS) & each newline (NEWLINE) as tokens w/ -tokens-json")
+
+	if err := fs.Parse(argv); err != nil {
+		return Config{}, fmt.Errorf("%w: %v", ErrUsage, err)
+	}
+
+	if cfg.Graph != "" && cfg.Graph != "dot" {
+		return Config{}, fmt.Errorf("%w: unknown -graph format %q, expected \"dot\"", ErrUsage, cfg.Graph)
+	}
+
+	if cfg.ReportFormat != "" && !slices.Contains(reportFormats, cfg.ReportFormat) {
+		return Config{}, fmt.Errorf("%w: unknown -report-format %q, expected one of %s", ErrUsage, cfg.ReportFormat, strings.Join(reportFormats, ", "))
+	}
+
+	// -disallow-duplicate-keys is a shorthand for -duplicate-keys=error
+	if cfg.DisallowDuplicateKeys {
+		if cfg.DuplicateKeys != "" && cfg.DuplicateKeys != parser.DuplicateKeyError {
+			return Config{}, fmt.Errorf("%w: -disallow-duplicate-keys conflicts w/ -duplicate-keys=%s", ErrUsage, cfg.DuplicateKeys)
+		}
+		cfg.DuplicateKeys = parser.DuplicateKeyError
+	}
+
+	if *rulesPath != "" {
+		rules, err := loadRules(*rulesPath)
+		if err != nil {
+			return Config{}, err
+		}
+		cfg.Rules = rules
+
+		set := make(map[string]bool)
+		fs.Visit(func(f *flag.Flag) { set[f.Name] = true })
+		applyRules(&cfg, set)
+	}
+
+	if cfg.NormalizeNumbers && !cfg.Format {
+		return Config{}, fmt.Errorf("%w: -normalize-numbers requires -format", ErrUsage)
+	}
+
+	if cfg.EmitWhitespace && !cfg.TokensJSON {
+		return Config{}, fmt.Errorf("%w: -emit-whitespace requires -tokens-json", ErrUsage)
+	}
+	// The whitespace is lexed as tokens instead of the trivia these flags read
+	if cfg.EmitWhitespace && (cfg.PreserveTrivia || cfg.DetectIndent) {
+		return Config{}, fmt.Errorf("%w: -emit-whitespace conflicts w/ -preserve-trivia & -detect-indent", ErrUsage)
+	}
+
+	if cfg.RequireFinalNewline && cfg.NoFinalNewline {
+		return Config{}, fmt.Errorf("%w: -require-final-newline and -no-final-newline are mutually exclusive", ErrUsage)
+	}
+
+	if cfg.Markdown && cfg.JSONSeq {
+		return Config{}, fmt.Errorf("%w: -markdown and -json-seq are mutually exclusive", ErrUsage)
+	}
+
+	// Reject malformed globs up front instead of silently never matching
+	for _, pattern := range cfg.Ignore {
+		if _, err := filepath.Match(pattern, ""); err != nil {
+			return Config{}, fmt.Errorf("%w: invalid -ignore pattern %q: %v", ErrUsage, pattern, err)
+		}
+	}
+
+	// The positional arguments are filepaths or manifests (@file) listing filepaths,
+	// falling back to the environment variable if there are none
+	positional := fs.Args()
+	if cfg.Pipe {
+		// The document is read from stdin instead
+		if len(positional) > 0 {
+			return Config{}, fmt.Errorf("%w: -pipe reads the document from stdin, got %d files", ErrUsage, len(positional))
+		}
+		return cfg, nil
+	}
+	if len(positional) == 0 {
+		if envPath := os.Getenv(EnvFilePath); envPath != "" {
+			positional = []string{envPath}
+		}
+	}
+	paths, err := expandPaths(positional)
+	if err != nil {
+		return Config{}, err
+	}
+	// The server receives the documents to validate instead
+	if len(paths) == 0 && cfg.Serve == "" {
+		return Config{}, ErrUsage
+	}
+	cfg.FilePaths = paths
+
+	if cfg.Compare && len(cfg.FilePaths) != 2 {
+		return Config{}, fmt.Errorf("%w: -compare expects exactly 2 files, got %d", ErrUsage, len(cfg.FilePaths))
+	}
+
+	return cfg, nil
+}
+
+// parseEnum converts the value of an -enum flag, a path & the comma-separated strings allowed at that path, into an enum
+func parseEnum(value string) (parser.Enum, error) {
+	path, list, found := strings.Cut(value, "=")
+	if !found || strings.TrimSpace(path) == "" {
+		return parser.Enum{}, fmt.Errorf("invalid enum %q, expected path=values (ex. status=active,inactive)", value)
+	}
+
+	enum := parser.Enum{Path: strings.TrimSpace(path)}
+	for _, v := range strings.Split(list, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			enum.Values = append(enum.Values, v)
+		}
+	}
+	if len(enum.Values) == 0 {
+		return parser.Enum{}, fmt.Errorf("invalid enum %q, expected at least 1 value", value)
+	}
+	return enum, nil
+}
+
+// parseIndent converts the value of the -indent flag, either a number of spaces or "tab", into the indentation
+func parseIndent(value string) (string, error) {
+	if value == "tab" {
+		return "\t", nil
+	}
+
+	spaces, err := strconv.Atoi(value)
+	if err != nil || spaces < 0 {
+		return "", fmt.Errorf("invalid indent %q, expected a number of spaces or \"tab\"", value)
+	}
+	return strings.Repeat(" ", spaces), nil
+}
+
+// stringList is a flag.Value collecting the values of a repeatable flag
+type stringList []string
+
+func (l *stringList) String() string {
+	return strings.Join(*l, ",")
+}
+
+func (l *stringList) Set(value string) error {
+	*l = append(*l, value)
+	return nil
+}
+
+// GetFilePath parses and returns the first passed in filepath.
+// Exits the app if the arguments are invalid or name no file (ex. w/ -pipe or -serve), use ParseArgs to handle the error instead.
 //
 // Returns:
 // string containing the file path
 func GetFilePath() string {
-	if len(os.Args) != 2 {
-		fmt.Println("Usage: parser <filepath>")
+	cfg, err := ParseArgs(os.Args[1:]) // 1st arg is the app binary
+	if err == nil && len(cfg.FilePaths) == 0 {
+		err = fmt.Errorf("%w: no file path provided", ErrUsage)
+	}
+	if err != nil {
+		fmt.Println(err)
 		os.Exit(1) // Exit the app w/ a non-zero status code to indicate an error
 	}
 
-	return os.Args[1] // 1st arg is the app binary, 2nd arg should be the filepath
+	return cfg.FilePaths[0]
+}
+
+// timeLayouts lists the layouts accepted by parseTime, a date w/o a time is midnight UTC
+var timeLayouts = []string{time.DateOnly, time.RFC3339}
+
+// parseTime parses a date (ex. 2024-01-01) or an RFC 3339 time (ex. 2024-01-01T12:00:00Z)
+func parseTime(value string) (time.Time, error) {
+	for _, layout := range timeLayouts {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid time %q, expected a date (2006-01-02) or an RFC 3339 time (2006-01-02T15:04:05Z07:00)", value)
 }
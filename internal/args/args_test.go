@@ -0,0 +1,649 @@
+package args
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/pszponder/json-linter_go/internal/input"
+	"github.com/pszponder/json-linter_go/internal/lexer"
+	"github.com/pszponder/json-linter_go/internal/parser"
+)
+
+func TestParseArgs(t *testing.T) {
+	// Define test cases
+	testCases := []struct {
+		name           string
+		argv           []string
+		expectedConfig Config
+		expectedErr    error
+	}{
+		{
+			name:        "no args",
+			argv:        []string{},
+			expectedErr: ErrUsage,
+		},
+		{
+			name:           "multiple filepaths",
+			argv:           []string{"a.json", "b.json"},
+			expectedConfig: withDefaults(Config{FilePaths: []string{"a.json", "b.json"}}),
+		},
+		{
+			name:           "single filepath",
+			argv:           []string{"tests/step1/valid.json"},
+			expectedConfig: withDefaults(Config{FilePaths: []string{"tests/step1/valid.json"}}),
+		},
+		{
+			name:        "unknown flag",
+			argv:        []string{"-unknown", "a.json"},
+			expectedErr: ErrUsage,
+		},
+		{
+			name:           "disallow duplicate keys",
+			argv:           []string{"-disallow-duplicate-keys", "a.json"},
+			expectedConfig: withDefaults(Config{FilePaths: []string{"a.json"}, DisallowDuplicateKeys: true, DuplicateKeys: parser.DuplicateKeyError}),
+		},
+		{
+			name:           "timeout",
+			argv:           []string{"-timeout=30s", "https://example.com/a.json"},
+			expectedConfig: withDefaults(Config{FilePaths: []string{"https://example.com/a.json"}, Timeout: 30 * time.Second}),
+		},
+		{
+			name:           "jsonc",
+			argv:           []string{"-jsonc", "settings.jsonc"},
+			expectedConfig: withDefaults(Config{FilePaths: []string{"settings.jsonc"}, AllowComments: true}),
+		},
+		{
+			name:           "depth",
+			argv:           []string{"-depth", "a.json"},
+			expectedConfig: withDefaults(Config{FilePaths: []string{"a.json"}, Depth: true}),
+		},
+		{
+			name:           "infer schema",
+			argv:           []string{"-infer-schema", "a.json"},
+			expectedConfig: withDefaults(Config{FilePaths: []string{"a.json"}, InferSchema: true}),
+		},
+		{
+			name:           "max string length",
+			argv:           []string{"-max-string-length=1024", "a.json"},
+			expectedConfig: withDefaults(Config{FilePaths: []string{"a.json"}, MaxStringLength: 1024}),
+		},
+		{
+			name:           "require keys",
+			argv:           []string{"-require-keys=name, version,,", "a.json"},
+			expectedConfig: withDefaults(Config{FilePaths: []string{"a.json"}, RequireKeys: []string{"name", "version"}}),
+		},
+		{
+			name:           "unwrap",
+			argv:           []string{"-unwrap=event.body", "a.json"},
+			expectedConfig: withDefaults(Config{FilePaths: []string{"a.json"}, Unwrap: "event.body"}),
+		},
+		{
+			name:           "max errors",
+			argv:           []string{"-max-errors=0", "a.json"},
+			expectedConfig: Config{FilePaths: []string{"a.json"}, Indent: DefaultIndent},
+		},
+		{
+			name:           "schema draft",
+			argv:           []string{"-schema=schema.json", "-schema-draft=2020-12", "a.json"},
+			expectedConfig: withDefaults(Config{FilePaths: []string{"a.json"}, Schema: "schema.json", SchemaDraft: "2020-12"}),
+		},
+		{
+			name:           "dry run",
+			argv:           []string{"-dry-run", "src"},
+			expectedConfig: withDefaults(Config{FilePaths: []string{"src"}, DryRun: true}),
+		},
+		{
+			name:           "summary json",
+			argv:           []string{"-summary-json", "a.json", "b.json"},
+			expectedConfig: withDefaults(Config{FilePaths: []string{"a.json", "b.json"}, SummaryJSON: true}),
+		},
+		{
+			name:           "compare",
+			argv:           []string{"-compare", "a.json", "b.json"},
+			expectedConfig: withDefaults(Config{FilePaths: []string{"a.json", "b.json"}, Compare: true}),
+		},
+		{
+			name:        "compare w/ a single file",
+			argv:        []string{"-compare", "a.json"},
+			expectedErr: ErrUsage,
+		},
+		{
+			name:           "format w/ tab indent",
+			argv:           []string{"-format", "-indent=tab", "a.json"},
+			expectedConfig: withDefaults(Config{FilePaths: []string{"a.json"}, Format: true, Indent: "\t"}),
+		},
+		{
+			name:           "format w/ 4 spaces indent",
+			argv:           []string{"-format", "-indent=4", "a.json"},
+			expectedConfig: withDefaults(Config{FilePaths: []string{"a.json"}, Format: true, Indent: "    "}),
+		},
+		{
+			name:           "format w/o indent",
+			argv:           []string{"-format", "-indent=0", "a.json"},
+			expectedConfig: Config{FilePaths: []string{"a.json"}, Format: true, Indent: "", MaxErrors: DefaultMaxErrors},
+		},
+		{
+			name:        "invalid indent",
+			argv:        []string{"-format", "-indent=-2", "a.json"},
+			expectedErr: ErrUsage,
+		},
+		{
+			name:           "require final newline",
+			argv:           []string{"-require-final-newline", "a.json"},
+			expectedConfig: withDefaults(Config{FilePaths: []string{"a.json"}, RequireFinalNewline: true}),
+		},
+		{
+			name:        "both final newline policies",
+			argv:        []string{"-require-final-newline", "-no-final-newline", "a.json"},
+			expectedErr: ErrUsage,
+		},
+		{
+			name:           "check encoding",
+			argv:           []string{"-check-encoding", "a.json"},
+			expectedConfig: withDefaults(Config{FilePaths: []string{"a.json"}, CheckEncoding: true}),
+		},
+		{
+			name:           "base64",
+			argv:           []string{"-base64", "a.b64"},
+			expectedConfig: withDefaults(Config{FilePaths: []string{"a.b64"}, Base64: true}),
+		},
+		{
+			name:           "invalid utf-8 policy",
+			argv:           []string{"-invalid-utf8=replace", "a.json"},
+			expectedConfig: withDefaults(Config{FilePaths: []string{"a.json"}, InvalidUTF8: lexer.UTF8Replace}),
+		},
+		{
+			name:        "unknown invalid utf-8 policy",
+			argv:        []string{"-invalid-utf8=ignore", "a.json"},
+			expectedErr: ErrUsage,
+		},
+		{
+			name:           "graph",
+			argv:           []string{"-graph=dot", "a.json"},
+			expectedConfig: withDefaults(Config{FilePaths: []string{"a.json"}, Graph: "dot"}),
+		},
+		{
+			name:        "unknown graph format",
+			argv:        []string{"-graph=svg", "a.json"},
+			expectedErr: ErrUsage,
+		},
+		{
+			name:           "preserve trivia",
+			argv:           []string{"-preserve-trivia", "a.json"},
+			expectedConfig: withDefaults(Config{FilePaths: []string{"a.json"}, PreserveTrivia: true}),
+		},
+		{
+			name:           "exit zero",
+			argv:           []string{"-exit-zero", "a.json"},
+			expectedConfig: withDefaults(Config{FilePaths: []string{"a.json"}, ExitZero: true}),
+		},
+		{
+			name:           "expect type",
+			argv:           []string{"-expect-type=array", "a.json"},
+			expectedConfig: withDefaults(Config{FilePaths: []string{"a.json"}, ExpectType: "array"}),
+		},
+		{
+			name:           "keys only",
+			argv:           []string{"-keys-only", "a.json"},
+			expectedConfig: withDefaults(Config{FilePaths: []string{"a.json"}, KeysOnly: true}),
+		},
+		{
+			name:           "duplicate key policy",
+			argv:           []string{"-duplicate-keys=keep-last", "a.json"},
+			expectedConfig: withDefaults(Config{FilePaths: []string{"a.json"}, DuplicateKeys: parser.DuplicateKeyKeepLast}),
+		},
+		{
+			name:        "unknown duplicate key policy",
+			argv:        []string{"-duplicate-keys=ignore", "a.json"},
+			expectedErr: ErrUsage,
+		},
+		{
+			name:        "conflicting duplicate key policies",
+			argv:        []string{"-disallow-duplicate-keys", "-duplicate-keys=warn", "a.json"},
+			expectedErr: ErrUsage,
+		},
+		{
+			name:           "trace",
+			argv:           []string{"-trace", "a.json"},
+			expectedConfig: withDefaults(Config{FilePaths: []string{"a.json"}, Trace: true}),
+		},
+		{
+			name:           "relaxed",
+			argv:           []string{"-relaxed", "a.json5"},
+			expectedConfig: withDefaults(Config{FilePaths: []string{"a.json5"}, Relaxed: true}),
+		},
+		{
+			name:           "tokens json",
+			argv:           []string{"-tokens-json", "a.json"},
+			expectedConfig: withDefaults(Config{FilePaths: []string{"a.json"}, TokensJSON: true}),
+		},
+		{
+			name:           "watch",
+			argv:           []string{"-watch", "a.json"},
+			expectedConfig: withDefaults(Config{FilePaths: []string{"a.json"}, Watch: true}),
+		},
+		{
+			name:           "json seq",
+			argv:           []string{"-json-seq", "a.json-seq"},
+			expectedConfig: withDefaults(Config{FilePaths: []string{"a.json-seq"}, JSONSeq: true}),
+		},
+		{
+			name:           "canonical",
+			argv:           []string{"-canonical", "a.json"},
+			expectedConfig: withDefaults(Config{FilePaths: []string{"a.json"}, Canonical: true}),
+		},
+		{
+			name:           "modified since date",
+			argv:           []string{"-modified-since=2024-01-01", "src"},
+			expectedConfig: withDefaults(Config{FilePaths: []string{"src"}, ModifiedSince: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}),
+		},
+		{
+			name:           "modified since time",
+			argv:           []string{"-modified-since=2024-01-01T12:30:00Z", "src"},
+			expectedConfig: withDefaults(Config{FilePaths: []string{"src"}, ModifiedSince: time.Date(2024, 1, 1, 12, 30, 0, 0, time.UTC)}),
+		},
+		{
+			name:        "invalid modified since",
+			argv:        []string{"-modified-since=yesterday", "src"},
+			expectedErr: ErrUsage,
+		},
+		{
+			name:           "only errors",
+			argv:           []string{"-only-errors", "src"},
+			expectedConfig: withDefaults(Config{FilePaths: []string{"src"}, OnlyErrors: true}),
+		},
+		{
+			name:           "progress",
+			argv:           []string{"-progress", "big.json"},
+			expectedConfig: withDefaults(Config{FilePaths: []string{"big.json"}, Progress: true}),
+		},
+		{
+			name:           "first error only",
+			argv:           []string{"-first-error-only", "a.json", "b.json"},
+			expectedConfig: withDefaults(Config{FilePaths: []string{"a.json", "b.json"}, FirstErrorOnly: true}),
+		},
+		{
+			name:           "forbid empty containers",
+			argv:           []string{"-forbid-empty-object", "-forbid-empty-array", "a.json"},
+			expectedConfig: withDefaults(Config{FilePaths: []string{"a.json"}, ForbidEmptyObject: true, ForbidEmptyArray: true}),
+		},
+		{
+			name:           "encoding",
+			argv:           []string{"-encoding=utf16le", "a.json"},
+			expectedConfig: withDefaults(Config{FilePaths: []string{"a.json"}, Encoding: input.EncodingUTF16LE}),
+		},
+		{
+			name:        "unknown encoding",
+			argv:        []string{"-encoding=latin1", "a.json"},
+			expectedErr: ErrUsage,
+		},
+		{
+			name:           "homogeneous arrays",
+			argv:           []string{"-homogeneous-arrays", "a.json"},
+			expectedConfig: withDefaults(Config{FilePaths: []string{"a.json"}, HomogeneousArrays: true}),
+		},
+		{
+			name:           "normalize numbers",
+			argv:           []string{"-format", "-normalize-numbers", "a.json"},
+			expectedConfig: withDefaults(Config{FilePaths: []string{"a.json"}, Format: true, NormalizeNumbers: true}),
+		},
+		{
+			name:        "normalize numbers w/o format",
+			argv:        []string{"-normalize-numbers", "a.json"},
+			expectedErr: ErrUsage,
+		},
+		{
+			name:           "emit whitespace",
+			argv:           []string{"-tokens-json", "-emit-whitespace", "a.json"},
+			expectedConfig: withDefaults(Config{FilePaths: []string{"a.json"}, TokensJSON: true, EmitWhitespace: true}),
+		},
+		{
+			name:        "emit whitespace w/o tokens json",
+			argv:        []string{"-emit-whitespace", "a.json"},
+			expectedErr: ErrUsage,
+		},
+		{
+			name:        "emit whitespace w/ detect indent",
+			argv:        []string{"-tokens-json", "-emit-whitespace", "-detect-indent", "a.json"},
+			expectedErr: ErrUsage,
+		},
+		{
+			name:           "serve w/o filepath",
+			argv:           []string{"-serve=localhost:7070"},
+			expectedConfig: withDefaults(Config{Serve: "localhost:7070"}),
+		},
+		{
+			name:           "output",
+			argv:           []string{"-output=reports/report.json", "-summary-json", "a.json"},
+			expectedConfig: withDefaults(Config{FilePaths: []string{"a.json"}, Output: "reports/report.json", SummaryJSON: true}),
+		},
+		{
+			name: "repeated enum",
+			argv: []string{"-enum=status=active, inactive,pending", "-enum", "users[0].role=admin", "a.json"},
+			expectedConfig: withDefaults(Config{FilePaths: []string{"a.json"}, Enums: []parser.Enum{
+				{Path: "status", Values: []string{"active", "inactive", "pending"}},
+				{Path: "users[0].role", Values: []string{"admin"}},
+			}}),
+		},
+		{
+			name:        "enum w/o values",
+			argv:        []string{"-enum=status=", "a.json"},
+			expectedErr: ErrUsage,
+		},
+		{
+			name:        "enum w/o path",
+			argv:        []string{"-enum=active,inactive", "a.json"},
+			expectedErr: ErrUsage,
+		},
+		{
+			name:           "max values",
+			argv:           []string{"-max-array-elements=1000", "-max-object-members=50", "a.json"},
+			expectedConfig: withDefaults(Config{FilePaths: []string{"a.json"}, MaxArrayElements: 1000, MaxObjectMembers: 50}),
+		},
+		{
+			name:           "checkstyle report format",
+			argv:           []string{"-report-format=checkstyle", "a.json"},
+			expectedConfig: withDefaults(Config{FilePaths: []string{"a.json"}, ReportFormat: FormatCheckstyle}),
+		},
+		{
+			name:           "boolean format",
+			argv:           []string{"-format=true", "a.json"},
+			expectedConfig: withDefaults(Config{FilePaths: []string{"a.json"}, Format: true}),
+		},
+		{
+			name:        "unknown report format",
+			argv:        []string{"-report-format=html", "a.json"},
+			expectedErr: ErrUsage,
+		},
+		{
+			name:        "report format passed to -format",
+			argv:        []string{"-format=checkstyle", "a.json"},
+			expectedErr: ErrUsage,
+		},
+		{
+			name:           "relaxed keys",
+			argv:           []string{"-relaxed-keys", "a.json"},
+			expectedConfig: withDefaults(Config{FilePaths: []string{"a.json"}, RelaxedKeys: true}),
+		},
+		{
+			name:           "verbose",
+			argv:           []string{"-verbose", "a.json"},
+			expectedConfig: withDefaults(Config{FilePaths: []string{"a.json"}, Verbose: true}),
+		},
+		{
+			name:           "allow NaN & Infinity",
+			argv:           []string{"-allow-nan-inf", "a.json"},
+			expectedConfig: withDefaults(Config{FilePaths: []string{"a.json"}, AllowNaNInf: true}),
+		},
+		{
+			name:           "detect indent",
+			argv:           []string{"-detect-indent", "a.json"},
+			expectedConfig: withDefaults(Config{FilePaths: []string{"a.json"}, DetectIndent: true}),
+		},
+		{
+			name:           "max lines",
+			argv:           []string{"-max-lines=1000", "a.json"},
+			expectedConfig: withDefaults(Config{FilePaths: []string{"a.json"}, MaxLines: 1000}),
+		},
+		{
+			name:           "max files",
+			argv:           []string{"-max-files=100", "src"},
+			expectedConfig: withDefaults(Config{FilePaths: []string{"src"}, MaxFiles: 100}),
+		},
+		{
+			name:           "markdown",
+			argv:           []string{"-markdown", "docs"},
+			expectedConfig: withDefaults(Config{FilePaths: []string{"docs"}, Markdown: true}),
+		},
+		{
+			name:        "markdown json-seq",
+			argv:        []string{"-markdown", "-json-seq", "docs"},
+			expectedErr: ErrUsage,
+		},
+		{
+			name:           "max token length",
+			argv:           []string{"-max-token-length=4096", "a.json"},
+			expectedConfig: withDefaults(Config{FilePaths: []string{"a.json"}, MaxTokenLength: 4096}),
+		},
+		{
+			name:           "max number length",
+			argv:           []string{"-max-number-length=-1", "a.json"},
+			expectedConfig: withDefaults(Config{FilePaths: []string{"a.json"}, MaxNumberLength: -1}),
+		},
+		{
+			name:           "pipe",
+			argv:           []string{"-pipe"},
+			expectedConfig: withDefaults(Config{Pipe: true}),
+		},
+		{
+			name:        "pipe w/ filepath",
+			argv:        []string{"-pipe", "a.json"},
+			expectedErr: ErrUsage,
+		},
+		{
+			name:           "assert empty",
+			argv:           []string{"-assert-empty=overrides", "-assert-empty=env.flags", "a.json"},
+			expectedConfig: withDefaults(Config{FilePaths: []string{"a.json"}, AssertEmpty: []string{"overrides", "env.flags"}}),
+		},
+		{
+			name:           "max line length",
+			argv:           []string{"-max-line-length=120", "a.json"},
+			expectedConfig: withDefaults(Config{FilePaths: []string{"a.json"}, MaxLineLength: 120}),
+		},
+		{
+			name:           "profiles",
+			argv:           []string{"-cpuprofile=cpu.out", "-memprofile=mem.out", "a.json"},
+			expectedConfig: withDefaults(Config{FilePaths: []string{"a.json"}, CPUProfile: "cpu.out", MemProfile: "mem.out"}),
+		},
+		{
+			name:           "repeated ignore",
+			argv:           []string{"-ignore=node_modules", "-ignore", "fixtures/*.json", "src"},
+			expectedConfig: withDefaults(Config{FilePaths: []string{"src"}, Ignore: []string{"node_modules", "fixtures/*.json"}}),
+		},
+		{
+			name:        "invalid ignore pattern",
+			argv:        []string{"-ignore=[", "src"},
+			expectedErr: ErrUsage,
+		},
+		{
+			name:        "invalid timeout",
+			argv:        []string{"-timeout=soon", "a.json"},
+			expectedErr: ErrUsage,
+		},
+	}
+
+	// The filepath must not come from the environment
+	t.Setenv(EnvFilePath, "")
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			cfg, err := ParseArgs(testCase.argv)
+
+			if !errors.Is(err, testCase.expectedErr) {
+				t.Fatalf("Expected error %v, got %v", testCase.expectedErr, err)
+			}
+			if !reflect.DeepEqual(testCase.expectedConfig, cfg) {
+				t.Errorf("Expected config %+v, got %+v", testCase.expectedConfig, cfg)
+			}
+		})
+	}
+}
+
+// withDefaults returns the config w/ the fields left to their zero value set to the defaults of ParseArgs.
+// Rows expecting a zero value where ParseArgs has a default (ex. -max-errors=0 or -indent=0) spell the whole Config out instead.
+func withDefaults(cfg Config) Config {
+	if cfg.MaxErrors == 0 {
+		cfg.MaxErrors = DefaultMaxErrors
+	}
+	if cfg.Indent == "" {
+		cfg.Indent = DefaultIndent
+	}
+	return cfg
+}
+
+func TestParseArgsManifest(t *testing.T) {
+	dir := t.TempDir()
+	manifest := filepath.Join(dir, "list.txt")
+	content := "# Fixtures to validate\nvalid.json\n\n  invalid.json  \n# trailing comment\n"
+	if err := os.WriteFile(manifest, []byte(content), 0o644); err != nil {
+		t.Fatalf("Failed to write %s: %v", manifest, err)
+	}
+	empty := filepath.Join(dir, "empty.txt")
+	if err := os.WriteFile(empty, []byte("# nothing to validate\n\n"), 0o644); err != nil {
+		t.Fatalf("Failed to write %s: %v", empty, err)
+	}
+
+	// Define test cases
+	testCases := []struct {
+		name              string
+		argv              []string
+		expectedFilePaths []string
+		expectErr         bool
+	}{
+		{
+			name:              "manifest only",
+			argv:              []string{"@" + manifest},
+			expectedFilePaths: []string{"valid.json", "invalid.json"},
+		},
+		{
+			name:              "manifest mixed w/ filepaths",
+			argv:              []string{"a.json", "@" + manifest, "b.json"},
+			expectedFilePaths: []string{"a.json", "valid.json", "invalid.json", "b.json"},
+		},
+		{
+			name:      "empty manifest",
+			argv:      []string{"@" + empty},
+			expectErr: true,
+		},
+		{
+			name:      "missing manifest",
+			argv:      []string{"@" + filepath.Join(dir, "missing.txt")},
+			expectErr: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			cfg, err := ParseArgs(testCase.argv)
+
+			if (err != nil) != testCase.expectErr {
+				t.Fatalf("Expected error: %v, got %v", testCase.expectErr, err)
+			}
+			if !reflect.DeepEqual(testCase.expectedFilePaths, cfg.FilePaths) {
+				t.Errorf("Expected filepaths %q, got %q", testCase.expectedFilePaths, cfg.FilePaths)
+			}
+		})
+	}
+}
+
+func TestParseArgsRules(t *testing.T) {
+	// The schema rule is registered by the linter, which the args don't depend on
+	const externalRule = "schema"
+	RegisterRule(externalRule)
+
+	dir := t.TempDir()
+	writeRules := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatalf("Failed to write %s: %v", path, err)
+		}
+		return path
+	}
+	rules := writeRules("rules.json", `{"empty-container": "error", "homogeneous-arrays": "warn", "duplicate-keys": "warn", "schema": "off", "final-newline": "off"}`)
+	unknown := writeRules("unknown.json", `{"no-such-rule": "warn"}`)
+	invalid := writeRules("invalid.json", `{"schema": "maybe"}`)
+
+	// Define test cases
+	testCases := []struct {
+		name          string
+		argv          []string
+		expectedRules map[string]parser.RuleLevel
+		check         func(t *testing.T, cfg Config)
+		expectErr     bool
+	}{
+		{
+			name:          "config enables rules",
+			argv:          []string{"-rules", rules, "file.json"},
+			expectedRules: map[string]parser.RuleLevel{parser.RuleEmptyContainer: parser.RuleError, parser.RuleHomogeneousArrays: parser.RuleWarn, externalRule: parser.RuleOff, parser.RuleFinalNewline: parser.RuleOff},
+			check: func(t *testing.T, cfg Config) {
+				if !cfg.ForbidEmptyObject || !cfg.ForbidEmptyArray || !cfg.HomogeneousArrays {
+					t.Errorf("Expected the empty-container & homogeneous-arrays rules to be enabled, got %+v", cfg)
+				}
+				if cfg.DuplicateKeys != parser.DuplicateKeyWarn {
+					t.Errorf("Expected duplicate keys policy %q, got %q", parser.DuplicateKeyWarn, cfg.DuplicateKeys)
+				}
+				if cfg.RequireFinalNewline {
+					t.Errorf("Expected the final-newline rule to stay disabled")
+				}
+			},
+		},
+		{
+			name:          "flags override config",
+			argv:          []string{"-rules", rules, "-disallow-duplicate-keys", "-require-final-newline", "file.json"},
+			expectedRules: map[string]parser.RuleLevel{parser.RuleEmptyContainer: parser.RuleError, parser.RuleHomogeneousArrays: parser.RuleWarn, externalRule: parser.RuleOff},
+			check: func(t *testing.T, cfg Config) {
+				if cfg.DuplicateKeys != parser.DuplicateKeyError {
+					t.Errorf("Expected duplicate keys policy %q, got %q", parser.DuplicateKeyError, cfg.DuplicateKeys)
+				}
+				if !cfg.RequireFinalNewline {
+					t.Errorf("Expected -require-final-newline to enable the final-newline rule")
+				}
+			},
+		},
+		{name: "unknown rule", argv: []string{"-rules", unknown, "file.json"}, expectErr: true},
+		{name: "invalid level", argv: []string{"-rules", invalid, "file.json"}, expectErr: true},
+		{name: "missing config", argv: []string{"-rules", filepath.Join(dir, "missing.json"), "file.json"}, expectErr: true},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			cfg, err := ParseArgs(testCase.argv)
+
+			if (err != nil) != testCase.expectErr {
+				t.Fatalf("Expected error: %v, got %v", testCase.expectErr, err)
+			}
+			if testCase.expectErr {
+				return
+			}
+			if !reflect.DeepEqual(testCase.expectedRules, cfg.Rules) {
+				t.Errorf("Expected rules %v, got %v", testCase.expectedRules, cfg.Rules)
+			}
+			testCase.check(t, cfg)
+		})
+	}
+}
+
+func TestParseArgsEnvFilePath(t *testing.T) {
+	// Define test cases
+	testCases := []struct {
+		name              string
+		argv              []string
+		env               string
+		expectedFilePaths []string
+		expectedErr       error
+	}{
+		{name: "arg only", argv: []string{"arg.json"}, expectedFilePaths: []string{"arg.json"}},
+		{name: "env only", argv: []string{"-depth"}, env: "env.json", expectedFilePaths: []string{"env.json"}},
+		{name: "arg takes precedence", argv: []string{"arg.json"}, env: "env.json", expectedFilePaths: []string{"arg.json"}},
+		{name: "neither", argv: []string{}, expectedErr: ErrUsage},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Setenv(EnvFilePath, testCase.env)
+
+			cfg, err := ParseArgs(testCase.argv)
+
+			if !errors.Is(err, testCase.expectedErr) {
+				t.Fatalf("Expected error %v, got %v", testCase.expectedErr, err)
+			}
+			if !reflect.DeepEqual(testCase.expectedFilePaths, cfg.FilePaths) {
+				t.Errorf("Expected filepaths %q, got %q", testCase.expectedFilePaths, cfg.FilePaths)
+			}
+		})
+	}
+}
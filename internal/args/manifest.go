@@ -0,0 +1,55 @@
+package args
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// ManifestPrefix marks a positional argument as a manifest (ex. @list.txt) listing the files to validate
+const ManifestPrefix = "@"
+
+// expandPaths replaces every manifest argument by the paths it lists, keeping the order of the arguments
+func expandPaths(positional []string) ([]string, error) {
+	var paths []string
+	for _, arg := range positional {
+		if !strings.HasPrefix(arg, ManifestPrefix) {
+			paths = append(paths, arg)
+			continue
+		}
+
+		manifestPaths, err := readManifest(strings.TrimPrefix(arg, ManifestPrefix))
+		if err != nil {
+			return nil, err
+		}
+		paths = append(paths, manifestPaths...)
+	}
+
+	return paths, nil
+}
+
+// readManifest reads the newline-separated paths listed in the manifest file located at path.
+// Blank lines & lines starting with "#" (comments) are skipped.
+func readManifest(path string) ([]string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("Could not read manifest: %v", err)
+	}
+	defer file.Close()
+
+	var paths []string
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		paths = append(paths, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("Could not read manifest %s: %v", path, err)
+	}
+
+	return paths, nil
+}
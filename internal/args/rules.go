@@ -0,0 +1,118 @@
+package args
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/pszponder/json-linter_go/internal/parser"
+)
+
+// knownRules lists the lint rules which can be configured by the rule config, along w/ those added by RegisterRule
+var knownRules = []string{
+	parser.RuleDuplicateKeys,
+	parser.RuleRequiredKeys,
+	parser.RuleEmptyContainer,
+	parser.RuleEnum,
+	parser.RuleAssertEmpty,
+	parser.RuleExpectType,
+	parser.RuleFinalNewline,
+	parser.RuleInvalidUTF8,
+	parser.RuleHomogeneousArrays,
+	parser.RuleMixedIndentation,
+	parser.RuleMaxLineLength,
+	parser.RuleNumberOverflow,
+}
+
+// RegisterRule adds a lint rule implemented outside of the parser (ex. the schema rule) to the rules the rule config
+// can configure. It must be called before ParseArgs, registering a rule more than once has no effect.
+func RegisterRule(rule string) {
+	if !slices.Contains(knownRules, rule) {
+		knownRules = append(knownRules, rule)
+	}
+}
+
+// ruleFlags maps the rules enabled or configured by flags to those flags, which override the rule config
+var ruleFlags = map[string][]string{
+	parser.RuleDuplicateKeys:     {"duplicate-keys", "disallow-duplicate-keys"},
+	parser.RuleEmptyContainer:    {"forbid-empty-object", "forbid-empty-array"},
+	parser.RuleFinalNewline:      {"require-final-newline", "no-final-newline"},
+	parser.RuleHomogeneousArrays: {"homogeneous-arrays"},
+	parser.RuleMixedIndentation:  {"preserve-trivia"},
+	parser.RuleAssertEmpty:       {"assert-empty"},
+	parser.RuleMaxLineLength:     {"max-line-length"},
+}
+
+// duplicateKeyPolicies maps the levels of the duplicate-keys rule to the policy reporting duplicate keys at that level
+var duplicateKeyPolicies = map[parser.RuleLevel]parser.DuplicateKeyPolicy{
+	parser.RuleOff:   parser.DuplicateKeyKeepLast,
+	parser.RuleWarn:  parser.DuplicateKeyWarn,
+	parser.RuleError: parser.DuplicateKeyError,
+}
+
+// loadRules reads the rule config located at path, a JSON object mapping rule names to levels
+func loadRules(path string) (map[string]parser.RuleLevel, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("Could not read rule config: %v", err)
+	}
+
+	levels, err := parser.ParseRuleLevels(data)
+	if err != nil {
+		return nil, fmt.Errorf("Invalid rule config %s: %v", path, err)
+	}
+
+	var unknown []string
+	for rule := range levels {
+		if !slices.Contains(knownRules, rule) {
+			unknown = append(unknown, rule)
+		}
+	}
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return nil, fmt.Errorf("Invalid rule config %s: unknown rules %s, expected one of %s",
+			path, strings.Join(unknown, ", "), strings.Join(knownRules, ", "))
+	}
+	return levels, nil
+}
+
+// applyRules enables the opt-in rules turned on by the rule config, unless their flags were set (see ruleFlags).
+// The level of a rule turned off by the config is dropped if its flags were set, so the flags enable it.
+func applyRules(cfg *Config, set map[string]bool) {
+	for rule, level := range cfg.Rules {
+		flagSet := false
+		for _, name := range ruleFlags[rule] {
+			flagSet = flagSet || set[name]
+		}
+		if flagSet {
+			// The severity of duplicate keys is configured by the flags
+			if level == parser.RuleOff || rule == parser.RuleDuplicateKeys {
+				delete(cfg.Rules, rule)
+			}
+			continue
+		}
+		// Duplicate keys are handled according to a policy (silently keeping the last by default), the config picks it
+		if rule == parser.RuleDuplicateKeys {
+			cfg.DuplicateKeys = duplicateKeyPolicies[level]
+			delete(cfg.Rules, rule)
+			continue
+		}
+		if level == parser.RuleOff {
+			continue
+		}
+
+		switch rule {
+		case parser.RuleEmptyContainer:
+			cfg.ForbidEmptyObject = true
+			cfg.ForbidEmptyArray = true
+		case parser.RuleFinalNewline:
+			cfg.RequireFinalNewline = true
+		case parser.RuleHomogeneousArrays:
+			cfg.HomogeneousArrays = true
+		case parser.RuleMixedIndentation:
+			cfg.PreserveTrivia = true
+		}
+	}
+}
@@ -0,0 +1,42 @@
+package input
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"unicode/utf8"
+)
+
+// CheckUTF8 returns an error w/ the byte offset of the first invalid UTF-8 sequence in data, if any.
+// The error hints at UTF-16 if data starts w/ a UTF-16 byte order mark.
+func CheckUTF8(data []byte) error {
+	if utf8.Valid(data) {
+		return nil
+	}
+
+	hint := ""
+	if bytes.HasPrefix(data, bomLE) || bytes.HasPrefix(data, bomBE) {
+		hint = ", the input looks UTF-16 encoded (use -encoding=utf16)"
+	}
+
+	for offset := 0; offset < len(data); {
+		r, size := utf8.DecodeRune(data[offset:])
+		if r == utf8.RuneError && size <= 1 {
+			return fmt.Errorf("invalid UTF-8 at byte offset %d (0x%02X)%s", offset, data[offset], hint)
+		}
+		offset += size
+	}
+	return nil
+}
+
+// DecodeBase64 decodes the standard (padded) base64 encoding of a document.
+// Surrounding whitespace & line breaks within the encoding are ignored.
+func DecodeBase64(data []byte) ([]byte, error) {
+	data = bytes.TrimSpace(data)
+	decoded := make([]byte, base64.StdEncoding.DecodedLen(len(data)))
+	n, err := base64.StdEncoding.Decode(decoded, data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid base64 input: %v", err)
+	}
+	return decoded[:n], nil
+}
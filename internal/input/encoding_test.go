@@ -0,0 +1,80 @@
+package input
+
+import "testing"
+
+func TestCheckUTF8(t *testing.T) {
+	// Define test cases
+	testCases := []struct {
+		name             string
+		data             []byte
+		expectedErrorMsg string // Empty if the data is valid UTF-8
+	}{
+		{name: "ascii", data: []byte(`{"a": 1}`)},
+		{name: "multi-byte", data: []byte(`{"café": "日本"}`)},
+		{name: "escaped replacement character", data: []byte(`"\uFFFD"`)},
+		{name: "literal replacement character", data: []byte("\"�\"")},
+		{
+			name:             "latin-1",
+			data:             []byte{'{', '"', 'c', 'a', 'f', 0xE9, '"', ':', '1', '}'},
+			expectedErrorMsg: "invalid UTF-8 at byte offset 5 (0xE9)",
+		},
+		{
+			name:             "truncated sequence",
+			data:             []byte{'"', 0xE6, 0x97, '"'},
+			expectedErrorMsg: "invalid UTF-8 at byte offset 1 (0xE6)",
+		},
+		{
+			name:             "utf-16",
+			data:             []byte{0xFF, 0xFE, '{', 0, '}', 0},
+			expectedErrorMsg: "invalid UTF-8 at byte offset 0 (0xFF), the input looks UTF-16 encoded (use -encoding=utf16)",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := CheckUTF8(testCase.data)
+			if testCase.expectedErrorMsg == "" {
+				if err != nil {
+					t.Errorf("Expected no error, got %v", err)
+				}
+			} else if err == nil || err.Error() != testCase.expectedErrorMsg {
+				t.Errorf("Expected error %q, got %v", testCase.expectedErrorMsg, err)
+			}
+		})
+	}
+}
+
+func TestDecodeBase64(t *testing.T) {
+	// Define test cases
+	testCases := []struct {
+		name             string
+		data             string
+		expected         string
+		expectedErrorMsg string // Empty if the data is valid base64
+	}{
+		{name: "valid", data: "eyJhIjogMX0=", expected: `{"a": 1}`},
+		{name: "surrounding whitespace", data: "  eyJhIjogMX0=\n", expected: `{"a": 1}`},
+		{name: "wrapped lines", data: "eyJhIjog\nMX0=\n", expected: `{"a": 1}`},
+		{name: "illegal character", data: "eyJh!jogMX0=", expectedErrorMsg: "invalid base64 input: illegal base64 data at input byte 4"},
+		{name: "missing padding", data: "eyJhIjogMX0", expectedErrorMsg: "invalid base64 input: illegal base64 data at input byte 8"},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			decoded, err := DecodeBase64([]byte(testCase.data))
+			if testCase.expectedErrorMsg != "" {
+				if err == nil || err.Error() != testCase.expectedErrorMsg {
+					t.Errorf("Expected error %q, got %v", testCase.expectedErrorMsg, err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if string(decoded) != testCase.expected {
+				t.Errorf("Expected %q, got %q", testCase.expected, decoded)
+			}
+		})
+	}
+}
@@ -0,0 +1,112 @@
+package input
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+// jsonExtensions lists the extensions of the files validated when scanning a directory
+var jsonExtensions = map[string]bool{".json": true, ".jsonc": true}
+
+// Expand returns the files to validate for path.
+// A directory is scanned recursively for .json & .jsonc files, any other path (file or URL) is returned as is.
+//
+// Files & directories matching one of the ignore globs are skipped (directories are not descended into).
+// A glob is matched against the path relative to the scanned directory, as well as against the base name,
+// so "node_modules" skips every node_modules directory while "fixtures/*.json" only skips the top-level fixtures.
+func Expand(path string, ignore []string) ([]string, error) {
+	return ExpandModifiedSince(path, ignore, time.Time{}, 0)
+}
+
+// ExpandModifiedSince is like Expand, but also skips the files of a scanned directory last modified before since,
+// ex. to only re-validate the files changed since the last run (a zero since skips none).
+// A path which is not a directory is returned as is, regardless of when it was modified.
+//
+// The scan stops once limit files are found (0 means no limit), so a huge directory isn't walked in full
+// for a capped run. The files are then the first ones walked, each directory's entries being walked in lexical order.
+func ExpandModifiedSince(path string, ignore []string, since time.Time, limit int) ([]string, error) {
+	return expand(path, ignore, since, limit, jsonExtensions)
+}
+
+// ExpandMarkdown is like ExpandModifiedSince, but scans the directories for .md & .markdown files instead,
+// ex. to validate the JSON code blocks of documentation.
+func ExpandMarkdown(path string, ignore []string, since time.Time, limit int) ([]string, error) {
+	return expand(path, ignore, since, limit, markdownExtensions)
+}
+
+// expand returns the files to validate for path, a directory being scanned for at most limit files (0 means no limit)
+// w/ one of the extensions
+func expand(path string, ignore []string, since time.Time, limit int, extensions map[string]bool) ([]string, error) {
+	if IsURL(path) {
+		return []string{path}, nil
+	}
+
+	// Missing files are reported when they are opened
+	info, err := os.Stat(path)
+	if err != nil || !info.IsDir() {
+		return []string{path}, nil
+	}
+
+	var files []string
+	err = filepath.WalkDir(path, func(filePath string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if filePath == path {
+			return nil
+		}
+
+		relPath, err := filepath.Rel(path, filePath)
+		if err != nil {
+			return err
+		}
+		if isIgnored(relPath, ignore) {
+			if entry.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		if entry.IsDir() || !extensions[filepath.Ext(filePath)] {
+			return nil
+		}
+		if !since.IsZero() {
+			info, err := entry.Info()
+			if err != nil {
+				return err
+			}
+			if info.ModTime().Before(since) {
+				return nil
+			}
+		}
+		files = append(files, filePath)
+		if limit > 0 && len(files) >= limit {
+			return filepath.SkipAll
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Strings(files)
+	return files, nil
+}
+
+// isIgnored reports whether the relative path (or its base name) matches one of the ignore globs
+func isIgnored(relPath string, ignore []string) bool {
+	base := filepath.Base(relPath)
+	for _, pattern := range ignore {
+		// The patterns are validated when parsing the arguments, so errors can't occur here
+		if matched, _ := filepath.Match(pattern, relPath); matched {
+			return true
+		}
+		if matched, _ := filepath.Match(pattern, base); matched {
+			return true
+		}
+	}
+	return false
+}
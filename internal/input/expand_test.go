@@ -0,0 +1,224 @@
+package input
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestExpand(t *testing.T) {
+	// Build a directory tree w/ JSON files, other files & nested directories
+	root := t.TempDir()
+	files := []string{
+		"a.json",
+		"b.jsonc",
+		"notes.txt",
+		"nested/c.json",
+		"nested/deeper/d.json",
+		"node_modules/pkg/package.json",
+		"fixtures/generated.json",
+		"fixtures/keep/e.json",
+	}
+	for _, file := range files {
+		path := filepath.Join(root, file)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatalf("Failed to create %s: %v", filepath.Dir(path), err)
+		}
+		if err := os.WriteFile(path, []byte(`{}`), 0o644); err != nil {
+			t.Fatalf("Failed to write %s: %v", path, err)
+		}
+	}
+
+	// Define test cases
+	testCases := []struct {
+		name          string
+		path          string
+		ignore        []string
+		expectedFiles []string
+	}{
+		{
+			name:          "file",
+			path:          filepath.Join(root, "a.json"),
+			expectedFiles: []string{"a.json"},
+		},
+		{
+			name:          "url",
+			path:          "https://example.com/a.json",
+			expectedFiles: []string{"https://example.com/a.json"},
+		},
+		{
+			name: "directory",
+			path: root,
+			expectedFiles: []string{
+				"a.json",
+				"b.jsonc",
+				"fixtures/generated.json",
+				"fixtures/keep/e.json",
+				"nested/c.json",
+				"nested/deeper/d.json",
+				"node_modules/pkg/package.json",
+			},
+		},
+		{
+			name:          "ignored directory",
+			path:          root,
+			ignore:        []string{"node_modules"},
+			expectedFiles: []string{"a.json", "b.jsonc", "fixtures/generated.json", "fixtures/keep/e.json", "nested/c.json", "nested/deeper/d.json"},
+		},
+		{
+			name:          "ignored files",
+			path:          root,
+			ignore:        []string{"fixtures/*.json", "*.jsonc"},
+			expectedFiles: []string{"a.json", "fixtures/keep/e.json", "nested/c.json", "nested/deeper/d.json", "node_modules/pkg/package.json"},
+		},
+		{
+			name:          "combined patterns",
+			path:          root,
+			ignore:        []string{"node_modules", "fixtures", "nested/deeper"},
+			expectedFiles: []string{"a.json", "b.jsonc", "nested/c.json"},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			files, err := Expand(testCase.path, testCase.ignore)
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+
+			// Compare the paths relative to the scanned directory
+			for i, file := range files {
+				if rel, err := filepath.Rel(root, file); err == nil && !IsURL(file) {
+					files[i] = filepath.ToSlash(rel)
+				}
+			}
+			if !reflect.DeepEqual(testCase.expectedFiles, files) {
+				t.Errorf("Expected files %q, got %q", testCase.expectedFiles, files)
+			}
+		})
+	}
+}
+
+func TestExpandModifiedSince(t *testing.T) {
+	// Build a directory tree w/ files modified on different days
+	root := t.TempDir()
+	since := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	modTimes := map[string]time.Time{
+		"old.json":        since.Add(-24 * time.Hour),
+		"boundary.json":   since,
+		"new.json":        since.Add(time.Hour),
+		"nested/old.json": since.Add(-time.Second),
+		"nested/new.json": since.Add(24 * time.Hour),
+	}
+	for file, modTime := range modTimes {
+		path := filepath.Join(root, file)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatalf("Failed to create %s: %v", filepath.Dir(path), err)
+		}
+		if err := os.WriteFile(path, []byte(`{}`), 0o644); err != nil {
+			t.Fatalf("Failed to write %s: %v", path, err)
+		}
+		if err := os.Chtimes(path, modTime, modTime); err != nil {
+			t.Fatalf("Failed to set the modification time of %s: %v", path, err)
+		}
+	}
+
+	// Define test cases
+	testCases := []struct {
+		name          string
+		path          string
+		since         time.Time
+		expectedFiles []string
+	}{
+		{
+			name:          "no filter",
+			path:          root,
+			expectedFiles: []string{"boundary.json", "nested/new.json", "nested/old.json", "new.json", "old.json"},
+		},
+		{
+			name:          "modified since",
+			path:          root,
+			since:         since,
+			expectedFiles: []string{"boundary.json", "nested/new.json", "new.json"},
+		},
+		{
+			name:          "none modified since",
+			path:          root,
+			since:         since.Add(48 * time.Hour),
+			expectedFiles: nil,
+		},
+		// Files passed explicitly are always validated
+		{
+			name:          "old file",
+			path:          filepath.Join(root, "old.json"),
+			since:         since,
+			expectedFiles: []string{"old.json"},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			files, err := ExpandModifiedSince(testCase.path, nil, testCase.since, 0)
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+
+			// Compare the paths relative to the scanned directory
+			for i, file := range files {
+				if rel, err := filepath.Rel(root, file); err == nil {
+					files[i] = filepath.ToSlash(rel)
+				}
+			}
+			if !reflect.DeepEqual(testCase.expectedFiles, files) {
+				t.Errorf("Expected files %q, got %q", testCase.expectedFiles, files)
+			}
+		})
+	}
+}
+
+func TestExpandLimit(t *testing.T) {
+	// Build a directory tree w/ JSON files in nested directories
+	root := t.TempDir()
+	for _, file := range []string{"a.json", "b/c.json", "b/d.json", "e.json", "notes.txt"} {
+		path := filepath.Join(root, file)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatalf("Failed to create %s: %v", filepath.Dir(path), err)
+		}
+		if err := os.WriteFile(path, []byte(`{}`), 0o644); err != nil {
+			t.Fatalf("Failed to write %s: %v", path, err)
+		}
+	}
+
+	// Define test cases, the scan stopping at the limit-th file walked
+	testCases := []struct {
+		name          string
+		limit         int
+		expectedFiles []string
+	}{
+		{name: "no limit", limit: 0, expectedFiles: []string{"a.json", "b/c.json", "b/d.json", "e.json"}},
+		{name: "nested directory", limit: 2, expectedFiles: []string{"a.json", "b/c.json"}},
+		{name: "limit reached", limit: 4, expectedFiles: []string{"a.json", "b/c.json", "b/d.json", "e.json"}},
+		{name: "limit past the files", limit: 10, expectedFiles: []string{"a.json", "b/c.json", "b/d.json", "e.json"}},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			files, err := ExpandModifiedSince(root, nil, time.Time{}, testCase.limit)
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+
+			// Compare the paths relative to the scanned directory
+			for i, file := range files {
+				if rel, err := filepath.Rel(root, file); err == nil {
+					files[i] = filepath.ToSlash(rel)
+				}
+			}
+			if !reflect.DeepEqual(testCase.expectedFiles, files) {
+				t.Errorf("Expected files %q, got %q", testCase.expectedFiles, files)
+			}
+		})
+	}
+}
@@ -0,0 +1,44 @@
+// Package input is responsible for opening the JSON input to validate, either from a local file or a URL.
+package input
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+)
+
+// IsURL reports whether the path refers to a remote document (http:// or https://) rather than a local file
+func IsURL(path string) bool {
+	return strings.HasPrefix(path, "http://") || strings.HasPrefix(path, "https://")
+}
+
+// Open opens the JSON input located at path, which is either a local filepath or a URL.
+// For URLs, the request is bound to the context, so cancelling the context also cancels reading the response body.
+//
+// Returns:
+// io.ReadCloser which the caller is responsible for closing
+func Open(ctx context.Context, path string) (io.ReadCloser, error) {
+	if !IsURL(path) {
+		return os.Open(path)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, fmt.Errorf("fetching %s: unexpected status %s", path, resp.Status)
+	}
+
+	return resp.Body, nil
+}
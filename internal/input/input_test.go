@@ -0,0 +1,73 @@
+package input
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/pszponder/json-linter_go/internal/lexer"
+)
+
+func TestOpenURL(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, `{"hello": "world"}`)
+	}))
+	defer server.Close()
+
+	reader, err := Open(context.Background(), server.URL)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	defer reader.Close()
+
+	body, err := io.ReadAll(reader)
+	if err != nil {
+		t.Fatalf("Expected no error reading body, got %v", err)
+	}
+	if string(body) != `{"hello": "world"}` {
+		t.Errorf("Expected body %q, got %q", `{"hello": "world"}`, body)
+	}
+}
+
+func TestOpenURLBadStatus(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	defer server.Close()
+
+	if _, err := Open(context.Background(), server.URL); err == nil {
+		t.Error("Expected an error for a 404 response, got nil")
+	}
+}
+
+func TestOpenURLTimeout(t *testing.T) {
+	// The server sends the start of the document, then stalls until the client gives up
+	done := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, `{"slow": [1, 2, `)
+		w.(http.Flusher).Flush()
+
+		select {
+		case <-r.Context().Done():
+		case <-done:
+		}
+	}))
+	defer server.Close()
+	defer close(done)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	reader, err := Open(ctx, server.URL)
+	if err != nil {
+		t.Fatalf("Expected no error opening the URL, got %v", err)
+	}
+	defer reader.Close()
+
+	_, err = lexer.LexReaderContext(ctx, reader, lexer.Options{})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected %v, got %v", context.DeadlineExceeded, err)
+	}
+}
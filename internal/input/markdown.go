@@ -0,0 +1,82 @@
+package input
+
+import (
+	"bytes"
+	"strings"
+)
+
+// markdownExtensions lists the extensions of the files validated when scanning a directory for Markdown files
+var markdownExtensions = map[string]bool{".md": true, ".markdown": true}
+
+// SplitMarkdown returns the content of each ```json (or ~~~json) fenced code block of the Markdown document.
+// The code blocks of other languages are skipped, as well as the json ones containing only whitespace.
+//
+// A block is closed by a fence of the same character at least as long as the opening one (or by the end of the
+// document). The fences may be indented (ex. within a list item), the indentation of the content being kept.
+// Each Record starts at the line following the opening fence, so its positions map back to the Markdown document.
+func SplitMarkdown(data []byte) []Record {
+	var records []Record
+
+	var fence string // Opening fence of the current code block (ex. "```"), empty outside of code blocks
+	isJSON := false  // Whether the current code block is a json one
+	start := 0       // Byte offset of the content of the current code block
+	startLine := 0   // Line of the content of the current code block
+
+	line, offset := 1, 0
+	for offset < len(data) {
+		end := len(data)
+		if i := bytes.IndexByte(data[offset:], '\n'); i >= 0 {
+			end = offset + i + 1
+		}
+		text := strings.TrimSpace(string(data[offset:end]))
+
+		switch {
+		case fence == "":
+			// Look for an opening fence, followed by the language of the block (ex. "```json" or "``` JSON {title=x}")
+			if marker := fenceMarker(text); marker != "" {
+				fence = marker
+				info := strings.Fields(strings.TrimPrefix(text, marker))
+				isJSON = len(info) > 0 && strings.EqualFold(info[0], "json")
+				start, startLine = end, line+1
+			}
+		case strings.HasPrefix(text, fence) && strings.Trim(text, fence[:1]) == "":
+			// Closing fence
+			if isJSON {
+				records = appendBlock(records, data[start:offset], startLine, start)
+			}
+			fence = ""
+		}
+
+		line++
+		offset = end
+	}
+
+	// A code block left open runs until the end of the document
+	if fence != "" && isJSON {
+		records = appendBlock(records, data[start:], startLine, start)
+	}
+
+	return records
+}
+
+// fenceMarker returns the run of 3 or more backticks or tildes starting the line, or "" if it isn't a code fence
+func fenceMarker(text string) string {
+	if !strings.HasPrefix(text, "```") && !strings.HasPrefix(text, "~~~") {
+		return ""
+	}
+	marker := text[:len(text)-len(strings.TrimLeft(text, text[:1]))]
+
+	// The language of a backtick fence can't contain backticks (ex. ```inline``` code spans)
+	if marker[0] == '`' && strings.Contains(text[len(marker):], "`") {
+		return ""
+	}
+	return marker
+}
+
+// appendBlock appends the content of a code block starting at line & offset to the records, unless it's blank
+func appendBlock(records []Record, content []byte, line, offset int) []Record {
+	if len(bytes.TrimSpace(content)) == 0 {
+		return records
+	}
+	return append(records, Record{Data: content, Line: line, Column: 1, Offset: offset})
+}
@@ -0,0 +1,58 @@
+package input
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSplitMarkdown(t *testing.T) {
+	// Define test cases
+	testCases := []struct {
+		name            string
+		data            string
+		expectedRecords []Record
+	}{
+		{name: "empty", data: ""},
+		{name: "no code block", data: "# Title\n\nSome `json` text.\n"},
+		{
+			name: "json blocks",
+			data: "# Config\n\n```json\n{\"a\": 1}\n```\n\nText\n~~~JSON\n[1, 2]\n~~~\n",
+			expectedRecords: []Record{
+				{Data: []byte("{\"a\": 1}\n"), Line: 4, Column: 1, Offset: 18},
+				{Data: []byte("[1, 2]\n"), Line: 9, Column: 1, Offset: 45},
+			},
+		},
+		{
+			name: "other languages are skipped",
+			data: "```sh\njl a.json\n```\n```\n{\n```\n```json title=\"a.json\"\ntrue\n```\n",
+			expectedRecords: []Record{
+				{Data: []byte("true\n"), Line: 8, Column: 1, Offset: 53},
+			},
+		},
+		// A json block may contain shorter fences & the indentation of a list item is kept
+		{
+			name: "nested fences & indentation",
+			data: "- Example:\n  ````json\n  [\"```\",\n  ```\n  1]\n  ````\n",
+			expectedRecords: []Record{
+				{Data: []byte("  [\"```\",\n  ```\n  1]\n"), Line: 3, Column: 1, Offset: 22},
+			},
+		},
+		{name: "blank json block", data: "```json\n\n```\n"},
+		{
+			name: "unclosed block",
+			data: "```json\n{\"a\":\n",
+			expectedRecords: []Record{
+				{Data: []byte("{\"a\":\n"), Line: 2, Column: 1, Offset: 8},
+			},
+		},
+		{name: "inline code span", data: "```json``` is not a fence\n[1,]\n"},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			if records := SplitMarkdown([]byte(testCase.data)); !reflect.DeepEqual(records, testCase.expectedRecords) {
+				t.Errorf("Expected records %+v, got %+v", testCase.expectedRecords, records)
+			}
+		})
+	}
+}
@@ -0,0 +1,82 @@
+package input
+
+import (
+	"fmt"
+	"io"
+	"os"
+)
+
+// ProgressStep is the step, in percent of the input size, at which a ProgressReader reports the progress
+const ProgressStep = 10
+
+// ProgressReader is a reader which reports the percentage of the input read so far, at every ProgressStep percent
+type ProgressReader struct {
+	r        io.Reader
+	name     string    // Name of the input in the reports (ex. its filepath)
+	size     int64     // Total size of the input in bytes
+	w        io.Writer // Receives the reports
+	read     int64     // Number of bytes read so far
+	reported int       // Last percentage reported
+}
+
+// NewProgressReader returns a reader which reads from r & writes the progress to w
+// (ex. "Progress: big.json 40%"), based on the bytes read versus the size of the input.
+// If the size is unknown (negative or 0, ex. for stdin fed by a pipe), r is returned as is & no progress is reported.
+func NewProgressReader(r io.Reader, name string, size int64, w io.Writer) io.Reader {
+	if size <= 0 {
+		return r
+	}
+	return &ProgressReader{r: r, name: name, size: size, w: w}
+}
+
+// Read reads from the underlying reader, reporting each ProgressStep percent reached
+func (p *ProgressReader) Read(buf []byte) (int, error) {
+	n, err := p.r.Read(buf)
+	p.read += int64(n)
+
+	// The file may have grown since its size was retrieved
+	percent := min(int(p.read*100/p.size), 100)
+	if step := percent / ProgressStep * ProgressStep; step > p.reported {
+		fmt.Fprintf(p.w, "Progress: %s %d%%\n", p.name, step)
+		p.reported = step
+	}
+	return n, err
+}
+
+// CountingReader is a reader which counts the bytes read from the underlying reader
+type CountingReader struct {
+	r io.Reader
+	n int64 // Number of bytes read so far
+}
+
+// NewCountingReader returns a reader which reads from r, counting the bytes read
+func NewCountingReader(r io.Reader) *CountingReader {
+	return &CountingReader{r: r}
+}
+
+// Read reads from the underlying reader & counts the bytes read
+func (c *CountingReader) Read(buf []byte) (int, error) {
+	n, err := c.r.Read(buf)
+	c.n += int64(n)
+	return n, err
+}
+
+// Count returns the number of bytes read so far
+func (c *CountingReader) Count() int64 {
+	return c.n
+}
+
+// Size returns the size in bytes of the input read by r, or -1 if it's unknown.
+// Only regular files have a known size, unlike pipes or HTTP responses. So os.Stdin has a size when it's redirected
+// from a file (ex. jl -pipe < a.json), but not when it's fed by a pipe (ex. cat a.json | jl -pipe).
+func Size(r io.Reader) int64 {
+	file, ok := r.(*os.File)
+	if !ok {
+		return -1
+	}
+	info, err := file.Stat()
+	if err != nil || !info.Mode().IsRegular() {
+		return -1
+	}
+	return info.Size()
+}
@@ -0,0 +1,99 @@
+package input
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+func TestProgressReader(t *testing.T) {
+	allSteps := "Progress: big.json 10%\nProgress: big.json 20%\nProgress: big.json 30%\nProgress: big.json 40%\nProgress: big.json 50%\n" +
+		"Progress: big.json 60%\nProgress: big.json 70%\nProgress: big.json 80%\nProgress: big.json 90%\nProgress: big.json 100%\n"
+
+	// Define test cases
+	testCases := []struct {
+		name             string
+		input            string
+		size             int64
+		expectedProgress string
+	}{
+		{name: "known size", input: strings.Repeat(" ", 100), size: 100, expectedProgress: allSteps},
+		// The input is read 1 byte at a time, each read of a small input reaching a later step
+		{name: "steps skipped", input: "[1]\n", size: 4, expectedProgress: "Progress: big.json 20%\nProgress: big.json 50%\nProgress: big.json 70%\nProgress: big.json 100%\n"},
+		{name: "grown input", input: strings.Repeat(" ", 100), size: 50, expectedProgress: allSteps},
+		{name: "unknown size", input: "[1]", size: -1, expectedProgress: ""},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			var progress strings.Builder
+			reader := NewProgressReader(iotest.OneByteReader(strings.NewReader(testCase.input)), "big.json", testCase.size, &progress)
+			data, err := io.ReadAll(reader)
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+
+			if string(data) != testCase.input {
+				t.Errorf("Expected to read %q, got %q", testCase.input, data)
+			}
+			if progress.String() != testCase.expectedProgress {
+				t.Errorf("Expected progress %q, got %q", testCase.expectedProgress, progress.String())
+			}
+		})
+	}
+}
+
+func TestCountingReader(t *testing.T) {
+	reader := NewCountingReader(iotest.OneByteReader(strings.NewReader(`{"é": 1}`)))
+	if count := reader.Count(); count != 0 {
+		t.Errorf("Expected 0 bytes before reading, got %d", count)
+	}
+	if _, err := io.ReadAll(reader); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if count := reader.Count(); count != 9 {
+		t.Errorf("Expected 9 bytes, got %d", count)
+	}
+}
+
+func TestSize(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "a.json")
+	if err := os.WriteFile(path, []byte(`{"a": 1}`), 0o644); err != nil {
+		t.Fatalf("Failed to write %s: %v", path, err)
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("Failed to open %s: %v", path, err)
+	}
+	defer file.Close()
+
+	// A pipe has no known size, like stdin fed by a pipe (stdin is covered by the linter tests of -pipe -progress)
+	pipeReader, pipeWriter, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("Failed to create a pipe: %v", err)
+	}
+	defer pipeReader.Close()
+	defer pipeWriter.Close()
+
+	// Define test cases
+	testCases := []struct {
+		name         string
+		reader       io.Reader
+		expectedSize int64
+	}{
+		{name: "file", reader: file, expectedSize: 8},
+		{name: "pipe", reader: pipeReader, expectedSize: -1},
+		{name: "other reader", reader: strings.NewReader(`{"a": 1}`), expectedSize: -1},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			if size := Size(testCase.reader); size != testCase.expectedSize {
+				t.Errorf("Expected size %d, got %d", testCase.expectedSize, size)
+			}
+		})
+	}
+}
@@ -0,0 +1,42 @@
+package input
+
+import (
+	"bytes"
+	"unicode/utf8"
+)
+
+// RecordSeparator is the byte starting each record of a JSON text sequence (RFC 7464)
+const RecordSeparator = 0x1E
+
+// Record is a JSON text of a JSON text sequence, along w/ where it starts within the sequence
+type Record struct {
+	Data   []byte // Content of the record, w/o the separators (may be surrounded by whitespace)
+	Line   int    // 1-based line of the first byte of the record
+	Column int    // 1-based column (in runes) of the first byte of the record
+	Offset int    // Byte offset of the first byte of the record
+}
+
+// SplitSequence splits a JSON text sequence (RFC 7464) into its records at each record separator (0x1E).
+// Records containing only whitespace (ex. between consecutive separators or before the first one) are skipped.
+func SplitSequence(data []byte) []Record {
+	var records []Record
+
+	line, column, offset := 1, 1, 0
+	for _, chunk := range bytes.Split(data, []byte{RecordSeparator}) {
+		if len(bytes.TrimSpace(chunk)) > 0 {
+			records = append(records, Record{Data: chunk, Line: line, Column: column, Offset: offset})
+		}
+
+		// Advance past the chunk & the separator following it
+		if newlines := bytes.Count(chunk, []byte{'\n'}); newlines > 0 {
+			line += newlines
+			column = 1 + utf8.RuneCount(chunk[bytes.LastIndexByte(chunk, '\n')+1:])
+		} else {
+			column += utf8.RuneCount(chunk)
+		}
+		column++
+		offset += len(chunk) + 1
+	}
+
+	return records
+}
@@ -0,0 +1,49 @@
+package input
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSplitSequence(t *testing.T) {
+	// Define test cases
+	testCases := []struct {
+		name            string
+		data            string
+		expectedRecords []Record
+	}{
+		{name: "empty", data: ""},
+		{
+			name: "records ending w/ newlines",
+			data: "\x1e{\"a\": 1}\n\x1e[1, 2]\n",
+			expectedRecords: []Record{
+				{Data: []byte("{\"a\": 1}\n"), Line: 1, Column: 2, Offset: 1},
+				{Data: []byte("[1, 2]\n"), Line: 2, Column: 2, Offset: 11},
+			},
+		},
+		{
+			name: "whitespace-only records are skipped",
+			data: " \n\x1e\x1e \"é\" \x1e  \n",
+			expectedRecords: []Record{
+				{Data: []byte(" \"é\" "), Line: 2, Column: 3, Offset: 4},
+			},
+		},
+		// Content before the first separator is a record as well
+		{
+			name: "no leading separator",
+			data: "1\x1e2",
+			expectedRecords: []Record{
+				{Data: []byte("1"), Line: 1, Column: 1, Offset: 0},
+				{Data: []byte("2"), Line: 1, Column: 3, Offset: 2},
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			if records := SplitSequence([]byte(testCase.data)); !reflect.DeepEqual(records, testCase.expectedRecords) {
+				t.Errorf("Expected records %+v, got %+v", testCase.expectedRecords, records)
+			}
+		})
+	}
+}
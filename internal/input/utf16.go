@@ -0,0 +1,90 @@
+package input
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+// Encoding is the character encoding of the input
+type Encoding string
+
+const (
+	EncodingUTF8    Encoding = "utf8"    // The input is lexed as is
+	EncodingUTF16   Encoding = "utf16"   // UTF-16 w/ a byte order mark telling its endianness
+	EncodingUTF16LE Encoding = "utf16le" // Little-endian UTF-16, w/ an optional byte order mark
+	EncodingUTF16BE Encoding = "utf16be" // Big-endian UTF-16, w/ an optional byte order mark
+
+	// DefaultEncoding is the encoding used when none is specified
+	DefaultEncoding = EncodingUTF8
+)
+
+// ErrUTF16NoBOM is returned when decoding UTF-16 of unspecified endianness which doesn't start w/ a byte order mark
+var ErrUTF16NoBOM = errors.New("UTF-16 input w/o a byte order mark, the endianness is ambiguous (specify utf16le or utf16be)")
+
+// UTF-16 byte order marks
+var (
+	bomLE = []byte{0xFF, 0xFE}
+	bomBE = []byte{0xFE, 0xFF}
+)
+
+// ParseEncoding returns the Encoding named by name (ex. "utf16le").
+// An empty name selects DefaultEncoding.
+func ParseEncoding(name string) (Encoding, error) {
+	switch encoding := Encoding(name); encoding {
+	case "":
+		return DefaultEncoding, nil
+	case EncodingUTF8, EncodingUTF16, EncodingUTF16LE, EncodingUTF16BE:
+		return encoding, nil
+	default:
+		return "", fmt.Errorf("unknown encoding %q, expected %q, %q, %q or %q", name, EncodingUTF8, EncodingUTF16, EncodingUTF16LE, EncodingUTF16BE)
+	}
+}
+
+// IsUTF16 reports whether the encoding is one of the UTF-16 encodings
+func (e Encoding) IsUTF16() bool {
+	return e == EncodingUTF16 || e == EncodingUTF16LE || e == EncodingUTF16BE
+}
+
+// DecodeUTF16 converts UTF-16 data in the encoding to UTF-8, so positions are within the decoded runes.
+// The byte order mark is dropped. For EncodingUTF16, it selects the endianness & is required (see ErrUTF16NoBOM),
+// while for an explicit endianness it's optional but must match. Unpaired surrogates are decoded as U+FFFD.
+func DecodeUTF16(data []byte, encoding Encoding) ([]byte, error) {
+	var order binary.ByteOrder
+	switch {
+	case bytes.HasPrefix(data, bomLE):
+		order = binary.LittleEndian
+	case bytes.HasPrefix(data, bomBE):
+		order = binary.BigEndian
+	case encoding == EncodingUTF16:
+		return nil, ErrUTF16NoBOM
+	}
+
+	if order != nil {
+		if (encoding == EncodingUTF16LE && order != binary.LittleEndian) || (encoding == EncodingUTF16BE && order != binary.BigEndian) {
+			return nil, fmt.Errorf("byte order mark of the UTF-16 input doesn't match the %s encoding", encoding)
+		}
+		data = data[len(bomLE):]
+	} else if encoding == EncodingUTF16LE {
+		order = binary.LittleEndian
+	} else {
+		order = binary.BigEndian
+	}
+
+	if len(data)%2 != 0 {
+		return nil, fmt.Errorf("invalid UTF-16 input, odd number of bytes (%d)", len(data))
+	}
+
+	units := make([]uint16, len(data)/2)
+	for i := range units {
+		units[i] = order.Uint16(data[2*i:])
+	}
+	decoded := make([]byte, 0, len(data))
+	for _, r := range utf16.Decode(units) {
+		decoded = utf8.AppendRune(decoded, r)
+	}
+	return decoded, nil
+}
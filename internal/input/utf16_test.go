@@ -0,0 +1,106 @@
+package input
+
+import (
+	"errors"
+	"testing"
+	"unicode/utf16"
+)
+
+// encodeUTF16 is a helper which encodes the string as UTF-16 w/ the byte order mark
+func encodeUTF16(s string, bigEndian bool, bom bool) []byte {
+	var data []byte
+	units := utf16.Encode([]rune(s))
+	if bom {
+		units = append([]uint16{0xFEFF}, units...)
+	}
+	for _, unit := range units {
+		if bigEndian {
+			data = append(data, byte(unit>>8), byte(unit))
+		} else {
+			data = append(data, byte(unit), byte(unit>>8))
+		}
+	}
+	return data
+}
+
+func TestDecodeUTF16(t *testing.T) {
+	doc := "{\"é\": [\"日本\", \"😀\"]}\n"
+
+	// Define test cases
+	testCases := []struct {
+		name             string
+		data             []byte
+		encoding         Encoding
+		expected         string
+		expectedErrorMsg string // Empty if the data is valid
+	}{
+		{name: "LE w/ BOM", data: encodeUTF16(doc, false, true), encoding: EncodingUTF16, expected: doc},
+		{name: "BE w/ BOM", data: encodeUTF16(doc, true, true), encoding: EncodingUTF16, expected: doc},
+		{name: "explicit LE w/ BOM", data: encodeUTF16(doc, false, true), encoding: EncodingUTF16LE, expected: doc},
+		{name: "explicit BE w/ BOM", data: encodeUTF16(doc, true, true), encoding: EncodingUTF16BE, expected: doc},
+		{name: "explicit LE w/o BOM", data: encodeUTF16(doc, false, false), encoding: EncodingUTF16LE, expected: doc},
+		{name: "explicit BE w/o BOM", data: encodeUTF16(doc, true, false), encoding: EncodingUTF16BE, expected: doc},
+		{name: "unpaired surrogate", data: []byte{0xFF, 0xFE, 0x3D, 0xD8, 0x31, 0x00}, encoding: EncodingUTF16, expected: "�1"},
+		{name: "ambiguous w/o BOM", data: encodeUTF16(doc, false, false), encoding: EncodingUTF16, expectedErrorMsg: ErrUTF16NoBOM.Error()},
+		{
+			name:             "mismatched BOM",
+			data:             encodeUTF16(doc, true, true),
+			encoding:         EncodingUTF16LE,
+			expectedErrorMsg: "byte order mark of the UTF-16 input doesn't match the utf16le encoding",
+		},
+		{name: "odd length", data: []byte{0xFF, 0xFE, '1', 0, '2'}, encoding: EncodingUTF16, expectedErrorMsg: "invalid UTF-16 input, odd number of bytes (3)"},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			decoded, err := DecodeUTF16(testCase.data, testCase.encoding)
+			if testCase.expectedErrorMsg != "" {
+				if err == nil || err.Error() != testCase.expectedErrorMsg {
+					t.Errorf("Expected error %q, got %v", testCase.expectedErrorMsg, err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if string(decoded) != testCase.expected {
+				t.Errorf("Expected %q, got %q", testCase.expected, decoded)
+			}
+		})
+	}
+}
+
+func TestDecodeUTF16NoBOM(t *testing.T) {
+	if _, err := DecodeUTF16([]byte{'{', 0, '}', 0}, EncodingUTF16); !errors.Is(err, ErrUTF16NoBOM) {
+		t.Errorf("Expected %v, got %v", ErrUTF16NoBOM, err)
+	}
+}
+
+func TestParseEncoding(t *testing.T) {
+	// Define test cases
+	testCases := []struct {
+		name        string
+		expected    Encoding
+		expectedErr bool
+	}{
+		{name: "", expected: DefaultEncoding},
+		{name: "utf8", expected: EncodingUTF8},
+		{name: "utf16", expected: EncodingUTF16},
+		{name: "utf16le", expected: EncodingUTF16LE},
+		{name: "utf16be", expected: EncodingUTF16BE},
+		{name: "latin1", expectedErr: true},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			encoding, err := ParseEncoding(testCase.name)
+			if (err != nil) != testCase.expectedErr {
+				t.Fatalf("Expected error %v, got %v", testCase.expectedErr, err)
+			}
+			if encoding != testCase.expected {
+				t.Errorf("Expected encoding %q, got %q", testCase.expected, encoding)
+			}
+		})
+	}
+}
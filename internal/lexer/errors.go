@@ -0,0 +1,31 @@
+package lexer
+
+import "fmt"
+
+// LexError describes the problem of an ILLEGAL token along w/ its position,
+// giving lexical problems the same structure as the parser's ParseError
+type LexError struct {
+	Message string // Description of the problem w/o the position (ex. "Unexpected '01', invalid number")
+	Lexeme  string // Lexeme of the ILLEGAL token
+	Pos     TokenPosition
+	Err     error // Why the lexeme is ILLEGAL (ex. ErrInvalidNumber), nil if the lexer can't tell
+}
+
+// NewLexError returns the LexError describing the ILLEGAL token
+func NewLexError(tok Token) LexError {
+	message := fmt.Sprintf("Unexpected '%s'", tok.Lexeme)
+	if tok.Err != nil {
+		message = fmt.Sprintf("%s, %v", message, tok.Err)
+	}
+	return LexError{Message: message, Lexeme: tok.Lexeme, Pos: tok.TokPos, Err: tok.Err}
+}
+
+// Error formats the LexError in the same style as the parser's error messages
+func (e LexError) Error() string {
+	return fmt.Sprintf("%s at line %d, Column %d:%d", e.Message, e.Pos.Line, e.Pos.ColStart, e.Pos.ColEnd)
+}
+
+// Unwrap returns why the lexeme is ILLEGAL, if known
+func (e LexError) Unwrap() error {
+	return e.Err
+}
@@ -2,6 +2,8 @@ package lexer
 
 import (
 	"bufio"
+	"bytes"
+	"context"
 	"errors"
 	"fmt"
 	"io"
@@ -9,18 +11,134 @@ import (
 	"regexp"
 	"strings"
 	"unicode"
+	"unicode/utf8"
 )
 
-// Define Position Struct to track the current position of lexer's reader
+// ErrInvalidNumber is the Err of ILLEGAL tokens for malformed number literals
+var ErrInvalidNumber = errors.New("invalid number")
+
+// ErrStringTooLong is the Err of ILLEGAL tokens for strings exceeding Options.MaxStringLength
+var ErrStringTooLong = errors.New("string too long")
+
+// ErrNumberTooLong is the Err of ILLEGAL tokens for number literals exceeding Options.MaxNumberLength
+var ErrNumberTooLong = errors.New("number literal too long")
+
+// ErrTokenTooLong is the Err of ILLEGAL tokens exceeding Options.MaxTokenLength
+var ErrTokenTooLong = errors.New("token too long")
+
+// ErrUnterminatedString is the Err of ILLEGAL tokens for strings w/o a closing quote before the end of the input
+var ErrUnterminatedString = errors.New("unterminated string")
+
+// ErrTooManyLines is wrapped by the Err of the Lexer once the input exceeds Options.MaxLines
+var ErrTooManyLines = errors.New("too many lines")
+
+// ErrSingleQuoted is the Err of ILLEGAL tokens for single-quoted strings (lexed w/ Options.RelaxedKeys),
+// which the parser only accepts as object keys
+var ErrSingleQuoted = errors.New("single-quoted strings are only allowed as object keys")
+
+// DefaultMaxNumberLength is the maximum length of a number literal when Options.MaxNumberLength is 0
+const DefaultMaxNumberLength = 1 << 20
+
+// Define Position Struct to track the current position of lexer's reader.
+// Lines & columns are 1-based, Pos refers to the rune read last (Column is 0 before reading the first rune of a line),
+// so the position of a token is the Lexer's Pos right after reading the token's first rune.
 type LexerPosition struct {
 	Line   int // Current line Lexer's reader is scanning
-	Column int // Current column position of Lexer's reader
+	Column int // Column of the rune read last
+	Offset int // Byte offset of the rune read last
+}
+
+// Options configures optional behavior of the Lexer (the zero value is the default behavior)
+type Options struct {
+	// PreserveTrivia records the whitespace surrounding each token in its LeadingTrivia & TrailingTrivia
+	// so the input can be reproduced byte-for-byte from the tokens (ex. by a formatter).
+	PreserveTrivia bool
+
+	// AllowComments enables JSONC mode, where "// ..." & "/* ... */" comments are emitted as COMMENT tokens
+	// instead of being rejected as ILLEGAL.
+	AllowComments bool
+
+	// MaxStringLength rejects strings longer than this many characters (0 means no limit).
+	// The characters between the quotes are counted as written, so an escape sequence like \n counts as 2.
+	// Once the limit is exceeded, the rest of the string is skipped instead of being buffered.
+	MaxStringLength int
+
+	// EmitWhitespace emits runs of spaces, tabs & carriage returns as WS tokens and each newline as a NEWLINE token
+	// instead of skipping them, so the token stream covers the whole input (ex. for syntax highlighting).
+	// Whitespace tokens carry no trivia, as the whitespace is part of the token stream.
+	EmitWhitespace bool
+
+	// InvalidUTF8 determines how bytes which aren't valid UTF-8 are handled (empty means DefaultUTF8Policy)
+	InvalidUTF8 UTF8Policy
+
+	// MaxNumberLength rejects number literals longer than this many characters
+	// (0 means DefaultMaxNumberLength, a negative value means no limit).
+	// Once the limit is exceeded, the rest of the literal is skipped instead of being buffered.
+	MaxNumberLength int
+
+	// Relaxed accepts syntax beyond strict JSON, as found in JSON5 & hand-edited files:
+	//   - hexadecimal integers (ex. 0x1F or -0xabc)
+	Relaxed bool
+
+	// RelaxedKeys lexes the keys of configs which are otherwise strict JSON:
+	//   - identifiers may also contain digits, '_' & '$' (ex. max_size), and start w/ '_' or '$'
+	//   - single-quoted strings (ex. 'name') become ILLEGAL tokens w/ ErrSingleQuoted, including the quotes
+	// Both are still ILLEGAL tokens, the parser accepts them as keys only (see parser.ParseOptions.RelaxedKeys).
+	RelaxedKeys bool
+
+	// MaxTokenLength rejects any string, number literal, identifier or comment longer than this many characters
+	// (0 means no limit), unless the limit of its own kind (ex. MaxStringLength) is lower.
+	// Once the limit is exceeded, the rest of the token is skipped instead of being buffered.
+	MaxTokenLength int
+
+	// MaxLines stops lexing at the first rune past this many lines (0 means no limit), as if the input ended there.
+	// The Err of the Lexer then wraps ErrTooManyLines.
+	MaxLines int
+
+	// MaxLineLength records in the LongLine of the Lexer the first line longer than this many characters
+	// (0 means no limit), ex. to flag minified files. Carriage returns aren't counted, and lexing goes on.
+	MaxLineLength int
+
+	// KeepIllegal makes Tokenize & TokenizeWithOptions return the ILLEGAL tokens inline w/ the others,
+	// instead of stopping w/ a LexError at the first one
+	KeepIllegal bool
+
+	// AllowNaNInf lexes NaN, Infinity & -Infinity as NUM tokens, as accepted by some decoders (ex. Python's json.loads)
+	AllowNaNInf bool
 }
 
 // lexer struct is responsible for tokenizing input
 type Lexer struct {
 	Reader *bufio.Reader // Reader object of file to be tokenized
 	Pos    LexerPosition
+	Err    error // First error (other than io.EOF) encountered while reading the input
+	Opts   Options
+
+	// Number of consecutive newlines ending the input read so far (ex. 2 for "{}\n\n" & "{}\r\n\r\n", 0 for "{}\n ")
+	FinalNewlines int
+
+	// Positions of the invalid UTF-8 bytes decoded as U+FFFD (only recorded w/ the UTF8Replace policy)
+	Replacements []LexerPosition
+
+	// Number of runes read so far (runes read again after backing up are counted once)
+	RuneCount int
+
+	// Position of the first rune past Opts.MaxLineLength on its line, for the first line that long (zero if there is none)
+	LongLine LexerPosition
+
+	trivia strings.Builder // Trivia collected since the previous token (only used when Opts.PreserveTrivia is set)
+
+	nextOffset int // Byte offset of the next rune to be read
+	prevOffset int // Byte offset of the previously read rune (restored when backing up)
+	readOffset int // Byte offset just past the furthest rune read so far (runes read again after backing up are before it)
+
+	newlines   int // Number of newlines read so far (runes read again after backing up are counted once)
+	lineLength int // Number of characters of the current line read so far, carriage returns excluded
+
+	invalidRunes int  // Number of invalid UTF-8 bytes read as part of the current token
+	lastInvalid  bool // Whether the rune read last is an invalid UTF-8 byte (restored when backing up)
+
+	peeked *Token // Token read ahead by PeekToken, returned by the next call to GetNextToken (nil if there is none)
 }
 
 // Lex is responsible for opening the JSON file specified at the filePath.
@@ -33,22 +151,68 @@ func Lex(filePath string) []Token {
 	}
 	defer file.Close()
 
-	reader := bufio.NewReader(file)
+	return LexReader(file)
+}
+
+// LexReader tokenizes the JSON input provided by the reader.
+// Returns a slice of Tokens representing the input.
+func LexReader(reader io.Reader) []Token {
+	tokens, _ := LexReaderContext(context.Background(), reader, Options{})
+	return tokens
+}
 
-	lxr := createLexer(reader)
+// LexReaderContext tokenizes the JSON input provided by the reader, configured by opts.
+// Tokenizing stops w/ an error if the context is cancelled or the reader fails.
+// Returns a slice of Tokens representing the input.
+func LexReaderContext(ctx context.Context, reader io.Reader, opts Options) ([]Token, error) {
+	return NewLexer(reader, opts).ReadAll(ctx)
+}
+
+// Tokenize tokenizes the in-memory JSON input, see TokenizeWithOptions.
+func Tokenize(data []byte) ([]Token, error) {
+	return TokenizeWithOptions(data, Options{})
+}
 
+// TokenizeWithOptions tokenizes the in-memory JSON input, configured by opts.
+// The first ILLEGAL token is returned as a LexError along w/ the tokens preceding it, unless opts.KeepIllegal is set.
+// Returns a slice of Tokens representing the input, w/o the EOF token.
+func TokenizeWithOptions(data []byte, opts Options) ([]Token, error) {
+	tokens, err := NewLexer(bytes.NewReader(data), opts).ReadAll(context.Background())
+	if err != nil || opts.KeepIllegal {
+		return tokens, err
+	}
+
+	for i, tok := range tokens {
+		if tok.TokType == ILLEGAL {
+			return tokens[:i], NewLexError(tok)
+		}
+	}
+	return tokens, nil
+}
+
+// ReadAll tokenizes the rest of the Lexer's input, dropping the EOF token.
+// Tokenizing stops w/ an error if the context is cancelled or the reader fails.
+func (lxr *Lexer) ReadAll(ctx context.Context) ([]Token, error) {
 	var tokens []Token
 	for {
-		tok := lxr.getNextToken()
+		if err := ctx.Err(); err != nil {
+			return tokens, err
+		}
+
+		tok := lxr.GetNextToken()
 
 		// Break the loop if EOF is reached
 		if tok.TokType == EOF {
+			// Trivia at the end of the input belongs to the last token since the EOF token is dropped
+			if tok.LeadingTrivia != "" && len(tokens) > 0 {
+				tokens[len(tokens)-1].TrailingTrivia += tok.LeadingTrivia
+			}
 			break
 		}
 
 		tokens = append(tokens, tok)
 	}
-	return tokens
+	return tokens, lxr.Err
 }
 
 // createLexer creates & returns a new lexer instance for lexical analysis of the input from the given reader.
@@ -61,76 +225,270 @@ func Lex(filePath string) []Token {
 // Returns:
 //   - A pointer to the created lexer.
 func createLexer(reader io.Reader) *Lexer {
+	return NewLexer(reader, Options{})
+}
+
+// NewLexer creates & returns a new lexer instance for lexical analysis of the input from the given reader,
+// configured by opts.
+func NewLexer(reader io.Reader, opts Options) *Lexer {
 	lxrPtr := &Lexer{
 		Reader: bufio.NewReader(reader),
 		Pos:    LexerPosition{Line: 1, Column: 0},
+		Opts:   opts,
 	}
 
 	return lxrPtr
 }
 
-// getNextToken scans the Lexer's input to return the next token
-func (lxr *Lexer) getNextToken() Token {
+// Reset discards the Lexer's state and switches it to reading from reader, keeping its options.
+// The buffered reader is reused, so a single Lexer can tokenize many inputs (ex. the lines of NDJSON) w/o reallocating it.
+func (lxr *Lexer) Reset(reader io.Reader) {
+	lxr.Reader.Reset(reader)
+	lxr.Pos = LexerPosition{Line: 1, Column: 0}
+	lxr.Err = nil
+	lxr.FinalNewlines = 0
+	lxr.Replacements = nil
+	lxr.RuneCount = 0
+	lxr.newlines = 0
+	lxr.lineLength = 0
+	lxr.LongLine = LexerPosition{}
+	lxr.trivia.Reset()
+	lxr.nextOffset = 0
+	lxr.prevOffset = 0
+	lxr.readOffset = 0
+	lxr.invalidRunes = 0
+	lxr.lastInvalid = false
+	lxr.peeked = nil
+}
+
+// StartAt positions the Lexer as if its input started at the 1-based line & column and the byte offset of a larger input
+// (ex. a record of a JSON text sequence), so the positions of the tokens are within the larger input.
+// It must be called before reading any token.
+func (lxr *Lexer) StartAt(line, column, offset int) {
+	lxr.Pos = LexerPosition{Line: line, Column: column - 1, Offset: offset}
+	lxr.nextOffset = offset
+	lxr.prevOffset = offset
+	lxr.readOffset = offset
+	lxr.lineLength = column - 1
+}
+
+// End returns the position of the EOF token, just past the last rune read (at the start of the line following a final newline).
+// Once the whole input has been read (ex. by ReadAll, which drops the EOF token), it's the end of the input.
+func (lxr *Lexer) End() TokenPosition {
+	column := lxr.Pos.Column + 1
+	return TokenPosition{Line: lxr.Pos.Line, ColStart: column, ColEnd: column, Offset: lxr.nextOffset}
+}
+
+// LineCount returns the number of lines of the input read so far, the last one counting only if it isn't empty
+// (ex. 2 for "{\n}" & "{\n}\n", 0 for an empty input)
+func (lxr *Lexer) LineCount() int {
+	if lxr.RuneCount == 0 {
+		return 0
+	}
+	if lxr.FinalNewlines > 0 {
+		return lxr.newlines
+	}
+	return lxr.newlines + 1
+}
+
+// GetNextToken scans the Lexer's input to return the next token.
+// Lexical errors are returned as ILLEGAL tokens, see Scan to get them as errors instead.
+func (lxr *Lexer) GetNextToken() Token {
+	if lxr.peeked != nil {
+		token := *lxr.peeked
+		lxr.peeked = nil
+		return token
+	}
+	return lxr.readToken()
+}
+
+// PeekToken returns the next token w/o consuming it, so the next call to GetNextToken (or Scan) returns it again.
+// Peeking repeatedly returns the same token. The token is read ahead from the input,
+// so the Lexer's state (ex. Pos or FinalNewlines) already accounts for it.
+func (lxr *Lexer) PeekToken() Token {
+	if lxr.peeked == nil {
+		token := lxr.readToken()
+		lxr.peeked = &token
+	}
+	return *lxr.peeked
+}
+
+// readToken scans the Lexer's input to return the next token along w/ its trivia
+func (lxr *Lexer) readToken() Token {
+	lxr.invalidRunes = 0
+	token := lxr.scanToken()
+
+	// Tokens containing invalid UTF-8 bytes are rejected as a whole
+	if lxr.invalidRunes > 0 && lxr.Opts.InvalidUTF8 == UTF8Reject && token.TokType != EOF {
+		token.TokType = ILLEGAL
+		token.Err = ErrInvalidUTF8
+	}
+
+	if lxr.Opts.PreserveTrivia {
+		token.LeadingTrivia = lxr.trivia.String()
+		lxr.trivia.Reset()
+
+		if token.TokType != EOF && !lxr.Opts.EmitWhitespace {
+			token.TrailingTrivia = lxr.readTrailingTrivia()
+		}
+	}
+
+	return token
+}
+
+// Scan scans the Lexer's input to return the next token, like GetNextToken.
+// An ILLEGAL token is returned along w/ a LexError describing it & its position,
+// and a failure to read the input is returned along w/ the EOF token.
+func (lxr *Lexer) Scan() (Token, error) {
+	tok := lxr.GetNextToken()
+
+	switch {
+	case tok.TokType == ILLEGAL:
+		return tok, NewLexError(tok)
+	case tok.TokType == EOF && lxr.Err != nil:
+		return tok, lxr.Err
+	}
+
+	return tok, nil
+}
+
+// readTrailingTrivia reads the whitespace following a token up to (and including) the end of the line.
+// Any whitespace on the following lines becomes the leading trivia of the next token.
+func (lxr *Lexer) readTrailingTrivia() string {
+	var trivia []rune
+
+	for {
+		r, err := lxr.advanceReader()
+		if err != nil {
+			break
+		}
+
+		if r == ' ' || r == '\t' || r == '\r' {
+			trivia = append(trivia, r)
+			continue
+		}
+
+		if r == '\n' {
+			trivia = append(trivia, r)
+			lxr.resetPosition()
+		} else {
+			lxr.backupReader()
+		}
+		break
+	}
+
+	return string(trivia)
+}
+
+// scanToken scans the Lexer's input for the next token, collecting skipped whitespace as trivia if enabled
+func (lxr *Lexer) scanToken() Token {
 	var token Token
 
 	// Keep scanning until a token is found or EOF is reached
 	for {
 		r, err := lxr.advanceReader()
 		if err != nil {
-			if err == io.EOF {
-				token = createToken(EOF, lxr.Pos, '0')
-				return token
-			}
-			panic(err)
+			// Read failures are recorded in lxr.Err, so the end of the input is reached either way
+			return Token{TokType: EOF, Lexeme: "EOF", TokPos: lxr.End()}
 		}
 
-		// Skip whitespace / tabs before proceeding
-		if r == ' ' || r == '\t' {
+		// Emit whitespace as tokens if requested
+		if lxr.Opts.EmitWhitespace && isWhitespace(r) {
+			return lxr.readWhitespace(r)
+		}
+
+		// Skip whitespace / tabs / carriage returns (of CRLF line endings) before proceeding
+		if r == ' ' || r == '\t' || r == '\r' {
+			lxr.addTrivia(r)
 			continue
 		}
 
 		// Evaluate the rune (r) at the current scan position
 		switch r {
-		case '0':
-			token = createToken(EOF, lxr.Pos, r)
-			return token
 		case '\n':
 			// Reset lexer's position at each newline
+			lxr.addTrivia(r)
 			lxr.resetPosition()
 		case '{':
-			token = createToken(LBRACE, lxr.Pos, r)
+			token = createToken(LBRACE, lxr.Pos, lxr.Pos, r)
 			return token
 		case '}':
-			token = createToken(RBRACE, lxr.Pos, r)
+			token = createToken(RBRACE, lxr.Pos, lxr.Pos, r)
 			return token
 		case '[':
-			token = createToken(LBRACKET, lxr.Pos, r)
+			token = createToken(LBRACKET, lxr.Pos, lxr.Pos, r)
 			return token
 		case ']':
-			token = createToken(RBRACKET, lxr.Pos, r)
+			token = createToken(RBRACKET, lxr.Pos, lxr.Pos, r)
 			return token
 		case ',':
-			token = createToken(COMMA, lxr.Pos, r)
+			token = createToken(COMMA, lxr.Pos, lxr.Pos, r)
 			return token
 		case ':':
-			token = createToken(COLON, lxr.Pos, r)
+			token = createToken(COLON, lxr.Pos, lxr.Pos, r)
 			return token
 		case '"':
 			return handleStringToken(lxr, r)
+		case '/':
+			if lxr.Opts.AllowComments {
+				return handleCommentToken(lxr, r)
+			}
+			token = createToken(ILLEGAL, lxr.Pos, lxr.Pos, r)
+			return token
 		default:
-			if isNumberMaybe(r) {
+			if isNumberStart(r) || (r == '+' && lxr.nextIsDigit()) {
+				// A '+' can't start a number, but "+1" is lexed as a single malformed number to report why
 				return handleNumberToken(lxr, r)
-			} else if unicode.IsLetter(r) {
+			} else if unicode.IsLetter(r) || (lxr.Opts.RelaxedKeys && (r == '_' || r == '$')) {
 				return handleIdentifierToken(lxr, r)
+			} else if r == '\'' && lxr.Opts.RelaxedKeys {
+				return handleSingleQuotedToken(lxr, r)
 			} else {
 				// Handle Unknown Tokens
-				token = createToken(ILLEGAL, lxr.Pos, r)
+				token = createToken(ILLEGAL, lxr.Pos, lxr.Pos, r)
 				return token
 			}
 		}
 	}
 }
 
+// addTrivia records a skipped whitespace rune as trivia if trivia is being preserved
+func (lxr *Lexer) addTrivia(r rune) {
+	if lxr.Opts.PreserveTrivia {
+		lxr.trivia.WriteRune(r)
+	}
+}
+
+// isWhitespace reports whether the rune is whitespace between tokens
+func isWhitespace(r rune) bool {
+	return r == ' ' || r == '\t' || r == '\r' || r == '\n'
+}
+
+// readWhitespace returns a NEWLINE token for a newline, or a WS token for the run of other whitespace starting w/ r
+func (lxr *Lexer) readWhitespace(r rune) Token {
+	if r == '\n' {
+		token := createToken(NEWLINE, lxr.Pos, lxr.Pos, r)
+		lxr.resetPosition()
+		return token
+	}
+
+	startPos := lxr.Pos
+	ws := []rune{r}
+	for {
+		next, err := lxr.advanceReader()
+		if err != nil {
+			break
+		}
+		if next == '\n' || !isWhitespace(next) {
+			lxr.backupReader()
+			break
+		}
+		ws = append(ws, next)
+	}
+
+	return createToken(WS, startPos, lxr.Pos, ws...)
+}
+
 // resetPosition is a helper func to reset the pos of the lexer to the next line and 0th column position
 func (lxr *Lexer) resetPosition() {
 	lxr.Pos.Line++
@@ -139,12 +497,57 @@ func (lxr *Lexer) resetPosition() {
 
 // advanceReader moves the reader position forwarder by 1 rune & updates the Lexer's position
 func (lxr *Lexer) advanceReader() (rune, error) {
-	r, _, err := lxr.Reader.ReadRune()
+	r, size, err := lxr.Reader.ReadRune()
 	if err != nil {
+		// Remember the first read failure so it can be reported once lexing stops
+		if err != io.EOF && lxr.Err == nil {
+			lxr.Err = err
+		}
 		return 0, err // Return error
 	}
 
+	// Stop at the first rune past the maximum number of lines, which is on the line following the newlines read so far
+	// (runes read again after backing up are before it)
+	if lxr.Opts.MaxLines > 0 && lxr.nextOffset >= lxr.readOffset && lxr.newlines >= lxr.Opts.MaxLines {
+		if lxr.Err == nil {
+			lxr.Err = fmt.Errorf("%w: line %d exceeds the maximum of %d lines", ErrTooManyLines, lxr.newlines+1, lxr.Opts.MaxLines)
+		}
+		return 0, lxr.Err
+	}
+
 	lxr.Pos.Column++ // Advance position of lexer
+	lxr.prevOffset = lxr.Pos.Offset
+	lxr.Pos.Offset = lxr.nextOffset
+	lxr.nextOffset += size
+
+	// Track the invalid UTF-8 bytes (decoded as U+FFFD w/ a size of 1) of the current token
+	lxr.lastInvalid = r == utf8.RuneError && size == 1
+	if lxr.lastInvalid {
+		lxr.invalidRunes++
+	}
+
+	// Count the newlines ending the input & record the replacements, once per rune
+	if lxr.nextOffset > lxr.readOffset {
+		lxr.readOffset = lxr.nextOffset
+		lxr.RuneCount++
+		if lxr.lastInvalid && lxr.Opts.InvalidUTF8 == UTF8Replace {
+			lxr.Replacements = append(lxr.Replacements, lxr.Pos)
+		}
+		if r == '\n' {
+			lxr.FinalNewlines++
+			lxr.newlines++
+			lxr.lineLength = 0
+		} else {
+			// The carriage returns of CRLF line endings neither end the run of final newlines nor count as characters
+			if r != '\r' {
+				lxr.FinalNewlines = 0
+				lxr.lineLength++
+			}
+			if max := lxr.Opts.MaxLineLength; max > 0 && lxr.lineLength > max && lxr.LongLine.Line == 0 {
+				lxr.LongLine = lxr.Pos
+			}
+		}
+	}
 
 	return r, nil // Return rune and no error
 }
@@ -159,6 +562,12 @@ func (lxr *Lexer) backupReader() {
 		}
 
 		lxr.Pos.Column-- // Backup column position
+		lxr.nextOffset = lxr.Pos.Offset
+		lxr.Pos.Offset = lxr.prevOffset
+		if lxr.lastInvalid {
+			lxr.invalidRunes--
+			lxr.lastInvalid = false
+		}
 	}
 }
 
@@ -201,53 +610,70 @@ func (lxr *Lexer) peekForward(steps ...int) (rune, error) {
 }
 
 // createToken creates & returns a new token based on the specified TokenType,
-// lexer position, and a variable number of runes representing the lexeme.
+// lexer positions, and a variable number of runes representing the lexeme.
 //
 // Parameters:
 //   - tokenType: TokenType - The type of the token, such as Identifier, Number, etc.
-//   - pos: lexerPosition - The position information (line and column) where the lexeme starts.
+//   - start: lexerPosition - The position information (line, column & offset) of the token's first rune.
+//   - end: lexerPosition - The position information of the token's last rune, which may not be part of
+//     the lexeme (ex. the closing quote of a string, or the rest of a literal too long to be kept).
 //   - lexemeChars ...rune: A variadic parameter allowing the passing of one or more runes
 //     representing the characters of the lexeme.
 //
 // Returns:
 //   - Token: A newly created token containing information about the token type, lexeme,
 //     and position in the source code.
-func createToken(tokType TokenType, pos LexerPosition, lexemeChars ...rune) Token {
-	// Convert lexemeChar to a string
-	lexemeStr := string(lexemeChars)
-
-	// Handle special characters & update colEnd accordingly
-	visualWidth := len([]rune(lexemeStr)) // Consideration for characters with escape sequences
-	colEnd := pos.Column + visualWidth - 1
-
+func createToken(tokType TokenType, start, end LexerPosition, lexemeChars ...rune) Token {
 	// Generate a new struct to store data for token position
 	tokenPos := TokenPosition{
-		Line:     pos.Line,
-		ColStart: pos.Column,
-		ColEnd:   colEnd,
+		Line:     start.Line,
+		ColStart: start.Column,
+		ColEnd:   end.Column,
+		Offset:   start.Offset,
 	}
 
 	// Generate a new token struct
 	token := Token{
 		TokType: tokType,
-		Lexeme:  lexemeStr,
+		Lexeme:  string(lexemeChars),
 		TokPos:  tokenPos,
 	}
 
 	// Update token for EOF condition
 	if tokType == EOF {
 		token.Lexeme = "EOF"
-		token.TokPos.ColEnd = token.TokPos.ColStart
 	}
 
 	return token
 }
 
-// isNumberMaybe checks if the rune at the current position could be a number.
+// nextIsDigit reports whether the rune following the current position is a digit, w/o consuming it
+func (lxr *Lexer) nextIsDigit() bool {
+	r, err := lxr.advanceReader()
+	if err != nil {
+		return false
+	}
+	lxr.backupReader()
+	return r >= '0' && r <= '9'
+}
+
+// isNumberStart checks if the rune at the current position could start a number, only a digit or a '-' can.
+// Other runes of a number (ex. 'e') start identifiers instead, so "eggs" isn't lexed as a malformed number.
+func isNumberStart(r rune) bool {
+	return (r >= '0' && r <= '9') || r == '-'
+}
+
+// isNumberMaybe checks if the rune at the current position could be part of a number.
 func isNumberMaybe(r rune) bool {
 	return (r >= '0' && r <= '9') || r == '-' || r == '.' || r == 'e' || r == 'E'
 }
 
+// isExponentSign reports whether r is a '+' directly following the exponent marker of the number read so far (ex. 1E+5).
+// Unlike '-', a '+' can't start a number, so it isn't an isNumberMaybe rune. Any other '+' is a misplaced sign.
+func isExponentSign(r rune, num []rune) bool {
+	return r == '+' && len(num) > 0 && (num[len(num)-1] == 'e' || num[len(num)-1] == 'E')
+}
+
 // isValidJSONNumber checks if the given runes form a valid JSON number.
 func isValidJSONNumber(runes []rune) bool {
 	input := string(runes)
@@ -258,34 +684,72 @@ func isValidJSONNumber(runes []rune) bool {
 	return regexp.MustCompile(jsonNumberPattern).MatchString(input)
 }
 
+// hasHexPrefix reports whether the runes start like a hexadecimal integer, w/ "0x" or "0X" after an optional '-'
+func hasHexPrefix(runes []rune) bool {
+	if len(runes) > 0 && runes[0] == '-' {
+		runes = runes[1:]
+	}
+	return len(runes) >= 2 && runes[0] == '0' && (runes[1] == 'x' || runes[1] == 'X')
+}
+
+// isHexNumber checks if the given runes form a hexadecimal integer (ex. 0x1F or -0xabc), as accepted in relaxed mode
+func isHexNumber(runes []rune) bool {
+	return regexp.MustCompile(`^-?0[xX][0-9a-fA-F]+$`).MatchString(string(runes))
+}
+
 // handleNumberToken returns NUM or ILLEGAL token
 func handleNumberToken(lxr *Lexer, r rune) Token {
 
 	var token Token
-	lxr.backupReader()
-	numRune, startPos, err := lxr.readNumber()
+	startPos := lxr.Pos // The first rune has already been consumed
+	var numRune []rune
+	var err error
+	if r == '+' {
+		// The reader was backed up past the digit following the '+' (see nextIsDigit), so the '+' can't be read again
+		numRune, err = lxr.readNumber(r)
+	} else {
+		lxr.backupReader()
+		numRune, err = lxr.readNumber()
+	}
 	if err != nil {
-		if strings.Contains(err.Error(), "invalid JSON number") {
-			token = createToken(ILLEGAL, startPos, numRune...)
+		if errors.Is(err, ErrNumberTooLong) || errors.Is(err, ErrTokenTooLong) {
+			// The position spans the whole literal, but the lexeme is only its first rune as the rest wasn't kept
+			token = createToken(ILLEGAL, startPos, lxr.Pos, r)
+			token.Err = err
+			return token
+		}
+		if errors.Is(err, ErrInvalidNumber) {
+			// The whole malformed literal becomes a single token
+			token = createToken(ILLEGAL, startPos, lxr.Pos, numRune...)
+			token.Err = err
 			return token
 		}
 		// Invalid number, return Unknown Token
-		token = createToken(ILLEGAL, startPos, r)
+		token = createToken(ILLEGAL, startPos, startPos, r)
 	} else {
-		token = createToken(NUM, startPos, numRune...)
+		token = createToken(NUM, startPos, lxr.Pos, numRune...)
 	}
 	return token
 }
 
-// readNumber reads attempts to read in a number and return the read in value
-func (lxr *Lexer) readNumber() ([]rune, LexerPosition, error) {
-	var num []rune
+// lengthLimit returns the maximum length of a token whose kind is limited to max characters (0 or less means no limit),
+// along w/ the error wrapped once it's exceeded: errTooLong, or ErrTokenTooLong if Opts.MaxTokenLength is lower
+func (lxr *Lexer) lengthLimit(max int, errTooLong error) (int, error) {
+	if tokenMax := lxr.Opts.MaxTokenLength; tokenMax > 0 && (max <= 0 || tokenMax < max) {
+		return tokenMax, ErrTokenTooLong
+	}
+	return max, errTooLong
+}
 
-	// Store starting position
-	startPos := LexerPosition{
-		Line:   lxr.Pos.Line,
-		Column: lxr.Pos.Column + 1,
+// readNumber reads attempts to read in a number and return the read in value, starting w/ the runes already read (if any)
+func (lxr *Lexer) readNumber(read ...rune) ([]rune, error) {
+	num := read
+	length := len(read) // Number of characters read so far (the literal is dropped once it exceeds the limit)
+	maxLength := lxr.Opts.MaxNumberLength
+	if maxLength == 0 {
+		maxLength = DefaultMaxNumberLength
 	}
+	maxLength, errTooLong := lxr.lengthLimit(maxLength, ErrNumberTooLong)
 
 	// Keep reading until hit a non-numeric condition
 	for {
@@ -294,114 +758,326 @@ func (lxr *Lexer) readNumber() ([]rune, LexerPosition, error) {
 			if err == io.EOF {
 				break
 			}
-			return nil, startPos, err
+			return nil, err
 		}
 
-		if unicode.IsSpace(r) || !isNumberMaybe(r) {
+		// Letters & digits directly following the number are part of the (malformed) literal, ex. 123abc
+		if unicode.IsSpace(r) || !(isNumberMaybe(r) || r == '+' || unicode.IsLetter(r) || unicode.IsDigit(r)) {
 			lxr.backupReader()
 			break
 		}
 
+		length++
+		if maxLength > 0 && length > maxLength {
+			num = nil
+			continue
+		}
 		num = append(num, r)
 	}
 
+	if maxLength > 0 && length > maxLength {
+		return nil, fmt.Errorf("%w: exceeds the maximum length of %d characters", errTooLong, maxLength)
+	}
+
+	// Hexadecimal integers are only valid in relaxed mode
+	if lxr.Opts.Relaxed && hasHexPrefix(num) {
+		if !isHexNumber(num) {
+			return num, fmt.Errorf("%w: invalid hexadecimal number", ErrInvalidNumber)
+		}
+		return num, nil
+	}
+
+	// Infinity & NaN w/o a sign are lexed as identifiers
+	if lxr.Opts.AllowNaNInf && string(num) == "-Infinity" {
+		return num, nil
+	}
+
+	for _, r := range num {
+		if !isNumberMaybe(r) && r != '+' {
+			return num, fmt.Errorf("%w: unexpected character '%c'", ErrInvalidNumber, r)
+		}
+	}
 	if !isValidJSONNumber(num) {
-		return num, startPos, errors.New("invalid JSON number")
+		return num, classifyInvalidNumber(num)
 	}
 
-	return num, startPos, nil
+	return num, nil
+}
+
+// classifyInvalidNumber returns the error describing why the runes (which are all isNumberMaybe or '+') aren't a valid JSON number.
+// Common malformations get a distinct message, the others fall back to ErrInvalidNumber.
+func classifyInvalidNumber(num []rune) error {
+	dots, exponents := 0, 0
+	for i, r := range num {
+		switch r {
+		case '.':
+			dots++
+		case 'e', 'E':
+			exponents++
+		case '-':
+			// A sign may only start the number or directly follow the exponent marker
+			if i > 0 && num[i-1] != 'e' && num[i-1] != 'E' {
+				return fmt.Errorf("%w: misplaced sign", ErrInvalidNumber)
+			}
+		case '+':
+			// Only the exponent may be explicitly positive (ex. 1E+5)
+			if i == 0 {
+				return fmt.Errorf("%w: leading '+' sign", ErrInvalidNumber)
+			}
+			if !isExponentSign(r, num[:i]) {
+				return fmt.Errorf("%w: misplaced '+' sign", ErrInvalidNumber)
+			}
+		}
+	}
+
+	switch {
+	case dots > 1:
+		return fmt.Errorf("%w: multiple decimal points", ErrInvalidNumber)
+	case exponents > 1:
+		return fmt.Errorf("%w: multiple exponents", ErrInvalidNumber)
+	}
+	return ErrInvalidNumber
 }
 
 // handleStringToken returns STR or ILLEGAL token
 func handleStringToken(lxr *Lexer, r rune) Token {
 	var token Token
-	strRune, startPos, err := lxr.readString()
-	if err != nil || len(strRune) == 0 {
+	startPos := lxr.Pos // The opening quote has already been consumed
+	strRune, err := lxr.readString('"')
+	if errors.Is(err, ErrStringTooLong) || errors.Is(err, ErrTokenTooLong) {
+		// The position spans the whole string, but the lexeme is only the opening quote as the content wasn't kept
+		token = createToken(ILLEGAL, startPos, lxr.Pos, r)
+		token.Err = err
+	} else if err != nil {
 		// Invalid string, return Unknown Token
-		token = createToken(ILLEGAL, startPos, r)
+		token = createToken(ILLEGAL, startPos, startPos, r)
+		if errors.Is(err, ErrUnterminatedString) {
+			token.Err = err
+		}
 	} else {
-		token = createToken(STR, startPos, strRune...)
+		// The lexeme excludes the quotes, but the position spans them (ColEnd points at the closing quote)
+		token = createToken(STR, startPos, lxr.Pos, strRune...)
 	}
 	return token
 }
 
-// readString reads the string from the current position of the Lexer's reader
-func (lxr *Lexer) readString() ([]rune, LexerPosition, error) {
+// readString reads the string from the current position of the Lexer's reader (just past the opening quote)
+// up to the closing quote, which is either '"' or '\” (for single-quoted keys)
+func (lxr *Lexer) readString(quote rune) ([]rune, error) {
 	var str []rune
-
-	// Store starting position
-	startPos := LexerPosition{
-		Line:   lxr.Pos.Line,
-		Column: lxr.Pos.Column + 1,
-	}
+	escaped := false // Tracks if the previous rune was an unescaped backslash
+	length := 0      // Number of characters read so far (the content is dropped once it exceeds the limit)
+	maxLength, errTooLong := lxr.lengthLimit(lxr.Opts.MaxStringLength, ErrStringTooLong)
 
 	for {
 		r, err := lxr.advanceReader()
 		if err != nil {
 			if err == io.EOF {
-				break
+				return str, ErrUnterminatedString
 			}
-			return nil, startPos, err
+			return nil, err
 		}
 
-		// Break if we hit the next " and it is not escaped
-		if r == '"' && str[len(str)-1] != '\\' {
+		// Break if we hit the closing quote and it is not escaped
+		if r == quote && !escaped {
 			break
 		}
 
+		escaped = r == '\\' && !escaped
+		length++
+		if maxLength > 0 && length > maxLength {
+			str = nil
+			continue
+		}
 		str = append(str, r)
 	}
 
-	return str, startPos, nil
+	if maxLength > 0 && length > maxLength {
+		return nil, fmt.Errorf("%w: exceeds the maximum length of %d characters", errTooLong, maxLength)
+	}
+
+	return str, nil
 }
 
-// handleIdentifierToken returns TRUE, FALSE, NULL or ILLEGAL token
+// handleSingleQuotedToken returns an ILLEGAL token w/ ErrSingleQuoted for a single-quoted string (quotes included),
+// or w/ the reason (if known) the string is invalid, ex. ErrUnterminatedString
+func handleSingleQuotedToken(lxr *Lexer, r rune) Token {
+	startPos := lxr.Pos // The opening quote has already been consumed
+	strRune, err := lxr.readString(r)
+	if errors.Is(err, ErrStringTooLong) || errors.Is(err, ErrTokenTooLong) {
+		// The position spans the whole string, like for double-quoted strings
+		token := createToken(ILLEGAL, startPos, lxr.Pos, r)
+		token.Err = err
+		return token
+	} else if err != nil {
+		token := createToken(ILLEGAL, startPos, startPos, r)
+		if errors.Is(err, ErrUnterminatedString) {
+			token.Err = err
+		}
+		return token
+	}
+
+	token := createToken(ILLEGAL, startPos, lxr.Pos, append(append([]rune{r}, strRune...), r)...)
+	token.Err = ErrSingleQuoted
+	return token
+}
+
+// handleIdentifierToken returns TRUE, FALSE, NULL or ILLEGAL token (or NUM for NaN & Infinity w/ Opts.AllowNaNInf)
 func handleIdentifierToken(lxr *Lexer, r rune) Token {
 	var token Token
+	startPos := lxr.Pos // The first rune has already been consumed
 	lxr.backupReader()
-	identRune, startPos, err := lxr.readIdentifier()
-	if err != nil {
+	identRune, err := lxr.readIdentifier()
+	if errors.Is(err, ErrTokenTooLong) {
+		// The position spans the whole identifier, but the lexeme is only its first rune as the rest wasn't kept
+		token = createToken(ILLEGAL, startPos, lxr.Pos, r)
+		token.Err = err
+	} else if err != nil {
 		// Invalid string, return Unknown Token
-		token = createToken(ILLEGAL, startPos, r)
+		token = createToken(ILLEGAL, startPos, startPos, r)
 	} else if string(identRune) == "true" {
-		token = createToken(TRUE, startPos, identRune...)
+		token = createToken(TRUE, startPos, lxr.Pos, identRune...)
 	} else if string(identRune) == "false" {
-		token = createToken(FALSE, startPos, identRune...)
+		token = createToken(FALSE, startPos, lxr.Pos, identRune...)
 
 	} else if string(identRune) == "null" {
-		token = createToken(NULL, startPos, identRune...)
+		token = createToken(NULL, startPos, lxr.Pos, identRune...)
+	} else if lxr.Opts.AllowNaNInf && (string(identRune) == "NaN" || string(identRune) == "Infinity") {
+		token = createToken(NUM, startPos, lxr.Pos, identRune...)
 	} else {
-		token = createToken(ILLEGAL, startPos, identRune...)
+		token = createToken(ILLEGAL, startPos, lxr.Pos, identRune...)
+		if keyword := nearKeyword(string(identRune)); keyword != "" {
+			token.Err = fmt.Errorf("unknown literal '%s'; did you mean '%s'?", string(identRune), keyword)
+		}
 	}
 	return token
 }
 
-// readIdentifier attempts to read an identifier
-func (lxr *Lexer) readIdentifier() ([]rune, LexerPosition, error) {
-	var ident []rune
+// nearKeyword returns the keyword (true, false or null) the identifier is a typo of, or "" if it isn't one.
+// A typo is a single inserted, deleted, substituted or swapped (adjacent) rune.
+func nearKeyword(ident string) string {
+	for _, keyword := range []string{"true", "false", "null"} {
+		if isSingleEdit([]rune(ident), []rune(keyword)) {
+			return keyword
+		}
+	}
+	return ""
+}
 
-	// Store starting position
-	startPos := LexerPosition{
-		Line:   lxr.Pos.Line,
-		Column: lxr.Pos.Column + 1,
+// isSingleEdit reports whether a is exactly one insertion, deletion, substitution or adjacent transposition away from b
+func isSingleEdit(a, b []rune) bool {
+	// Skip the common prefix & suffix, the remainder is what has been edited
+	for len(a) > 0 && len(b) > 0 && a[0] == b[0] {
+		a, b = a[1:], b[1:]
+	}
+	for len(a) > 0 && len(b) > 0 && a[len(a)-1] == b[len(b)-1] {
+		a, b = a[:len(a)-1], b[:len(b)-1]
 	}
 
+	switch {
+	case len(a)+len(b) == 1: // Insertion or deletion
+		return true
+	case len(a) == 1 && len(b) == 1: // Substitution
+		return true
+	case len(a) == 2 && len(b) == 2: // Transposition
+		return a[0] == b[1] && a[1] == b[0]
+	}
+	return false
+}
+
+// readIdentifier attempts to read an identifier
+func (lxr *Lexer) readIdentifier() ([]rune, error) {
+	var ident []rune
+	length := 0 // Number of characters read so far (the identifier is dropped once it exceeds the limit)
+	maxLength, errTooLong := lxr.lengthLimit(0, nil)
+
 	for {
 		r, err := lxr.advanceReader()
 		if err != nil {
 			if err == io.EOF {
 				break
 			}
-			return nil, startPos, err
+			return nil, err
 		}
 
-		if unicode.IsSpace(r) || !unicode.IsLetter(r) {
+		// Keys may also contain digits, '_' & '$' in relaxed keys mode (ex. max_size)
+		if !unicode.IsLetter(r) && !(lxr.Opts.RelaxedKeys && (unicode.IsDigit(r) || r == '_' || r == '$')) {
 			lxr.backupReader()
 			break
 		}
 
+		length++
+		if maxLength > 0 && length > maxLength {
+			ident = nil
+			continue
+		}
 		ident = append(ident, r)
 	}
 
-	return ident, startPos, nil
+	if maxLength > 0 && length > maxLength {
+		return nil, fmt.Errorf("%w: exceeds the maximum length of %d characters", errTooLong, maxLength)
+	}
+	return ident, nil
+}
+
+// handleCommentToken returns COMMENT or ILLEGAL token.
+// The lexeme of a COMMENT token is the raw comment, including the "//" or "/*" & "*/" delimiters.
+func handleCommentToken(lxr *Lexer, r rune) Token {
+	startPos := lxr.Pos
+	comment := []rune{r}
+
+	next, err := lxr.advanceReader()
+	if err != nil || (next != '/' && next != '*') {
+		// A lone '/' is not a comment
+		if err == nil {
+			lxr.backupReader()
+		}
+		return createToken(ILLEGAL, startPos, startPos, r)
+	}
+	comment = append(comment, next)
+
+	length := 2     // Number of characters read so far (the comment is dropped once it exceeds the limit)
+	prev := rune(0) // Rune read before r, past the delimiter
+	maxLength, errTooLong := lxr.lengthLimit(0, nil)
+	for {
+		r, err := lxr.advanceReader()
+		if err != nil {
+			if next == '*' && (maxLength <= 0 || length <= maxLength) {
+				// Block comments must be terminated
+				return createToken(ILLEGAL, startPos, lxr.Pos, comment...)
+			}
+			break
+		}
+
+		// Line comments run up to the end of the line (the newline is left for the whitespace handling)
+		if next == '/' && r == '\n' {
+			lxr.backupReader()
+			break
+		}
+
+		length++
+		if maxLength <= 0 || length <= maxLength {
+			comment = append(comment, r)
+		}
+
+		if r == '\n' {
+			lxr.resetPosition()
+		}
+
+		// Block comments end w/ "*/"
+		if next == '*' && r == '/' && prev == '*' && length > 3 {
+			break
+		}
+		prev = r
+	}
+
+	// The position spans the whole comment, but the lexeme is only its delimiter as the rest wasn't kept
+	if maxLength > 0 && length > maxLength {
+		token := createToken(ILLEGAL, startPos, lxr.Pos, comment[:2]...)
+		token.Err = fmt.Errorf("%w: exceeds the maximum length of %d characters", errTooLong, maxLength)
+		return token
+	}
+
+	// Block comments can span lines, so ColEnd is the column of the comment's last rune (on the comment's last line)
+	return createToken(COMMENT, startPos, lxr.Pos, comment...)
 }
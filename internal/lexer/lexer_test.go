@@ -1,6 +1,10 @@
 package lexer
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
 	"strings"
 	"testing"
 )
@@ -16,8 +20,8 @@ func TestGetNextToken(t *testing.T) {
 		{
 			input: `{}`,
 			expectedTokens: []Token{
-				{LBRACE, "{", TokenPosition{1, 1, 1}},
-				{RBRACE, "}", TokenPosition{1, 2, 2}},
+				{TokType: LBRACE, Lexeme: "{", TokPos: TokenPosition{1, 1, 1, 0}},
+				{TokType: RBRACE, Lexeme: "}", TokPos: TokenPosition{1, 2, 2, 1}},
 			},
 		},
 		// Testing empty string
@@ -29,65 +33,112 @@ func TestGetNextToken(t *testing.T) {
 		{
 			input: `[]`,
 			expectedTokens: []Token{
-				{LBRACKET, "[", TokenPosition{1, 1, 1}},
-				{RBRACKET, "]", TokenPosition{1, 2, 2}},
+				{TokType: LBRACKET, Lexeme: "[", TokPos: TokenPosition{1, 1, 1, 0}},
+				{TokType: RBRACKET, Lexeme: "]", TokPos: TokenPosition{1, 2, 2, 1}},
 			},
 		},
 		// Testing brackets and string
 		{
 			input: `["hello"]`,
 			expectedTokens: []Token{
-				{LBRACKET, "[", TokenPosition{1, 1, 1}},
-				{STR, "hello", TokenPosition{1, 3, 7}},
-				{RBRACKET, "]", TokenPosition{1, 9, 9}},
+				{TokType: LBRACKET, Lexeme: "[", TokPos: TokenPosition{1, 1, 1, 0}},
+				{TokType: STR, Lexeme: "hello", TokPos: TokenPosition{1, 2, 8, 1}},
+				{TokType: RBRACKET, Lexeme: "]", TokPos: TokenPosition{1, 9, 9, 8}},
 			},
 		},
 		// Testing strings
 		{
 			input: `"a", "bc", "def","ghij" whaat "`,
 			expectedTokens: []Token{
-				{STR, "a", TokenPosition{1, 2, 2}},
-				{COMMA, ",", TokenPosition{1, 4, 4}},
-				{STR, "bc", TokenPosition{1, 7, 8}},
-				{COMMA, ",", TokenPosition{1, 10, 10}},
-				{STR, "def", TokenPosition{1, 13, 15}},
-				{COMMA, ",", TokenPosition{1, 17, 17}},
-				{STR, "ghij", TokenPosition{1, 19, 22}},
-				{ILLEGAL, "whaat", TokenPosition{1, 25, 29}},
-				{ILLEGAL, "\"", TokenPosition{1, 32, 32}},
+				{TokType: STR, Lexeme: "a", TokPos: TokenPosition{1, 1, 3, 0}},
+				{TokType: COMMA, Lexeme: ",", TokPos: TokenPosition{1, 4, 4, 3}},
+				{TokType: STR, Lexeme: "bc", TokPos: TokenPosition{1, 6, 9, 5}},
+				{TokType: COMMA, Lexeme: ",", TokPos: TokenPosition{1, 10, 10, 9}},
+				{TokType: STR, Lexeme: "def", TokPos: TokenPosition{1, 12, 16, 11}},
+				{TokType: COMMA, Lexeme: ",", TokPos: TokenPosition{1, 17, 17, 16}},
+				{TokType: STR, Lexeme: "ghij", TokPos: TokenPosition{1, 18, 23, 17}},
+				{TokType: ILLEGAL, Lexeme: "whaat", TokPos: TokenPosition{1, 25, 29, 24}},
+				{TokType: ILLEGAL, Lexeme: "\"", TokPos: TokenPosition{1, 31, 31, 30}},
+			},
+		},
+		// Testing empty and escaped strings (positions include the surrounding quotes)
+		{
+			input: `"" "\"" "a\\"`,
+			expectedTokens: []Token{
+				{TokType: STR, Lexeme: "", TokPos: TokenPosition{1, 1, 2, 0}},
+				{TokType: STR, Lexeme: `\"`, TokPos: TokenPosition{1, 4, 7, 3}},
+				{TokType: STR, Lexeme: `a\\`, TokPos: TokenPosition{1, 9, 13, 8}},
+			},
+		},
+		// Testing byte offsets across lines and multi-byte runes
+		{
+			input: "{\n  \"é\": [1, true]\n}",
+			expectedTokens: []Token{
+				{TokType: LBRACE, Lexeme: "{", TokPos: TokenPosition{1, 1, 1, 0}},
+				{TokType: STR, Lexeme: "é", TokPos: TokenPosition{2, 3, 5, 4}},
+				{TokType: COLON, Lexeme: ":", TokPos: TokenPosition{2, 6, 6, 8}},
+				{TokType: LBRACKET, Lexeme: "[", TokPos: TokenPosition{2, 8, 8, 10}},
+				{TokType: NUM, Lexeme: "1", TokPos: TokenPosition{2, 9, 9, 11}},
+				{TokType: COMMA, Lexeme: ",", TokPos: TokenPosition{2, 10, 10, 12}},
+				{TokType: TRUE, Lexeme: "true", TokPos: TokenPosition{2, 12, 15, 14}},
+				{TokType: RBRACKET, Lexeme: "]", TokPos: TokenPosition{2, 16, 16, 18}},
+				{TokType: RBRACE, Lexeme: "}", TokPos: TokenPosition{3, 1, 1, 20}},
 			},
 		},
 		// Testing identifiers
 		{
 			input: `invalid true false null`,
 			expectedTokens: []Token{
-				{ILLEGAL, "invalid", TokenPosition{1, 1, 7}},
-				{TRUE, "true", TokenPosition{1, 9, 12}},
-				{FALSE, "false", TokenPosition{1, 14, 18}},
-				{NULL, "null", TokenPosition{1, 20, 23}},
+				{TokType: ILLEGAL, Lexeme: "invalid", TokPos: TokenPosition{1, 1, 7, 0}},
+				{TokType: TRUE, Lexeme: "true", TokPos: TokenPosition{1, 9, 12, 8}},
+				{TokType: FALSE, Lexeme: "false", TokPos: TokenPosition{1, 14, 18, 13}},
+				{TokType: NULL, Lexeme: "null", TokPos: TokenPosition{1, 20, 23, 19}},
+			},
+		},
+		// Testing identifiers starting w/ an exponent marker, which aren't numbers
+		{
+			input: `eggs E`,
+			expectedTokens: []Token{
+				{TokType: ILLEGAL, Lexeme: "eggs", TokPos: TokenPosition{1, 1, 4, 0}},
+				{TokType: ILLEGAL, Lexeme: "E", TokPos: TokenPosition{1, 6, 6, 5}},
 			},
 		},
 		// Testing numbers
 		{
 			input: `123 1.23 -1.23 1.23e10 -1.23e10 1.23e-10 -1.23e-10 1.23E10 -1.23E10 1.23E-10 -1.23E-10 e10 e-10 E10 E-10 -1.2.3 --1.2.3`,
 			expectedTokens: []Token{
-				{NUM, "123", TokenPosition{1, 1, 3}},
-				{NUM, "1.23", TokenPosition{1, 5, 8}},
-				{NUM, "-1.23", TokenPosition{1, 10, 14}},
-				{NUM, "1.23e10", TokenPosition{1, 16, 22}},
-				{NUM, "-1.23e10", TokenPosition{1, 24, 31}},
-				{NUM, "1.23e-10", TokenPosition{1, 33, 40}},
-				{NUM, "-1.23e-10", TokenPosition{1, 42, 50}},
-				{NUM, "1.23E10", TokenPosition{1, 52, 58}},
-				{NUM, "-1.23E10", TokenPosition{1, 60, 67}},
-				{NUM, "1.23E-10", TokenPosition{1, 69, 76}},
-				{NUM, "-1.23E-10", TokenPosition{1, 78, 86}},
-				{ILLEGAL, "e10", TokenPosition{1, 88, 90}},
-				{ILLEGAL, "e-10", TokenPosition{1, 92, 95}},
-				{ILLEGAL, "E10", TokenPosition{1, 97, 99}},
-				{ILLEGAL, "E-10", TokenPosition{1, 101, 104}},
-				{ILLEGAL, "-1.2.3", TokenPosition{1, 106, 111}},
-				{ILLEGAL, "--1.2.3", TokenPosition{1, 113, 119}},
+				{TokType: NUM, Lexeme: "123", TokPos: TokenPosition{1, 1, 3, 0}},
+				{TokType: NUM, Lexeme: "1.23", TokPos: TokenPosition{1, 5, 8, 4}},
+				{TokType: NUM, Lexeme: "-1.23", TokPos: TokenPosition{1, 10, 14, 9}},
+				{TokType: NUM, Lexeme: "1.23e10", TokPos: TokenPosition{1, 16, 22, 15}},
+				{TokType: NUM, Lexeme: "-1.23e10", TokPos: TokenPosition{1, 24, 31, 23}},
+				{TokType: NUM, Lexeme: "1.23e-10", TokPos: TokenPosition{1, 33, 40, 32}},
+				{TokType: NUM, Lexeme: "-1.23e-10", TokPos: TokenPosition{1, 42, 50, 41}},
+				{TokType: NUM, Lexeme: "1.23E10", TokPos: TokenPosition{1, 52, 58, 51}},
+				{TokType: NUM, Lexeme: "-1.23E10", TokPos: TokenPosition{1, 60, 67, 59}},
+				{TokType: NUM, Lexeme: "1.23E-10", TokPos: TokenPosition{1, 69, 76, 68}},
+				{TokType: NUM, Lexeme: "-1.23E-10", TokPos: TokenPosition{1, 78, 86, 77}},
+				// An exponent marker can't start a number, it's lexed as an identifier
+				{TokType: ILLEGAL, Lexeme: "e", TokPos: TokenPosition{1, 88, 88, 87}},
+				{TokType: NUM, Lexeme: "10", TokPos: TokenPosition{1, 89, 90, 88}},
+				{TokType: ILLEGAL, Lexeme: "e", TokPos: TokenPosition{1, 92, 92, 91}},
+				{TokType: NUM, Lexeme: "-10", TokPos: TokenPosition{1, 93, 95, 92}},
+				{TokType: ILLEGAL, Lexeme: "E", TokPos: TokenPosition{1, 97, 97, 96}},
+				{TokType: NUM, Lexeme: "10", TokPos: TokenPosition{1, 98, 99, 97}},
+				{TokType: ILLEGAL, Lexeme: "E", TokPos: TokenPosition{1, 101, 101, 100}},
+				{TokType: NUM, Lexeme: "-10", TokPos: TokenPosition{1, 102, 104, 101}},
+				{TokType: ILLEGAL, Lexeme: "-1.2.3", TokPos: TokenPosition{1, 106, 111, 105}},
+				{TokType: ILLEGAL, Lexeme: "--1.2.3", TokPos: TokenPosition{1, 113, 119, 112}},
+			},
+		},
+		// Testing negative zero
+		{
+			input: `-0 0 -0.0 -0e0`,
+			expectedTokens: []Token{
+				{TokType: NUM, Lexeme: "-0", TokPos: TokenPosition{1, 1, 2, 0}},
+				{TokType: NUM, Lexeme: "0", TokPos: TokenPosition{1, 4, 4, 3}},
+				{TokType: NUM, Lexeme: "-0.0", TokPos: TokenPosition{1, 6, 9, 5}},
+				{TokType: NUM, Lexeme: "-0e0", TokPos: TokenPosition{1, 11, 14, 10}},
 			},
 		},
 	}
@@ -104,13 +155,13 @@ func TestGetNextToken(t *testing.T) {
 
 			// Iterate through expected tokens and compare with actual tokens
 			for _, expectedToken := range testCase.expectedTokens {
-				actualToken := lexer.getNextToken()
+				actualToken := lexer.GetNextToken()
 				assertTokenEquality(t, expectedToken, actualToken)
 			}
 
 			// This if statement is an example of "statement initialization" where we declare a variable within the if statement (actualToken) and use it in the if statement
 			// This variable is only in-scope for the if statement
-			if actualToken := lexer.getNextToken(); actualToken.TokType != EOF {
+			if actualToken := lexer.GetNextToken(); actualToken.TokType != EOF {
 				t.Errorf("Expected EOF, got %v", actualToken.TokType)
 			}
 		})
@@ -126,7 +177,1391 @@ func assertTokenEquality(t *testing.T, expected Token, actual Token) {
 	}
 	if expected.TokPos.Line != actual.TokPos.Line ||
 		expected.TokPos.ColStart != actual.TokPos.ColStart ||
-		expected.TokPos.ColEnd != actual.TokPos.ColEnd {
+		expected.TokPos.ColEnd != actual.TokPos.ColEnd ||
+		expected.TokPos.Offset != actual.TokPos.Offset {
 		t.Errorf("Expected token position %v, got %v", expected.TokPos, actual.TokPos)
 	}
 }
+
+func TestPreserveTrivia(t *testing.T) {
+	input := "\n  {\"a\": 1 ,\t\"b\":[ ]  \r\n}\n\n"
+
+	tokens, err := LexReaderContext(context.Background(), strings.NewReader(input), Options{PreserveTrivia: true})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	// Define the expected trivia for each token
+	expectedTrivia := []struct {
+		lexeme   string
+		leading  string
+		trailing string
+	}{
+		{lexeme: "{", leading: "\n  ", trailing: ""},
+		{lexeme: "a", leading: "", trailing: ""},
+		{lexeme: ":", leading: "", trailing: " "},
+		{lexeme: "1", leading: "", trailing: " "},
+		{lexeme: ",", leading: "", trailing: "\t"},
+		{lexeme: "b", leading: "", trailing: ""},
+		{lexeme: ":", leading: "", trailing: ""},
+		{lexeme: "[", leading: "", trailing: " "},
+		{lexeme: "]", leading: "", trailing: "  \r\n"}, // CRLF line ending
+		{lexeme: "}", leading: "", trailing: "\n\n"},   // Trivia at the end of the input belongs to the last token
+	}
+
+	if len(tokens) != len(expectedTrivia) {
+		t.Fatalf("Expected %d tokens, got %d", len(expectedTrivia), len(tokens))
+	}
+
+	var reproduced strings.Builder
+	for i, expected := range expectedTrivia {
+		actual := tokens[i]
+		if actual.Lexeme != expected.lexeme {
+			t.Errorf("Expected lexeme %q, got %q", expected.lexeme, actual.Lexeme)
+		}
+		if actual.LeadingTrivia != expected.leading {
+			t.Errorf("Expected leading trivia %q for %q, got %q", expected.leading, expected.lexeme, actual.LeadingTrivia)
+		}
+		if actual.TrailingTrivia != expected.trailing {
+			t.Errorf("Expected trailing trivia %q for %q, got %q", expected.trailing, expected.lexeme, actual.TrailingTrivia)
+		}
+
+		reproduced.WriteString(actual.LeadingTrivia)
+		if actual.TokType == STR {
+			reproduced.WriteString(`"` + actual.Lexeme + `"`)
+		} else {
+			reproduced.WriteString(actual.Lexeme)
+		}
+		reproduced.WriteString(actual.TrailingTrivia)
+	}
+
+	// The input should be reproducible byte-for-byte from the tokens
+	if reproduced.String() != input {
+		t.Errorf("Expected reproduced input %q, got %q", input, reproduced.String())
+	}
+}
+
+func TestEmitWhitespace(t *testing.T) {
+	input := "{\r\n\t\"a\": [1,  2]\n\n}  "
+
+	// PreserveTrivia has no effect, the whitespace is part of the token stream instead
+	tokens, err := LexReaderContext(context.Background(), strings.NewReader(input), Options{EmitWhitespace: true, PreserveTrivia: true})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	expectedTokens := []Token{
+		{TokType: LBRACE, Lexeme: "{", TokPos: TokenPosition{1, 1, 1, 0}},
+		{TokType: WS, Lexeme: "\r", TokPos: TokenPosition{1, 2, 2, 1}},
+		{TokType: NEWLINE, Lexeme: "\n", TokPos: TokenPosition{1, 3, 3, 2}},
+		{TokType: WS, Lexeme: "\t", TokPos: TokenPosition{2, 1, 1, 3}},
+		{TokType: STR, Lexeme: "a", TokPos: TokenPosition{2, 2, 4, 4}},
+		{TokType: COLON, Lexeme: ":", TokPos: TokenPosition{2, 5, 5, 7}},
+		{TokType: WS, Lexeme: " ", TokPos: TokenPosition{2, 6, 6, 8}},
+		{TokType: LBRACKET, Lexeme: "[", TokPos: TokenPosition{2, 7, 7, 9}},
+		{TokType: NUM, Lexeme: "1", TokPos: TokenPosition{2, 8, 8, 10}},
+		{TokType: COMMA, Lexeme: ",", TokPos: TokenPosition{2, 9, 9, 11}},
+		{TokType: WS, Lexeme: "  ", TokPos: TokenPosition{2, 10, 11, 12}},
+		{TokType: NUM, Lexeme: "2", TokPos: TokenPosition{2, 12, 12, 14}},
+		{TokType: RBRACKET, Lexeme: "]", TokPos: TokenPosition{2, 13, 13, 15}},
+		{TokType: NEWLINE, Lexeme: "\n", TokPos: TokenPosition{2, 14, 14, 16}},
+		{TokType: NEWLINE, Lexeme: "\n", TokPos: TokenPosition{3, 1, 1, 17}},
+		{TokType: RBRACE, Lexeme: "}", TokPos: TokenPosition{4, 1, 1, 18}},
+		{TokType: WS, Lexeme: "  ", TokPos: TokenPosition{4, 2, 3, 19}},
+	}
+
+	if len(tokens) != len(expectedTokens) {
+		t.Fatalf("Expected %d tokens, got %d: %v", len(expectedTokens), len(tokens), tokens)
+	}
+
+	// The input is the concatenation of the lexemes
+	var reproduced strings.Builder
+	for i, expected := range expectedTokens {
+		assertTokenEquality(t, expected, tokens[i])
+		if tokens[i].LeadingTrivia != "" || tokens[i].TrailingTrivia != "" {
+			t.Errorf("Expected no trivia for %q, got %q & %q", tokens[i].Lexeme, tokens[i].LeadingTrivia, tokens[i].TrailingTrivia)
+		}
+		if tokens[i].TokType == STR {
+			reproduced.WriteString(`"` + tokens[i].Lexeme + `"`)
+		} else {
+			reproduced.WriteString(tokens[i].Lexeme)
+		}
+	}
+	if reproduced.String() != input {
+		t.Errorf("Expected reproduced input %q, got %q", input, reproduced.String())
+	}
+}
+
+func TestTriviaNotPreservedByDefault(t *testing.T) {
+	for _, tok := range LexReader(strings.NewReader(" { \"a\" : 1 } \n")) {
+		if tok.LeadingTrivia != "" || tok.TrailingTrivia != "" {
+			t.Errorf("Expected no trivia for %q, got %q / %q", tok.Lexeme, tok.LeadingTrivia, tok.TrailingTrivia)
+		}
+	}
+}
+
+func TestComments(t *testing.T) {
+	// Define test cases
+	testCases := []struct {
+		input          string
+		opts           Options
+		expectedTokens []Token
+	}{
+		// Comments are rejected by default
+		{
+			input: `// comment`,
+			expectedTokens: []Token{
+				{TokType: ILLEGAL, Lexeme: "/", TokPos: TokenPosition{1, 1, 1, 0}},
+				{TokType: ILLEGAL, Lexeme: "/", TokPos: TokenPosition{1, 2, 2, 1}},
+				{TokType: ILLEGAL, Lexeme: "comment", TokPos: TokenPosition{1, 4, 10, 3}},
+			},
+		},
+		// Line comments run up to the end of the line
+		{
+			input: "[1, // first\n2] // last",
+			opts:  Options{AllowComments: true},
+			expectedTokens: []Token{
+				{TokType: LBRACKET, Lexeme: "[", TokPos: TokenPosition{1, 1, 1, 0}},
+				{TokType: NUM, Lexeme: "1", TokPos: TokenPosition{1, 2, 2, 1}},
+				{TokType: COMMA, Lexeme: ",", TokPos: TokenPosition{1, 3, 3, 2}},
+				{TokType: COMMENT, Lexeme: "// first", TokPos: TokenPosition{1, 5, 12, 4}},
+				{TokType: NUM, Lexeme: "2", TokPos: TokenPosition{2, 1, 1, 13}},
+				{TokType: RBRACKET, Lexeme: "]", TokPos: TokenPosition{2, 2, 2, 14}},
+				{TokType: COMMENT, Lexeme: "// last", TokPos: TokenPosition{2, 4, 10, 16}},
+			},
+		},
+		// Block comments can span lines
+		{
+			input: "/**/{/* a\nb */}",
+			opts:  Options{AllowComments: true},
+			expectedTokens: []Token{
+				{TokType: COMMENT, Lexeme: "/**/", TokPos: TokenPosition{1, 1, 4, 0}},
+				{TokType: LBRACE, Lexeme: "{", TokPos: TokenPosition{1, 5, 5, 4}},
+				{TokType: COMMENT, Lexeme: "/* a\nb */", TokPos: TokenPosition{1, 6, 4, 5}},
+				{TokType: RBRACE, Lexeme: "}", TokPos: TokenPosition{2, 5, 5, 14}},
+			},
+		},
+		// Unterminated block comments and lone slashes are illegal
+		{
+			input: "/ /* open",
+			opts:  Options{AllowComments: true},
+			expectedTokens: []Token{
+				{TokType: ILLEGAL, Lexeme: "/", TokPos: TokenPosition{1, 1, 1, 0}},
+				{TokType: ILLEGAL, Lexeme: "/* open", TokPos: TokenPosition{1, 3, 9, 2}},
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.input, func(t *testing.T) {
+			lexer := NewLexer(strings.NewReader(testCase.input), testCase.opts)
+
+			for _, expectedToken := range testCase.expectedTokens {
+				actualToken := lexer.GetNextToken()
+				assertTokenEquality(t, expectedToken, actualToken)
+			}
+
+			if actualToken := lexer.GetNextToken(); actualToken.TokType != EOF {
+				t.Errorf("Expected EOF, got %v", actualToken.TokType)
+			}
+		})
+	}
+}
+
+func TestMalformedNumbers(t *testing.T) {
+	// Define test cases
+	testCases := []struct {
+		input            string
+		expectedToken    Token
+		expectedErrorMsg string
+	}{
+		{
+			input:            `123abc`,
+			expectedToken:    Token{TokType: ILLEGAL, Lexeme: "123abc", TokPos: TokenPosition{1, 1, 6, 0}},
+			expectedErrorMsg: "invalid number: unexpected character 'a'",
+		},
+		{
+			input:            `-1x`,
+			expectedToken:    Token{TokType: ILLEGAL, Lexeme: "-1x", TokPos: TokenPosition{1, 1, 3, 0}},
+			expectedErrorMsg: "invalid number: unexpected character 'x'",
+		},
+		{
+			input:            `1.2.3`,
+			expectedToken:    Token{TokType: ILLEGAL, Lexeme: "1.2.3", TokPos: TokenPosition{1, 1, 5, 0}},
+			expectedErrorMsg: "invalid number: multiple decimal points",
+		},
+		{
+			input:            `-1.2.3`,
+			expectedToken:    Token{TokType: ILLEGAL, Lexeme: "-1.2.3", TokPos: TokenPosition{1, 1, 6, 0}},
+			expectedErrorMsg: "invalid number: multiple decimal points",
+		},
+		{
+			input:            `1e2e3`,
+			expectedToken:    Token{TokType: ILLEGAL, Lexeme: "1e2e3", TokPos: TokenPosition{1, 1, 5, 0}},
+			expectedErrorMsg: "invalid number: multiple exponents",
+		},
+		{
+			input:            `1E2e-3`,
+			expectedToken:    Token{TokType: ILLEGAL, Lexeme: "1E2e-3", TokPos: TokenPosition{1, 1, 6, 0}},
+			expectedErrorMsg: "invalid number: multiple exponents",
+		},
+		{
+			input:            `--1`,
+			expectedToken:    Token{TokType: ILLEGAL, Lexeme: "--1", TokPos: TokenPosition{1, 1, 3, 0}},
+			expectedErrorMsg: "invalid number: misplaced sign",
+		},
+		{
+			input:            `1-2`,
+			expectedToken:    Token{TokType: ILLEGAL, Lexeme: "1-2", TokPos: TokenPosition{1, 1, 3, 0}},
+			expectedErrorMsg: "invalid number: misplaced sign",
+		},
+		{
+			input:            `1+2`,
+			expectedToken:    Token{TokType: ILLEGAL, Lexeme: "1+2", TokPos: TokenPosition{1, 1, 3, 0}},
+			expectedErrorMsg: "invalid number: misplaced '+' sign",
+		},
+		{
+			input:            `1e5+`,
+			expectedToken:    Token{TokType: ILLEGAL, Lexeme: "1e5+", TokPos: TokenPosition{1, 1, 4, 0}},
+			expectedErrorMsg: "invalid number: misplaced '+' sign",
+		},
+		{
+			input:            `+1`,
+			expectedToken:    Token{TokType: ILLEGAL, Lexeme: "+1", TokPos: TokenPosition{1, 1, 2, 0}},
+			expectedErrorMsg: "invalid number: leading '+' sign",
+		},
+		{
+			input:            `+12.5e3`,
+			expectedToken:    Token{TokType: ILLEGAL, Lexeme: "+12.5e3", TokPos: TokenPosition{1, 1, 7, 0}},
+			expectedErrorMsg: "invalid number: leading '+' sign",
+		},
+		// Other malformations are only reported as invalid
+		{
+			input:            `01`,
+			expectedToken:    Token{TokType: ILLEGAL, Lexeme: "01", TokPos: TokenPosition{1, 1, 2, 0}},
+			expectedErrorMsg: "invalid number",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.input, func(t *testing.T) {
+			lexer := createLexer(strings.NewReader(testCase.input))
+
+			actualToken := lexer.GetNextToken()
+			assertTokenEquality(t, testCase.expectedToken, actualToken)
+			if !errors.Is(actualToken.Err, ErrInvalidNumber) || actualToken.Err.Error() != testCase.expectedErrorMsg {
+				t.Errorf("Expected error %q, got %v", testCase.expectedErrorMsg, actualToken.Err)
+			}
+
+			// The malformed literal is a single token
+			if actualToken := lexer.GetNextToken(); actualToken.TokType != EOF {
+				t.Errorf("Expected EOF, got %v", actualToken.TokType)
+			}
+		})
+	}
+}
+
+func TestMaxNumberLength(t *testing.T) {
+	// Define test cases
+	testCases := []struct {
+		name          string
+		input         string
+		maxLength     int
+		expectedToken Token
+		expectedErr   error
+	}{
+		{name: "at the limit", input: "12345 1", maxLength: 5, expectedToken: Token{TokType: NUM, Lexeme: "12345", TokPos: TokenPosition{1, 1, 5, 0}}},
+		// The token spans the whole literal
+		{name: "over the limit", input: "-123456 1", maxLength: 5, expectedToken: Token{TokType: ILLEGAL, Lexeme: "-", TokPos: TokenPosition{1, 1, 7, 0}}, expectedErr: ErrNumberTooLong},
+		{name: "no limit", input: strings.Repeat("9", 2000) + " 1", maxLength: -1, expectedToken: Token{TokType: NUM, Lexeme: strings.Repeat("9", 2000), TokPos: TokenPosition{1, 1, 2000, 0}}},
+		// The default limit applies when none is specified
+		{
+			name:          "over the default limit",
+			input:         strings.Repeat("1", DefaultMaxNumberLength+1) + " 1",
+			expectedToken: Token{TokType: ILLEGAL, Lexeme: "1", TokPos: TokenPosition{1, 1, DefaultMaxNumberLength + 1, 0}},
+			expectedErr:   ErrNumberTooLong,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			tokens, err := LexReaderContext(context.Background(), strings.NewReader(testCase.input), Options{MaxNumberLength: testCase.maxLength})
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+
+			assertTokenEquality(t, testCase.expectedToken, tokens[0])
+			if !errors.Is(tokens[0].Err, testCase.expectedErr) {
+				t.Errorf("Expected error %v, got %v", testCase.expectedErr, tokens[0].Err)
+			}
+
+			// Lexing resumes after the literal
+			if len(tokens) != 2 || tokens[1].Lexeme != "1" {
+				t.Errorf("Expected the literal to be followed by a single token '1', got %v", tokens[1:])
+			}
+		})
+	}
+}
+
+func TestMaxTokenLength(t *testing.T) {
+	// Define test cases
+	testCases := []struct {
+		name          string
+		input         string
+		opts          Options
+		expectedToken Token
+		expectedErr   string // Empty if no error is expected
+	}{
+		{name: "string at the limit", input: `"abcde" 1`, opts: Options{MaxTokenLength: 5}, expectedToken: Token{TokType: STR, Lexeme: "abcde", TokPos: TokenPosition{1, 1, 7, 0}}},
+		// The token is positioned at the start of the value & spans all of it
+		{
+			name:          "string over the limit",
+			input:         `"abcdef" 1`,
+			opts:          Options{MaxTokenLength: 5},
+			expectedToken: Token{TokType: ILLEGAL, Lexeme: `"`, TokPos: TokenPosition{1, 1, 8, 0}},
+			expectedErr:   "token too long: exceeds the maximum length of 5 characters",
+		},
+		{
+			name:          "number over the limit",
+			input:         "123456 1",
+			opts:          Options{MaxTokenLength: 5},
+			expectedToken: Token{TokType: ILLEGAL, Lexeme: "1", TokPos: TokenPosition{1, 1, 6, 0}},
+			expectedErr:   "token too long: exceeds the maximum length of 5 characters",
+		},
+		{
+			name:          "identifier over the limit",
+			input:         "truetrue 1",
+			opts:          Options{MaxTokenLength: 5},
+			expectedToken: Token{TokType: ILLEGAL, Lexeme: "t", TokPos: TokenPosition{1, 1, 8, 0}},
+			expectedErr:   "token too long: exceeds the maximum length of 5 characters",
+		},
+		{
+			name:          "comment over the limit",
+			input:         "/* long\n comment */ 1",
+			opts:          Options{MaxTokenLength: 5, AllowComments: true},
+			expectedToken: Token{TokType: ILLEGAL, Lexeme: "/*", TokPos: TokenPosition{1, 1, 11, 0}},
+			expectedErr:   "token too long: exceeds the maximum length of 5 characters",
+		},
+		// The lower limit of the kind of the token applies
+		{
+			name:          "lower string limit",
+			input:         `"abcdef" 1`,
+			opts:          Options{MaxTokenLength: 10, MaxStringLength: 3},
+			expectedToken: Token{TokType: ILLEGAL, Lexeme: `"`, TokPos: TokenPosition{1, 1, 8, 0}},
+			expectedErr:   "string too long: exceeds the maximum length of 3 characters",
+		},
+		{
+			name:          "lower token limit",
+			input:         `"abcdef" 1`,
+			opts:          Options{MaxTokenLength: 3, MaxStringLength: 10},
+			expectedToken: Token{TokType: ILLEGAL, Lexeme: `"`, TokPos: TokenPosition{1, 1, 8, 0}},
+			expectedErr:   "token too long: exceeds the maximum length of 3 characters",
+		},
+		{
+			name:          "number under the token limit",
+			input:         "123456 1",
+			opts:          Options{MaxTokenLength: 10, MaxNumberLength: 3},
+			expectedToken: Token{TokType: ILLEGAL, Lexeme: "1", TokPos: TokenPosition{1, 1, 6, 0}},
+			expectedErr:   "number literal too long: exceeds the maximum length of 3 characters",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			tokens, err := LexReaderContext(context.Background(), strings.NewReader(testCase.input), testCase.opts)
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+
+			assertTokenEquality(t, testCase.expectedToken, tokens[0])
+			if testCase.expectedErr == "" {
+				if tokens[0].Err != nil {
+					t.Errorf("Expected no error, got %v", tokens[0].Err)
+				}
+			} else if tokens[0].Err == nil || tokens[0].Err.Error() != testCase.expectedErr {
+				t.Errorf("Expected error %q, got %v", testCase.expectedErr, tokens[0].Err)
+			}
+
+			// Lexing resumes after the token
+			if len(tokens) != 2 || tokens[1].Lexeme != "1" {
+				t.Errorf("Expected the token to be followed by a single token '1', got %v", tokens[1:])
+			}
+		})
+	}
+}
+
+func TestKeywordTypos(t *testing.T) {
+	// Define test cases
+	testCases := []struct {
+		input            string
+		expectedType     TokenType
+		expectedErrorMsg string // Empty if no suggestion is expected
+	}{
+		{input: "true", expectedType: TRUE},
+		{input: "false", expectedType: FALSE},
+		{input: "null", expectedType: NULL},
+		{input: "tru", expectedType: ILLEGAL, expectedErrorMsg: "unknown literal 'tru'; did you mean 'true'?"},
+		{input: "truee", expectedType: ILLEGAL, expectedErrorMsg: "unknown literal 'truee'; did you mean 'true'?"},
+		{input: "True", expectedType: ILLEGAL, expectedErrorMsg: "unknown literal 'True'; did you mean 'true'?"},
+		{input: "flase", expectedType: ILLEGAL, expectedErrorMsg: "unknown literal 'flase'; did you mean 'false'?"},
+		{input: "fals", expectedType: ILLEGAL, expectedErrorMsg: "unknown literal 'fals'; did you mean 'false'?"},
+		{input: "nul", expectedType: ILLEGAL, expectedErrorMsg: "unknown literal 'nul'; did you mean 'null'?"},
+		{input: "nill", expectedType: ILLEGAL, expectedErrorMsg: "unknown literal 'nill'; did you mean 'null'?"},
+		// Identifiers further from a keyword aren't typos
+		{input: "tr", expectedType: ILLEGAL},
+		{input: "none", expectedType: ILLEGAL},
+		{input: "undefined", expectedType: ILLEGAL},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.input, func(t *testing.T) {
+			lexer := createLexer(strings.NewReader(testCase.input))
+
+			actualToken := lexer.GetNextToken()
+			if actualToken.TokType != testCase.expectedType || actualToken.Lexeme != testCase.input {
+				t.Fatalf("Expected %v token %q, got %v token %q", testCase.expectedType, testCase.input, actualToken.TokType, actualToken.Lexeme)
+			}
+
+			if testCase.expectedErrorMsg == "" {
+				if actualToken.Err != nil {
+					t.Errorf("Expected no error, got %v", actualToken.Err)
+				}
+			} else if actualToken.Err == nil || actualToken.Err.Error() != testCase.expectedErrorMsg {
+				t.Errorf("Expected error %q, got %v", testCase.expectedErrorMsg, actualToken.Err)
+			}
+		})
+	}
+}
+
+func TestExponentForms(t *testing.T) {
+	// Define test cases
+	testCases := []struct {
+		input        string
+		expectedType TokenType
+	}{
+		{input: "0e0", expectedType: NUM},
+		{input: "1E+000", expectedType: NUM},
+		{input: "1e-0", expectedType: NUM},
+		{input: "-0.0E+0", expectedType: NUM},
+		// The exponent is only checked lexically, the value overflowing (or not) is up to the parser
+		{input: "1e0000000000", expectedType: NUM},
+		{input: "1e+999999999999", expectedType: NUM},
+		{input: "1e+", expectedType: ILLEGAL},
+		{input: "1e+-2", expectedType: ILLEGAL},
+		// A '+' can't start a number, nor follow its digits
+		{input: "+1", expectedType: ILLEGAL},
+		{input: "1+2", expectedType: ILLEGAL},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.input, func(t *testing.T) {
+			lexer := createLexer(strings.NewReader(testCase.input))
+
+			actualToken := lexer.GetNextToken()
+			if actualToken.TokType != testCase.expectedType || actualToken.Lexeme != testCase.input {
+				t.Fatalf("Expected %v token %q, got %v token %q", testCase.expectedType, testCase.input, actualToken.TokType, actualToken.Lexeme)
+			}
+			if actualToken := lexer.GetNextToken(); actualToken.TokType != EOF {
+				t.Errorf("Expected EOF, got %v", actualToken.TokType)
+			}
+		})
+	}
+}
+
+func TestHexNumbers(t *testing.T) {
+	// Define test cases
+	testCases := []struct {
+		input            string
+		relaxed          bool
+		expectedType     TokenType
+		expectedErrorMsg string // Empty if no error is expected
+	}{
+		{input: "0x1F", relaxed: true, expectedType: NUM},
+		{input: "0xabc", relaxed: true, expectedType: NUM},
+		{input: "-0XFF", relaxed: true, expectedType: NUM},
+		{input: "0xG", relaxed: true, expectedType: ILLEGAL, expectedErrorMsg: "invalid number: invalid hexadecimal number"},
+		{input: "0x", relaxed: true, expectedType: ILLEGAL, expectedErrorMsg: "invalid number: invalid hexadecimal number"},
+		// Strict JSON has no hexadecimal numbers
+		{input: "0x1F", expectedType: ILLEGAL, expectedErrorMsg: "invalid number: unexpected character 'x'"},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(fmt.Sprintf("%s relaxed=%v", testCase.input, testCase.relaxed), func(t *testing.T) {
+			lexer := NewLexer(strings.NewReader(testCase.input), Options{Relaxed: testCase.relaxed})
+
+			actualToken := lexer.GetNextToken()
+			if actualToken.TokType != testCase.expectedType || actualToken.Lexeme != testCase.input {
+				t.Fatalf("Expected %v token %q, got %v token %q", testCase.expectedType, testCase.input, actualToken.TokType, actualToken.Lexeme)
+			}
+
+			if testCase.expectedErrorMsg == "" {
+				if actualToken.Err != nil {
+					t.Errorf("Expected no error, got %v", actualToken.Err)
+				}
+			} else if actualToken.Err == nil || actualToken.Err.Error() != testCase.expectedErrorMsg {
+				t.Errorf("Expected error %q, got %v", testCase.expectedErrorMsg, actualToken.Err)
+			}
+		})
+	}
+}
+
+func TestNaNInf(t *testing.T) {
+	// Define test cases
+	testCases := []struct {
+		input            string
+		allowNaNInf      bool
+		expectedType     TokenType
+		expectedErrorMsg string // Empty if no error is expected
+	}{
+		{input: "NaN", allowNaNInf: true, expectedType: NUM},
+		{input: "Infinity", allowNaNInf: true, expectedType: NUM},
+		{input: "-Infinity", allowNaNInf: true, expectedType: NUM},
+		// Only the spellings of JavaScript (& Python's json module) are accepted
+		{input: "nan", allowNaNInf: true, expectedType: ILLEGAL},
+		{input: "-NaN", allowNaNInf: true, expectedType: ILLEGAL, expectedErrorMsg: "invalid number: unexpected character 'N'"},
+		// Strict JSON has no NaN nor Infinity
+		{input: "NaN", expectedType: ILLEGAL},
+		{input: "Infinity", expectedType: ILLEGAL},
+		{input: "-Infinity", expectedType: ILLEGAL, expectedErrorMsg: "invalid number: unexpected character 'I'"},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(fmt.Sprintf("%s allowNaNInf=%v", testCase.input, testCase.allowNaNInf), func(t *testing.T) {
+			lexer := NewLexer(strings.NewReader(testCase.input), Options{AllowNaNInf: testCase.allowNaNInf})
+
+			actualToken := lexer.GetNextToken()
+			if actualToken.TokType != testCase.expectedType || actualToken.Lexeme != testCase.input {
+				t.Fatalf("Expected %v token %q, got %v token %q", testCase.expectedType, testCase.input, actualToken.TokType, actualToken.Lexeme)
+			}
+
+			if testCase.expectedErrorMsg == "" {
+				if actualToken.Err != nil {
+					t.Errorf("Expected no error, got %v", actualToken.Err)
+				}
+			} else if actualToken.Err == nil || actualToken.Err.Error() != testCase.expectedErrorMsg {
+				t.Errorf("Expected error %q, got %v", testCase.expectedErrorMsg, actualToken.Err)
+			}
+		})
+	}
+}
+
+func TestRelaxedKeys(t *testing.T) {
+	// Define test cases
+	testCases := []struct {
+		input          string
+		relaxedKeys    bool
+		expectedTokens []Token
+	}{
+		{
+			input:       `{max_size2: 1}`,
+			relaxedKeys: true,
+			expectedTokens: []Token{
+				{TokType: LBRACE, Lexeme: "{", TokPos: TokenPosition{1, 1, 1, 0}},
+				{TokType: ILLEGAL, Lexeme: "max_size2", TokPos: TokenPosition{1, 2, 10, 1}},
+				{TokType: COLON, Lexeme: ":", TokPos: TokenPosition{1, 11, 11, 10}},
+				{TokType: NUM, Lexeme: "1", TokPos: TokenPosition{1, 13, 13, 12}},
+				{TokType: RBRACE, Lexeme: "}", TokPos: TokenPosition{1, 14, 14, 13}},
+			},
+		},
+		{
+			input:       `{'it\'s': $x}`,
+			relaxedKeys: true,
+			expectedTokens: []Token{
+				{TokType: LBRACE, Lexeme: "{", TokPos: TokenPosition{1, 1, 1, 0}},
+				{TokType: ILLEGAL, Lexeme: `'it\'s'`, TokPos: TokenPosition{1, 2, 8, 1}},
+				{TokType: COLON, Lexeme: ":", TokPos: TokenPosition{1, 9, 9, 8}},
+				{TokType: ILLEGAL, Lexeme: "$x", TokPos: TokenPosition{1, 11, 12, 10}},
+				{TokType: RBRACE, Lexeme: "}", TokPos: TokenPosition{1, 13, 13, 12}},
+			},
+		},
+		// Strict JSON identifiers are only letters, and single quotes are unknown characters
+		{
+			input: `{max_size: 'a'}`,
+			expectedTokens: []Token{
+				{TokType: LBRACE, Lexeme: "{", TokPos: TokenPosition{1, 1, 1, 0}},
+				{TokType: ILLEGAL, Lexeme: "max", TokPos: TokenPosition{1, 2, 4, 1}},
+				{TokType: ILLEGAL, Lexeme: "_", TokPos: TokenPosition{1, 5, 5, 4}},
+				{TokType: ILLEGAL, Lexeme: "size", TokPos: TokenPosition{1, 6, 9, 5}},
+				{TokType: COLON, Lexeme: ":", TokPos: TokenPosition{1, 10, 10, 9}},
+				{TokType: ILLEGAL, Lexeme: "'", TokPos: TokenPosition{1, 12, 12, 11}},
+				{TokType: ILLEGAL, Lexeme: "a", TokPos: TokenPosition{1, 13, 13, 12}},
+				{TokType: ILLEGAL, Lexeme: "'", TokPos: TokenPosition{1, 14, 14, 13}},
+				{TokType: RBRACE, Lexeme: "}", TokPos: TokenPosition{1, 15, 15, 14}},
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(fmt.Sprintf("%s relaxedKeys=%v", testCase.input, testCase.relaxedKeys), func(t *testing.T) {
+			lexer := NewLexer(strings.NewReader(testCase.input), Options{RelaxedKeys: testCase.relaxedKeys})
+
+			for _, expectedToken := range testCase.expectedTokens {
+				actualToken := lexer.GetNextToken()
+				assertTokenEquality(t, expectedToken, actualToken)
+
+				// Only single-quoted strings are flagged, so the parser can tell them from unknown literals
+				isSingleQuoted := strings.HasPrefix(actualToken.Lexeme, "'") && len(actualToken.Lexeme) > 1
+				if isSingleQuoted != errors.Is(actualToken.Err, ErrSingleQuoted) {
+					t.Errorf("Expected ErrSingleQuoted only for single-quoted strings, got %v for %q", actualToken.Err, actualToken.Lexeme)
+				}
+			}
+			if tok := lexer.GetNextToken(); tok.TokType != EOF {
+				t.Errorf("Expected EOF, got %v", tok.TokType)
+			}
+		})
+	}
+}
+
+func TestMaxStringLength(t *testing.T) {
+	// Define test cases, w/ a limit of 5 characters
+	testCases := []struct {
+		input         string
+		expectedToken Token
+		expectedErr   error
+	}{
+		// Just under the limit
+		{input: `"abcd" 1`, expectedToken: Token{TokType: STR, Lexeme: "abcd", TokPos: TokenPosition{1, 1, 6, 0}}},
+		// At the limit
+		{input: `"abcde" 1`, expectedToken: Token{TokType: STR, Lexeme: "abcde", TokPos: TokenPosition{1, 1, 7, 0}}},
+		// Just over the limit, the token spans the whole string
+		{input: `"abcdef" 1`, expectedToken: Token{TokType: ILLEGAL, Lexeme: `"`, TokPos: TokenPosition{1, 1, 8, 0}}, expectedErr: ErrStringTooLong},
+		// Escape sequences are counted as written
+		{input: `"ab\\cd" 1`, expectedToken: Token{TokType: ILLEGAL, Lexeme: `"`, TokPos: TokenPosition{1, 1, 8, 0}}, expectedErr: ErrStringTooLong},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.input, func(t *testing.T) {
+			tokens, err := LexReaderContext(context.Background(), strings.NewReader(testCase.input), Options{MaxStringLength: 5})
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+
+			assertTokenEquality(t, testCase.expectedToken, tokens[0])
+			if !errors.Is(tokens[0].Err, testCase.expectedErr) {
+				t.Errorf("Expected error %v, got %v", testCase.expectedErr, tokens[0].Err)
+			}
+
+			// Lexing resumes after the closing quote
+			assertTokenEquality(t, Token{TokType: NUM, Lexeme: "1", TokPos: TokenPosition{1, len(testCase.input), len(testCase.input), len(testCase.input) - 1}}, tokens[1])
+		})
+	}
+}
+
+func TestFinalNewlines(t *testing.T) {
+	// Define test cases
+	testCases := []struct {
+		input                 string
+		expectedFinalNewlines int
+	}{
+		{input: `{}`, expectedFinalNewlines: 0},
+		{input: "{}\n", expectedFinalNewlines: 1},
+		{input: "{}\n\n\n", expectedFinalNewlines: 3},
+		{input: "{}\n ", expectedFinalNewlines: 0},
+		{input: "{\n}", expectedFinalNewlines: 0},
+		// CRLF line endings
+		{input: "{}\r\n", expectedFinalNewlines: 1},
+		{input: "{}\r\n\r\n", expectedFinalNewlines: 2},
+		{input: "{}\r\n \r\n", expectedFinalNewlines: 1},
+		// The newline is read again after backing up at the end of the number
+		{input: "1\n", expectedFinalNewlines: 1},
+		{input: "null\n\n", expectedFinalNewlines: 2},
+	}
+
+	for _, testCase := range testCases {
+		for _, opts := range []Options{{}, {PreserveTrivia: true}} {
+			t.Run(testCase.input, func(t *testing.T) {
+				lxr := NewLexer(strings.NewReader(testCase.input), opts)
+				if _, err := lxr.ReadAll(context.Background()); err != nil {
+					t.Fatalf("Expected no error, got %v", err)
+				}
+				if lxr.FinalNewlines != testCase.expectedFinalNewlines {
+					t.Errorf("Expected %d final newlines, got %d", testCase.expectedFinalNewlines, lxr.FinalNewlines)
+				}
+			})
+		}
+	}
+}
+
+func TestEOFPosition(t *testing.T) {
+	// Define test cases, the EOF token is positioned just past the last rune
+	testCases := []struct {
+		input       string
+		expectedPos TokenPosition
+	}{
+		{input: "", expectedPos: TokenPosition{Line: 1, ColStart: 1, ColEnd: 1, Offset: 0}},
+		{input: "{}", expectedPos: TokenPosition{Line: 1, ColStart: 3, ColEnd: 3, Offset: 2}},
+		{input: "{}  ", expectedPos: TokenPosition{Line: 1, ColStart: 5, ColEnd: 5, Offset: 4}},
+		{input: "{}\n", expectedPos: TokenPosition{Line: 2, ColStart: 1, ColEnd: 1, Offset: 3}},
+		{input: "{}\n\n", expectedPos: TokenPosition{Line: 3, ColStart: 1, ColEnd: 1, Offset: 4}},
+		{input: "[1,\n  \"é\"", expectedPos: TokenPosition{Line: 2, ColStart: 6, ColEnd: 6, Offset: 10}},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.input, func(t *testing.T) {
+			lexer := createLexer(strings.NewReader(testCase.input))
+
+			tok := lexer.GetNextToken()
+			for tok.TokType != EOF {
+				tok = lexer.GetNextToken()
+			}
+
+			if tok.TokPos != testCase.expectedPos {
+				t.Errorf("Expected EOF position %+v, got %+v", testCase.expectedPos, tok.TokPos)
+			}
+		})
+	}
+}
+
+func TestStartAt(t *testing.T) {
+	// Lex the record "[1,\n  true]" as if it started at line 3, Column 5 & byte offset 20 of a larger input
+	lexer := createLexer(strings.NewReader("[1,\n  true]"))
+	lexer.StartAt(3, 5, 20)
+
+	expectedTokens := []Token{
+		{TokType: LBRACKET, Lexeme: "[", TokPos: TokenPosition{3, 5, 5, 20}},
+		{TokType: NUM, Lexeme: "1", TokPos: TokenPosition{3, 6, 6, 21}},
+		{TokType: COMMA, Lexeme: ",", TokPos: TokenPosition{3, 7, 7, 22}},
+		// Columns restart on the following lines
+		{TokType: TRUE, Lexeme: "true", TokPos: TokenPosition{4, 3, 6, 26}},
+		{TokType: RBRACKET, Lexeme: "]", TokPos: TokenPosition{4, 7, 7, 30}},
+	}
+	for _, expected := range expectedTokens {
+		assertTokenEquality(t, expected, lexer.GetNextToken())
+	}
+}
+
+func TestReset(t *testing.T) {
+	inputs := []string{"{\"a\": [1, true]}\n", "  \"unterminated", "[null,\n  -1.5e3]  \n\n"}
+
+	opts := Options{PreserveTrivia: true}
+	reused := NewLexer(strings.NewReader(""), opts)
+
+	for _, input := range inputs {
+		t.Run(input, func(t *testing.T) {
+			fresh := NewLexer(strings.NewReader(input), opts)
+			expected, err := fresh.ReadAll(context.Background())
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+
+			reused.Reset(strings.NewReader(input))
+			actual, err := reused.ReadAll(context.Background())
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+
+			if len(actual) != len(expected) {
+				t.Fatalf("Expected %d tokens, got %d", len(expected), len(actual))
+			}
+			for i := range expected {
+				assertTokenEquality(t, expected[i], actual[i])
+				if actual[i].LeadingTrivia != expected[i].LeadingTrivia || actual[i].TrailingTrivia != expected[i].TrailingTrivia {
+					t.Errorf("Expected trivia %q & %q, got %q & %q",
+						expected[i].LeadingTrivia, expected[i].TrailingTrivia, actual[i].LeadingTrivia, actual[i].TrailingTrivia)
+				}
+			}
+			if reused.Pos != fresh.Pos || reused.FinalNewlines != fresh.FinalNewlines {
+				t.Errorf("Expected position %+v & %d final newlines, got %+v & %d",
+					fresh.Pos, fresh.FinalNewlines, reused.Pos, reused.FinalNewlines)
+			}
+		})
+	}
+}
+
+func TestPeekToken(t *testing.T) {
+	// Define test cases
+	testCases := []struct {
+		input string
+		opts  Options
+	}{
+		{input: `{"a": [1, true, null]}`},
+		{input: "[1,\n  2,]\n", opts: Options{PreserveTrivia: true}},
+		{input: "[whaat, 1.2.3, \"unterminated"},
+		{input: ""},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.input, func(t *testing.T) {
+			expected, err := NewLexer(strings.NewReader(testCase.input), testCase.opts).ReadAll(context.Background())
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+
+			// Each token is peeked (twice) before being consumed
+			lexer := NewLexer(strings.NewReader(testCase.input), testCase.opts)
+			for i := 0; ; i++ {
+				peeked := lexer.PeekToken()
+				if again := lexer.PeekToken(); again != peeked {
+					t.Errorf("Expected peeking again to return %+v, got %+v", peeked, again)
+				}
+				actual := lexer.GetNextToken()
+				if actual != peeked {
+					t.Errorf("Expected GetNextToken to return the peeked token %+v, got %+v", peeked, actual)
+				}
+
+				if actual.TokType == EOF {
+					if i != len(expected) {
+						t.Errorf("Expected %d tokens before EOF, got %d", len(expected), i)
+					}
+					break
+				}
+				if i >= len(expected) {
+					t.Fatalf("Expected %d tokens, got more", len(expected))
+				}
+				assertTokenEquality(t, expected[i], actual)
+			}
+		})
+	}
+}
+
+func TestPositionsAreOneBased(t *testing.T) {
+	// Every kind of token starting a line is at column 1 of that line
+	testCases := []struct {
+		input         string
+		expectedToken Token
+	}{
+		{input: "\n{", expectedToken: Token{TokType: LBRACE, Lexeme: "{", TokPos: TokenPosition{2, 1, 1, 1}}},
+		{input: "\n\"ab\"", expectedToken: Token{TokType: STR, Lexeme: "ab", TokPos: TokenPosition{2, 1, 4, 1}}},
+		{input: "\n-12", expectedToken: Token{TokType: NUM, Lexeme: "-12", TokPos: TokenPosition{2, 1, 3, 1}}},
+		{input: "\ntrue", expectedToken: Token{TokType: TRUE, Lexeme: "true", TokPos: TokenPosition{2, 1, 4, 1}}},
+		{input: "\nwhat", expectedToken: Token{TokType: ILLEGAL, Lexeme: "what", TokPos: TokenPosition{2, 1, 4, 1}}},
+		{input: "\n@", expectedToken: Token{TokType: ILLEGAL, Lexeme: "@", TokPos: TokenPosition{2, 1, 1, 1}}},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.input, func(t *testing.T) {
+			lexer := createLexer(strings.NewReader(testCase.input))
+			assertTokenEquality(t, testCase.expectedToken, lexer.GetNextToken())
+		})
+	}
+}
+
+func TestCreateToken(t *testing.T) {
+	// Define test cases, the token spans from the start to the end position whatever its lexeme
+	testCases := []struct {
+		name          string
+		tokType       TokenType
+		start         LexerPosition
+		end           LexerPosition
+		lexeme        []rune
+		expectedToken Token
+	}{
+		{
+			name:          "single rune",
+			tokType:       LBRACE,
+			start:         LexerPosition{Line: 2, Column: 3, Offset: 7},
+			end:           LexerPosition{Line: 2, Column: 3, Offset: 7},
+			lexeme:        []rune("{"),
+			expectedToken: Token{TokType: LBRACE, Lexeme: "{", TokPos: TokenPosition{2, 3, 3, 7}},
+		},
+		{
+			name:          "lexeme w/o its quotes",
+			tokType:       STR,
+			start:         LexerPosition{Line: 1, Column: 5, Offset: 4},
+			end:           LexerPosition{Line: 1, Column: 8, Offset: 7},
+			lexeme:        []rune("ab"),
+			expectedToken: Token{TokType: STR, Lexeme: "ab", TokPos: TokenPosition{1, 5, 8, 4}},
+		},
+		{
+			name:          "multi-byte runes",
+			tokType:       STR,
+			start:         LexerPosition{Line: 1, Column: 1, Offset: 0},
+			end:           LexerPosition{Line: 1, Column: 4, Offset: 5},
+			lexeme:        []rune("éé"),
+			expectedToken: Token{TokType: STR, Lexeme: "éé", TokPos: TokenPosition{1, 1, 4, 0}},
+		},
+		{
+			name:          "EOF",
+			tokType:       EOF,
+			start:         LexerPosition{Line: 3, Column: 2, Offset: 10},
+			end:           LexerPosition{Line: 3, Column: 2, Offset: 10},
+			expectedToken: Token{TokType: EOF, Lexeme: "EOF", TokPos: TokenPosition{3, 2, 2, 10}},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			actualToken := createToken(testCase.tokType, testCase.start, testCase.end, testCase.lexeme...)
+			assertTokenEquality(t, testCase.expectedToken, actualToken)
+		})
+	}
+}
+
+func TestNumberFollowedByWhitespace(t *testing.T) {
+	// Define test cases, whitespace ends a number w/o being part of it, so ColEnd is its last digit
+	testCases := []struct {
+		input          string
+		expectedTokens []Token
+	}{
+		{
+			input: "[1\t,2]",
+			expectedTokens: []Token{
+				{TokType: LBRACKET, Lexeme: "[", TokPos: TokenPosition{1, 1, 1, 0}},
+				{TokType: NUM, Lexeme: "1", TokPos: TokenPosition{1, 2, 2, 1}},
+				{TokType: COMMA, Lexeme: ",", TokPos: TokenPosition{1, 4, 4, 3}},
+				{TokType: NUM, Lexeme: "2", TokPos: TokenPosition{1, 5, 5, 4}},
+				{TokType: RBRACKET, Lexeme: "]", TokPos: TokenPosition{1, 6, 6, 5}},
+			},
+		},
+		{
+			input: "[1\n,2]",
+			expectedTokens: []Token{
+				{TokType: LBRACKET, Lexeme: "[", TokPos: TokenPosition{1, 1, 1, 0}},
+				{TokType: NUM, Lexeme: "1", TokPos: TokenPosition{1, 2, 2, 1}},
+				{TokType: COMMA, Lexeme: ",", TokPos: TokenPosition{2, 1, 1, 3}},
+				{TokType: NUM, Lexeme: "2", TokPos: TokenPosition{2, 2, 2, 4}},
+				{TokType: RBRACKET, Lexeme: "]", TokPos: TokenPosition{2, 3, 3, 5}},
+			},
+		},
+		{
+			input: "[-1.5e3\t\r\n,2]",
+			expectedTokens: []Token{
+				{TokType: LBRACKET, Lexeme: "[", TokPos: TokenPosition{1, 1, 1, 0}},
+				{TokType: NUM, Lexeme: "-1.5e3", TokPos: TokenPosition{1, 2, 7, 1}},
+				{TokType: COMMA, Lexeme: ",", TokPos: TokenPosition{2, 1, 1, 10}},
+				{TokType: NUM, Lexeme: "2", TokPos: TokenPosition{2, 2, 2, 11}},
+				{TokType: RBRACKET, Lexeme: "]", TokPos: TokenPosition{2, 3, 3, 12}},
+			},
+		},
+		{
+			// The number is split in 2 by the tab
+			input: "[12\t34]",
+			expectedTokens: []Token{
+				{TokType: LBRACKET, Lexeme: "[", TokPos: TokenPosition{1, 1, 1, 0}},
+				{TokType: NUM, Lexeme: "12", TokPos: TokenPosition{1, 2, 3, 1}},
+				{TokType: NUM, Lexeme: "34", TokPos: TokenPosition{1, 5, 6, 4}},
+				{TokType: RBRACKET, Lexeme: "]", TokPos: TokenPosition{1, 7, 7, 6}},
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.input, func(t *testing.T) {
+			lexer := createLexer(strings.NewReader(testCase.input))
+
+			for _, expectedToken := range testCase.expectedTokens {
+				assertTokenEquality(t, expectedToken, lexer.GetNextToken())
+			}
+			if tok := lexer.GetNextToken(); tok.TokType != EOF {
+				t.Errorf("Expected EOF, got %v", tok.TokType)
+			}
+		})
+	}
+}
+
+func TestPositionsAfterIllegalToken(t *testing.T) {
+	// Define test cases, the tokens following an ILLEGAL one (up to EOF) keep their own positions
+	testCases := []struct {
+		input          string
+		opts           Options
+		expectedTokens []Token // Tokens from the ILLEGAL one onwards
+	}{
+		{
+			input: "[whaat]",
+			expectedTokens: []Token{
+				{TokType: ILLEGAL, Lexeme: "whaat", TokPos: TokenPosition{1, 2, 6, 1}},
+				{TokType: RBRACKET, Lexeme: "]", TokPos: TokenPosition{1, 7, 7, 6}},
+			},
+		},
+		{
+			input: `{"a":tru}`,
+			expectedTokens: []Token{
+				{TokType: ILLEGAL, Lexeme: "tru", TokPos: TokenPosition{1, 6, 8, 5}},
+				{TokType: RBRACE, Lexeme: "}", TokPos: TokenPosition{1, 9, 9, 8}},
+			},
+		},
+		{
+			input: "[1.2.3,2]",
+			expectedTokens: []Token{
+				{TokType: ILLEGAL, Lexeme: "1.2.3", TokPos: TokenPosition{1, 2, 6, 1}},
+				{TokType: COMMA, Lexeme: ",", TokPos: TokenPosition{1, 7, 7, 6}},
+				{TokType: NUM, Lexeme: "2", TokPos: TokenPosition{1, 8, 8, 7}},
+				{TokType: RBRACKET, Lexeme: "]", TokPos: TokenPosition{1, 9, 9, 8}},
+			},
+		},
+		{
+			input: "[12abc, 3]",
+			expectedTokens: []Token{
+				{TokType: ILLEGAL, Lexeme: "12abc", TokPos: TokenPosition{1, 2, 6, 1}},
+				{TokType: COMMA, Lexeme: ",", TokPos: TokenPosition{1, 7, 7, 6}},
+				{TokType: NUM, Lexeme: "3", TokPos: TokenPosition{1, 9, 9, 8}},
+				{TokType: RBRACKET, Lexeme: "]", TokPos: TokenPosition{1, 10, 10, 9}},
+			},
+		},
+		{
+			input: "[tru3,1]",
+			expectedTokens: []Token{
+				{TokType: ILLEGAL, Lexeme: "tru", TokPos: TokenPosition{1, 2, 4, 1}},
+				{TokType: NUM, Lexeme: "3", TokPos: TokenPosition{1, 5, 5, 4}},
+				{TokType: COMMA, Lexeme: ",", TokPos: TokenPosition{1, 6, 6, 5}},
+				{TokType: NUM, Lexeme: "1", TokPos: TokenPosition{1, 7, 7, 6}},
+				{TokType: RBRACKET, Lexeme: "]", TokPos: TokenPosition{1, 8, 8, 7}},
+			},
+		},
+		{
+			input: "[-]",
+			expectedTokens: []Token{
+				{TokType: ILLEGAL, Lexeme: "-", TokPos: TokenPosition{1, 2, 2, 1}},
+				{TokType: RBRACKET, Lexeme: "]", TokPos: TokenPosition{1, 3, 3, 2}},
+			},
+		},
+		{
+			input: "[é@,1]",
+			expectedTokens: []Token{
+				{TokType: ILLEGAL, Lexeme: "é", TokPos: TokenPosition{1, 2, 2, 1}},
+				{TokType: ILLEGAL, Lexeme: "@", TokPos: TokenPosition{1, 3, 3, 3}},
+				{TokType: COMMA, Lexeme: ",", TokPos: TokenPosition{1, 4, 4, 4}},
+				{TokType: NUM, Lexeme: "1", TokPos: TokenPosition{1, 5, 5, 5}},
+				{TokType: RBRACKET, Lexeme: "]", TokPos: TokenPosition{1, 6, 6, 6}},
+			},
+		},
+		{
+			input: "[whaat\r\n,1]",
+			expectedTokens: []Token{
+				{TokType: ILLEGAL, Lexeme: "whaat", TokPos: TokenPosition{1, 2, 6, 1}},
+				{TokType: COMMA, Lexeme: ",", TokPos: TokenPosition{2, 1, 1, 8}},
+				{TokType: NUM, Lexeme: "1", TokPos: TokenPosition{2, 2, 2, 9}},
+				{TokType: RBRACKET, Lexeme: "]", TokPos: TokenPosition{2, 3, 3, 10}},
+			},
+		},
+		{
+			input: "[1.2.3\n]",
+			expectedTokens: []Token{
+				{TokType: ILLEGAL, Lexeme: "1.2.3", TokPos: TokenPosition{1, 2, 6, 1}},
+				{TokType: RBRACKET, Lexeme: "]", TokPos: TokenPosition{2, 1, 1, 7}},
+			},
+		},
+		{
+			input: "[1111111111,2]",
+			opts:  Options{MaxNumberLength: 5},
+			expectedTokens: []Token{
+				{TokType: ILLEGAL, Lexeme: "1", TokPos: TokenPosition{1, 2, 11, 1}},
+				{TokType: COMMA, Lexeme: ",", TokPos: TokenPosition{1, 12, 12, 11}},
+				{TokType: NUM, Lexeme: "2", TokPos: TokenPosition{1, 13, 13, 12}},
+				{TokType: RBRACKET, Lexeme: "]", TokPos: TokenPosition{1, 14, 14, 13}},
+			},
+		},
+		{
+			input: "[whaat  \n  ,1]",
+			opts:  Options{PreserveTrivia: true},
+			expectedTokens: []Token{
+				{TokType: ILLEGAL, Lexeme: "whaat", TokPos: TokenPosition{1, 2, 6, 1}},
+				{TokType: COMMA, Lexeme: ",", TokPos: TokenPosition{2, 3, 3, 11}},
+				{TokType: NUM, Lexeme: "1", TokPos: TokenPosition{2, 4, 4, 12}},
+				{TokType: RBRACKET, Lexeme: "]", TokPos: TokenPosition{2, 5, 5, 13}},
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.input, func(t *testing.T) {
+			lexer := NewLexer(strings.NewReader(testCase.input), testCase.opts)
+
+			// Skip the tokens before the 1st ILLEGAL one
+			tok, err := lexer.Scan()
+			for tok.TokType != ILLEGAL && tok.TokType != EOF {
+				tok, err = lexer.Scan()
+			}
+			var lexErr LexError
+			if !errors.As(err, &lexErr) {
+				t.Fatalf("Expected a LexError for the ILLEGAL token, got %v", err)
+			}
+
+			// Scanning resumes after the error, each token positioned where it is
+			for i, expectedToken := range testCase.expectedTokens {
+				if i > 0 {
+					tok, _ = lexer.Scan()
+				}
+				assertTokenEquality(t, expectedToken, tok)
+			}
+			if tok, _ := lexer.Scan(); tok.TokType != EOF {
+				t.Errorf("Expected EOF, got %v", tok.TokType)
+			}
+		})
+	}
+}
+
+func TestRuneAndLineCount(t *testing.T) {
+	// Define test cases
+	testCases := []struct {
+		name          string
+		input         string
+		expectedRunes int
+		expectedLines int
+	}{
+		{name: "empty input", input: "", expectedRunes: 0, expectedLines: 0},
+		{name: "single line", input: `{"é": [1, 2]}`, expectedRunes: 13, expectedLines: 1},
+		{name: "final newline", input: "{\n}\n", expectedRunes: 4, expectedLines: 2},
+		{name: "no final newline", input: "{\n}", expectedRunes: 3, expectedLines: 2},
+		{name: "blank lines at the end", input: "[]\n\n\n", expectedRunes: 5, expectedLines: 3},
+		// Runes read again after backing up (ex. the one ending a number) are counted once
+		{name: "numbers", input: "[12,\n3.5]", expectedRunes: 9, expectedLines: 2},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			lexer := NewLexer(strings.NewReader(testCase.input), Options{})
+			for tok := lexer.GetNextToken(); tok.TokType != EOF; tok = lexer.GetNextToken() {
+			}
+
+			if lexer.RuneCount != testCase.expectedRunes {
+				t.Errorf("Expected %d runes, got %d", testCase.expectedRunes, lexer.RuneCount)
+			}
+			if lines := lexer.LineCount(); lines != testCase.expectedLines {
+				t.Errorf("Expected %d lines, got %d", testCase.expectedLines, lines)
+			}
+		})
+	}
+}
+
+func TestMaxLines(t *testing.T) {
+	// Define test cases
+	testCases := []struct {
+		name             string
+		input            string
+		maxLines         int
+		expectedTokens   int
+		expectedErrorMsg string // Empty if no error is expected
+	}{
+		{name: "no limit", input: "[\n1,\n2\n]", expectedTokens: 5},
+		{name: "at the limit", input: "[\n1,\n2\n]", maxLines: 4, expectedTokens: 5},
+		{name: "final newline at the limit", input: "[\n1\n]\n", maxLines: 3, expectedTokens: 3},
+		// The newline ending a number is read again after backing up
+		{name: "number ending the last line", input: "12\n", maxLines: 1, expectedTokens: 1},
+		{name: "over the limit", input: "[\n1,\n2\n]", maxLines: 3, expectedTokens: 4, expectedErrorMsg: "too many lines: line 4 exceeds the maximum of 3 lines"},
+		{name: "blank line over the limit", input: "[\n1]\n\n", maxLines: 2, expectedTokens: 3, expectedErrorMsg: "too many lines: line 3 exceeds the maximum of 2 lines"},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			lexer := NewLexer(strings.NewReader(testCase.input), Options{MaxLines: testCase.maxLines})
+			tokens, err := lexer.ReadAll(context.Background())
+
+			if len(tokens) != testCase.expectedTokens {
+				t.Errorf("Expected %d tokens, got %d: %v", testCase.expectedTokens, len(tokens), tokens)
+			}
+			if testCase.expectedErrorMsg == "" {
+				if err != nil {
+					t.Errorf("Expected no error, got %v", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != testCase.expectedErrorMsg {
+				t.Errorf("Expected error %q, got %v", testCase.expectedErrorMsg, err)
+			}
+			if !errors.Is(err, ErrTooManyLines) {
+				t.Errorf("Expected the error to wrap %v, got %v", ErrTooManyLines, err)
+			}
+		})
+	}
+}
+
+func TestMaxLineLength(t *testing.T) {
+	// Define test cases
+	testCases := []struct {
+		name             string
+		input            string
+		maxLineLength    int
+		expectedLongLine LexerPosition
+	}{
+		{name: "no limit", input: `{"a": [1, 2, 3]}`},
+		{name: "under the limit", input: "{\n  \"a\": 1\n}\n", maxLineLength: 8},
+		{name: "at the limit w/ CRLF", input: "{\r\n  \"a\": 1\r\n}", maxLineLength: 8},
+		{name: "minified", input: `{"a": [1, 2, 3]}`, maxLineLength: 8, expectedLongLine: LexerPosition{Line: 1, Column: 9, Offset: 8}},
+		// Only the first long line is recorded
+		{name: "several long lines", input: "[\n  \"abcde\",\n  \"abcdefgh\",\n  \"abcdefghij\"\n]", maxLineLength: 10, expectedLongLine: LexerPosition{Line: 3, Column: 11, Offset: 23}},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			lxr := NewLexer(strings.NewReader(testCase.input), Options{MaxLineLength: testCase.maxLineLength})
+			tokens, err := lxr.ReadAll(context.Background())
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+
+			// Lexing goes on past the long line
+			if last := tokens[len(tokens)-1]; last.TokType != RBRACE && last.TokType != RBRACKET {
+				t.Errorf("Expected the last token to close the document, got %v", last.TokType)
+			}
+			if lxr.LongLine != testCase.expectedLongLine {
+				t.Errorf("Expected long line at %+v, got %+v", testCase.expectedLongLine, lxr.LongLine)
+			}
+		})
+	}
+}
+
+func TestScan(t *testing.T) {
+	// Define test cases
+	testCases := []struct {
+		input            string
+		expectedTypes    []TokenType // Types of the tokens scanned up to (and including) the erroneous one
+		expectedErrorMsg string      // Empty if the input is lexically valid
+	}{
+		{input: `{"a": [1, true, null]}`, expectedTypes: []TokenType{LBRACE, STR, COLON, LBRACKET, NUM, COMMA, TRUE, COMMA, NULL, RBRACKET, RBRACE, EOF}},
+		{input: `[1, whaat]`, expectedTypes: []TokenType{LBRACKET, NUM, COMMA, ILLEGAL}, expectedErrorMsg: "Unexpected 'whaat' at line 1, Column 5:9"},
+		{input: "[\n 123abc]", expectedTypes: []TokenType{LBRACKET, ILLEGAL}, expectedErrorMsg: "Unexpected '123abc', invalid number: unexpected character 'a' at line 2, Column 2:7"},
+		{input: `"abc`, expectedTypes: []TokenType{ILLEGAL}, expectedErrorMsg: "Unexpected '\"', unterminated string at line 1, Column 1:1"},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.input, func(t *testing.T) {
+			lexer := createLexer(strings.NewReader(testCase.input))
+
+			// Scan until the first error or EOF
+			var actualTypes []TokenType
+			var err error
+			for {
+				var tok Token
+				tok, err = lexer.Scan()
+				actualTypes = append(actualTypes, tok.TokType)
+				if err != nil || tok.TokType == EOF {
+					break
+				}
+			}
+
+			if len(actualTypes) != len(testCase.expectedTypes) {
+				t.Fatalf("Expected token types %v, got %v", testCase.expectedTypes, actualTypes)
+			}
+			for i := range actualTypes {
+				if actualTypes[i] != testCase.expectedTypes[i] {
+					t.Errorf("Expected token types %v, got %v", testCase.expectedTypes, actualTypes)
+					break
+				}
+			}
+
+			if testCase.expectedErrorMsg == "" {
+				if err != nil {
+					t.Errorf("Expected no error, got %v", err)
+				}
+			} else if err == nil || err.Error() != testCase.expectedErrorMsg {
+				t.Errorf("Expected error %q, got %v", testCase.expectedErrorMsg, err)
+			}
+		})
+	}
+}
+
+func TestScanLexError(t *testing.T) {
+	opts := Options{MaxStringLength: 3, MaxNumberLength: 5, InvalidUTF8: UTF8Reject}
+
+	// Define test cases
+	testCases := []struct {
+		input    string
+		expected LexError
+	}{
+		{input: "[1, whaat]", expected: LexError{Message: "Unexpected 'whaat'", Lexeme: "whaat", Pos: TokenPosition{1, 5, 9, 4}}},
+		{
+			input: "tru",
+			expected: LexError{
+				Message: "Unexpected 'tru', unknown literal 'tru'; did you mean 'true'?",
+				Lexeme:  "tru",
+				Pos:     TokenPosition{1, 1, 3, 0},
+				Err:     errors.New("unknown literal 'tru'; did you mean 'true'?"),
+			},
+		},
+		{input: "\n @", expected: LexError{Message: "Unexpected '@'", Lexeme: "@", Pos: TokenPosition{2, 2, 2, 2}}},
+		{input: "/", expected: LexError{Message: "Unexpected '/'", Lexeme: "/", Pos: TokenPosition{1, 1, 1, 0}}},
+		{input: `"abc`, expected: LexError{Message: "Unexpected '\"', unterminated string", Lexeme: `"`, Pos: TokenPosition{1, 1, 1, 0}, Err: ErrUnterminatedString}},
+		{input: "01", expected: LexError{Message: "Unexpected '01', invalid number", Lexeme: "01", Pos: TokenPosition{1, 1, 2, 0}, Err: ErrInvalidNumber}},
+		{input: "[-]", expected: LexError{Message: "Unexpected '-', invalid number", Lexeme: "-", Pos: TokenPosition{1, 2, 2, 1}, Err: ErrInvalidNumber}},
+		{
+			input:    `"abcdef"`,
+			expected: LexError{Message: "Unexpected '\"', string too long: exceeds the maximum length of 3 characters", Lexeme: `"`, Pos: TokenPosition{1, 1, 8, 0}, Err: ErrStringTooLong},
+		},
+		{
+			input:    "1234567",
+			expected: LexError{Message: "Unexpected '1', number literal too long: exceeds the maximum length of 5 characters", Lexeme: "1", Pos: TokenPosition{1, 1, 7, 0}, Err: ErrNumberTooLong},
+		},
+		{input: "\"\xff\"", expected: LexError{Message: "Unexpected '\uFFFD', invalid UTF-8", Lexeme: "\uFFFD", Pos: TokenPosition{1, 1, 3, 0}, Err: ErrInvalidUTF8}},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.input, func(t *testing.T) {
+			lexer := NewLexer(strings.NewReader(testCase.input), opts)
+
+			// Scan until the first error
+			var err error
+			for tok := (Token{}); err == nil && tok.TokType != EOF; {
+				tok, err = lexer.Scan()
+			}
+
+			var lexErr LexError
+			if !errors.As(err, &lexErr) {
+				t.Fatalf("Expected a LexError, got %#v", err)
+			}
+			if lexErr.Message != testCase.expected.Message || lexErr.Lexeme != testCase.expected.Lexeme || lexErr.Pos != testCase.expected.Pos {
+				t.Errorf("Expected %+v, got %+v", testCase.expected, lexErr)
+			}
+			if !errors.Is(lexErr.Err, testCase.expected.Err) && fmt.Sprint(lexErr.Err) != fmt.Sprint(testCase.expected.Err) {
+				t.Errorf("Expected the reason %v, got %v", testCase.expected.Err, lexErr.Err)
+			}
+			// The sentinel reasons are wrapped
+			if lexErr.Err != nil && !errors.Is(err, lexErr.Err) {
+				t.Errorf("Expected the error to wrap %v, got %v", lexErr.Err, err)
+			}
+		})
+	}
+}
+
+func TestTokenize(t *testing.T) {
+	// Define test cases
+	testCases := []struct {
+		name             string
+		input            []byte
+		opts             Options
+		expectedTypes    []TokenType
+		expectedErrorMsg string // Empty if no error is expected
+	}{
+		{name: "valid", input: []byte(`{"a": [1, true]}`), expectedTypes: []TokenType{LBRACE, STR, COLON, LBRACKET, NUM, COMMA, TRUE, RBRACKET, RBRACE}},
+		{name: "empty", input: nil, expectedTypes: nil},
+		// The tokens preceding the first ILLEGAL token are returned along w/ the error
+		{name: "illegal", input: []byte(`[1, whaat, @]`), expectedTypes: []TokenType{LBRACKET, NUM, COMMA}, expectedErrorMsg: "Unexpected 'whaat' at line 1, Column 5:9"},
+		{
+			name:             "invalid UTF-8",
+			input:            []byte("[\"\xff\"]"),
+			opts:             Options{InvalidUTF8: UTF8Reject},
+			expectedTypes:    []TokenType{LBRACKET},
+			expectedErrorMsg: "Unexpected '�', invalid UTF-8 at line 1, Column 2:4",
+		},
+		{
+			name:          "illegal tokens kept",
+			input:         []byte(`[1, whaat, @]`),
+			opts:          Options{KeepIllegal: true},
+			expectedTypes: []TokenType{LBRACKET, NUM, COMMA, ILLEGAL, COMMA, ILLEGAL, RBRACKET},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			tokens, err := TokenizeWithOptions(testCase.input, testCase.opts)
+
+			var actualTypes []TokenType
+			for _, tok := range tokens {
+				actualTypes = append(actualTypes, tok.TokType)
+			}
+			if !reflect.DeepEqual(actualTypes, testCase.expectedTypes) {
+				t.Errorf("Expected tokens %v, got %v", testCase.expectedTypes, actualTypes)
+			}
+
+			if testCase.expectedErrorMsg == "" {
+				if err != nil {
+					t.Errorf("Expected no error, got %v", err)
+				}
+				return
+			}
+			var lexErr LexError
+			if !errors.As(err, &lexErr) {
+				t.Fatalf("Expected a LexError, got %#v", err)
+			}
+			if lexErr.Error() != testCase.expectedErrorMsg {
+				t.Errorf("Expected error %q, got %q", testCase.expectedErrorMsg, lexErr.Error())
+			}
+		})
+	}
+
+	// Tokenize uses the default options
+	tokens, err := Tokenize([]byte(`[null]`))
+	if err != nil || len(tokens) != 3 || tokens[1].TokType != NULL {
+		t.Errorf("Expected the tokens of [null], got %v (%v)", tokens, err)
+	}
+}
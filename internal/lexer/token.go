@@ -1,6 +1,9 @@
 package lexer
 
-import "fmt"
+import (
+	"fmt"
+	"unicode/utf8"
+)
 
 // Define type alias for the type of the token (can later change this to a new type like string)
 type TokenType int
@@ -29,20 +32,132 @@ const (
 	TRUE  // true
 	FALSE // false
 	NULL  // null
+
+	// Comments (only produced when the Lexer's AllowComments option is set)
+	COMMENT // "// ..." or "/* ... */"
+
+	// Whitespace (only produced when the Lexer's EmitWhitespace option is set)
+	WS      // Run of spaces, tabs & carriage returns
+	NEWLINE // "\n"
 )
 
-// Define Position Struct for token positional context
+// tokenTypeNames holds the name of each token type, indexed by the type
+var tokenTypeNames = [...]string{
+	ILLEGAL:  "ILLEGAL",
+	EOF:      "EOF",
+	LBRACE:   "LBRACE",
+	RBRACE:   "RBRACE",
+	LBRACKET: "LBRACKET",
+	RBRACKET: "RBRACKET",
+	COMMA:    "COMMA",
+	COLON:    "COLON",
+	STR:      "STR",
+	NUM:      "NUM",
+	TRUE:     "TRUE",
+	FALSE:    "FALSE",
+	NULL:     "NULL",
+	COMMENT:  "COMMENT",
+	WS:       "WS",
+	NEWLINE:  "NEWLINE",
+}
+
+// String returns the name of the token type (ex. "NUM")
+func (t TokenType) String() string {
+	if t < 0 || int(t) >= len(tokenTypeNames) {
+		return fmt.Sprintf("TokenType(%d)", int(t))
+	}
+	return tokenTypeNames[t]
+}
+
+// IsValue reports whether a token of this type starts a JSON value (a literal, an object or an array)
+func (t TokenType) IsValue() bool {
+	switch t {
+	case STR, NUM, TRUE, FALSE, NULL, LBRACE, LBRACKET:
+		return true
+	}
+	return false
+}
+
+// IsTrivia reports whether a token of this type carries no meaning for the structure of the document
+// (comments & whitespace)
+func (t TokenType) IsTrivia() bool {
+	switch t {
+	case COMMENT, WS, NEWLINE:
+		return true
+	}
+	return false
+}
+
+// IsStructural reports whether a token of this type is one of the structural characters "{}[],:"
+func (t TokenType) IsStructural() bool {
+	switch t {
+	case LBRACE, RBRACE, LBRACKET, RBRACKET, COMMA, COLON:
+		return true
+	}
+	return false
+}
+
+// Define Position Struct for token positional context.
+// For STR tokens, the position includes the surrounding quotes:
+// ColStart points at the opening quote and ColEnd at the closing quote.
 type TokenPosition struct {
 	Line     int // Line number Token is found on
 	ColStart int // Column start position of Token
 	ColEnd   int // Column end position of Token
+	Offset   int // Byte offset of the start of the Token within the input
+}
+
+// OffsetToPosition converts a byte offset within data to the position of the rune starting there,
+// using the same conventions as the lexer: lines & columns start at 1 and a column is a rune, not a byte.
+// This bridges from errors which only have an offset (ex. json.SyntaxError.Offset).
+//
+// An offset within a multi-byte rune maps to the start of that rune. Offsets are clamped to the input,
+// so an offset at or past its end maps to just past the last rune.
+func OffsetToPosition(data []byte, offset int) TokenPosition {
+	offset = max(0, min(offset, len(data)))
+
+	line, column, start := 1, 1, 0
+	for start < offset {
+		r, size := utf8.DecodeRune(data[start:])
+		if start+size > offset {
+			break
+		}
+		if r == '\n' {
+			line++
+			column = 1
+		} else {
+			column++
+		}
+		start += size
+	}
+
+	return TokenPosition{Line: line, ColStart: column, ColEnd: column, Offset: start}
 }
 
 // Define the Token Struct
 type Token struct {
 	TokType TokenType
-	Lexeme  string // The literal which Token represents
+	Lexeme  string // The literal which Token represents (excludes the quotes for STR tokens)
 	TokPos  TokenPosition
+	Err     error // Why the lexeme is ILLEGAL, when the lexer can tell (nil otherwise)
+
+	// Whitespace surrounding the Token, only populated when the Lexer's PreserveTrivia option is set.
+	// Trailing trivia runs up to (and including) the end of the Token's line, the remainder is leading trivia of the next Token.
+	LeadingTrivia  string
+	TrailingTrivia string
+}
+
+// EndOffset returns the byte offset just past the end of the Token within the input
+func (t Token) EndOffset() int {
+	if t.TokType == STR {
+		return t.TokPos.Offset + len(t.Lexeme) + 2 // Account for the surrounding quotes
+	}
+	return t.TokPos.Offset + len(t.Lexeme)
+}
+
+// Equal reports whether both Tokens have the same type, lexeme & position (the error & trivia are ignored)
+func (t Token) Equal(other Token) bool {
+	return t.TokType == other.TokType && t.Lexeme == other.Lexeme && t.TokPos == other.TokPos
 }
 
 // String returns a pretty-printed string representation of the Token.
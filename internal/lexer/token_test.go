@@ -0,0 +1,128 @@
+package lexer
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestTokenTypeClassification(t *testing.T) {
+	// Define test cases covering every token type
+	testCases := []struct {
+		tokType            TokenType
+		expectedValue      bool
+		expectedStructural bool
+		expectedTrivia     bool
+	}{
+		{tokType: ILLEGAL},
+		{tokType: EOF},
+		{tokType: LBRACE, expectedValue: true, expectedStructural: true},
+		{tokType: RBRACE, expectedStructural: true},
+		{tokType: LBRACKET, expectedValue: true, expectedStructural: true},
+		{tokType: RBRACKET, expectedStructural: true},
+		{tokType: COMMA, expectedStructural: true},
+		{tokType: COLON, expectedStructural: true},
+		{tokType: STR, expectedValue: true},
+		{tokType: NUM, expectedValue: true},
+		{tokType: TRUE, expectedValue: true},
+		{tokType: FALSE, expectedValue: true},
+		{tokType: NULL, expectedValue: true},
+		{tokType: COMMENT, expectedTrivia: true},
+		{tokType: WS, expectedTrivia: true},
+		{tokType: NEWLINE, expectedTrivia: true},
+	}
+
+	for _, testCase := range testCases {
+		if actual := testCase.tokType.IsValue(); actual != testCase.expectedValue {
+			t.Errorf("Expected IsValue() of token type %v to be %v, got %v", testCase.tokType, testCase.expectedValue, actual)
+		}
+		if actual := testCase.tokType.IsStructural(); actual != testCase.expectedStructural {
+			t.Errorf("Expected IsStructural() of token type %v to be %v, got %v", testCase.tokType, testCase.expectedStructural, actual)
+		}
+		if actual := testCase.tokType.IsTrivia(); actual != testCase.expectedTrivia {
+			t.Errorf("Expected IsTrivia() of token type %v to be %v, got %v", testCase.tokType, testCase.expectedTrivia, actual)
+		}
+	}
+}
+
+func TestTokenTypeString(t *testing.T) {
+	if NUM.String() != "NUM" || NEWLINE.String() != "NEWLINE" {
+		t.Errorf("Expected the names of the constants, got %v & %v", NUM, NEWLINE)
+	}
+	if actual := TokenType(100).String(); actual != "TokenType(100)" {
+		t.Errorf("Expected TokenType(100) for an unknown type, got %s", actual)
+	}
+}
+
+func TestTokenEqual(t *testing.T) {
+	token := Token{TokType: STR, Lexeme: "a", TokPos: TokenPosition{Line: 1, ColStart: 2, ColEnd: 4, Offset: 1}}
+
+	// Define test cases
+	testCases := []struct {
+		name     string
+		other    Token
+		expected bool
+	}{
+		{name: "same token", other: token, expected: true},
+		{name: "different trivia", other: Token{TokType: STR, Lexeme: "a", TokPos: token.TokPos, LeadingTrivia: " "}, expected: true},
+		{name: "different type", other: Token{TokType: NUM, Lexeme: "a", TokPos: token.TokPos}},
+		{name: "different lexeme", other: Token{TokType: STR, Lexeme: "b", TokPos: token.TokPos}},
+		{name: "different line", other: Token{TokType: STR, Lexeme: "a", TokPos: TokenPosition{Line: 2, ColStart: 2, ColEnd: 4, Offset: 1}}},
+		{name: "different ColEnd", other: Token{TokType: STR, Lexeme: "a", TokPos: TokenPosition{Line: 1, ColStart: 2, ColEnd: 5, Offset: 1}}},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			if actual := token.Equal(testCase.other); actual != testCase.expected {
+				t.Errorf("Expected Equal() to be %v, got %v", testCase.expected, actual)
+			}
+			if actual := testCase.other.Equal(token); actual != testCase.expected {
+				t.Errorf("Expected Equal() to be symmetric (%v), got %v", testCase.expected, actual)
+			}
+		})
+	}
+}
+
+func TestOffsetToPosition(t *testing.T) {
+	data := []byte("{\n  \"é\": \"日本\",\r\n  \"b\": 1\n}")
+
+	// Define test cases
+	testCases := []struct {
+		name     string
+		offset   int
+		expected TokenPosition
+	}{
+		{name: "start", offset: 0, expected: TokenPosition{Line: 1, ColStart: 1, ColEnd: 1, Offset: 0}},
+		{name: "newline", offset: 1, expected: TokenPosition{Line: 1, ColStart: 2, ColEnd: 2, Offset: 1}},
+		{name: "line start", offset: 2, expected: TokenPosition{Line: 2, ColStart: 1, ColEnd: 1, Offset: 2}},
+		{name: "mid-line", offset: 4, expected: TokenPosition{Line: 2, ColStart: 3, ColEnd: 3, Offset: 4}},
+		{name: "after a 2-byte rune", offset: 7, expected: TokenPosition{Line: 2, ColStart: 5, ColEnd: 5, Offset: 7}},
+		{name: "within a 2-byte rune", offset: 6, expected: TokenPosition{Line: 2, ColStart: 4, ColEnd: 4, Offset: 5}},
+		{name: "after 3-byte runes", offset: 17, expected: TokenPosition{Line: 2, ColStart: 11, ColEnd: 11, Offset: 17}},
+		{name: "within a 3-byte rune", offset: 13, expected: TokenPosition{Line: 2, ColStart: 9, ColEnd: 9, Offset: 11}},
+		// A carriage return is a column, like in the lexer
+		{name: "after a carriage return", offset: 19, expected: TokenPosition{Line: 2, ColStart: 13, ColEnd: 13, Offset: 19}},
+		{name: "line start after CRLF", offset: 21, expected: TokenPosition{Line: 3, ColStart: 1, ColEnd: 1, Offset: 21}},
+		{name: "end", offset: 31, expected: TokenPosition{Line: 4, ColStart: 2, ColEnd: 2, Offset: 31}},
+		{name: "past the end", offset: 100, expected: TokenPosition{Line: 4, ColStart: 2, ColEnd: 2, Offset: 31}},
+		{name: "negative", offset: -1, expected: TokenPosition{Line: 1, ColStart: 1, ColEnd: 1, Offset: 0}},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			if actual := OffsetToPosition(data, testCase.offset); actual != testCase.expected {
+				t.Errorf("Expected position %+v, got %+v", testCase.expected, actual)
+			}
+		})
+	}
+}
+
+func TestOffsetToPositionMatchesLexer(t *testing.T) {
+	// The position of each token's offset is the token's start position
+	data := []byte("{\"é\": [1,\n\t\"日本\", true]}")
+	for _, tok := range LexReader(bytes.NewReader(data)) {
+		pos := OffsetToPosition(data, tok.TokPos.Offset)
+		if pos.Line != tok.TokPos.Line || pos.ColStart != tok.TokPos.ColStart || pos.Offset != tok.TokPos.Offset {
+			t.Errorf("Expected position %+v of %v, got %+v", tok.TokPos, tok.TokType, pos)
+		}
+	}
+}
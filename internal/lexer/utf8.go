@@ -0,0 +1,34 @@
+package lexer
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrInvalidUTF8 is the Err of ILLEGAL tokens containing invalid UTF-8 bytes w/ the UTF8Reject policy
+var ErrInvalidUTF8 = errors.New("invalid UTF-8")
+
+// UTF8Policy determines how the Lexer handles bytes which aren't valid UTF-8
+type UTF8Policy string
+
+const (
+	UTF8Accept  UTF8Policy = "accept"  // Invalid bytes are silently decoded as U+FFFD
+	UTF8Reject  UTF8Policy = "reject"  // Tokens containing invalid bytes are ILLEGAL, w/ ErrInvalidUTF8
+	UTF8Replace UTF8Policy = "replace" // Invalid bytes are decoded as U+FFFD & recorded in Lexer.Replacements
+
+	// DefaultUTF8Policy is the policy used when none is specified
+	DefaultUTF8Policy = UTF8Accept
+)
+
+// ParseUTF8Policy returns the UTF8Policy named by name (ex. "reject").
+// An empty name selects DefaultUTF8Policy.
+func ParseUTF8Policy(name string) (UTF8Policy, error) {
+	switch policy := UTF8Policy(name); policy {
+	case "":
+		return DefaultUTF8Policy, nil
+	case UTF8Accept, UTF8Reject, UTF8Replace:
+		return policy, nil
+	default:
+		return "", fmt.Errorf("unknown UTF-8 policy %q, expected %q, %q or %q", name, UTF8Accept, UTF8Reject, UTF8Replace)
+	}
+}
@@ -0,0 +1,90 @@
+package lexer
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestInvalidUTF8Policies(t *testing.T) {
+	// The string contains an invalid byte, followed by a number which is only looked ahead by the number before it
+	input := "[\"a\xffb\", 1]"
+
+	// Define test cases
+	testCases := []struct {
+		policy               UTF8Policy
+		expectedStrToken     Token
+		expectedErr          error
+		expectedReplacements []LexerPosition
+	}{
+		{policy: "", expectedStrToken: Token{TokType: STR, Lexeme: "a�b", TokPos: TokenPosition{1, 2, 6, 1}}},
+		{policy: UTF8Accept, expectedStrToken: Token{TokType: STR, Lexeme: "a�b", TokPos: TokenPosition{1, 2, 6, 1}}},
+		{
+			policy:           UTF8Reject,
+			expectedStrToken: Token{TokType: ILLEGAL, Lexeme: "a�b", TokPos: TokenPosition{1, 2, 6, 1}},
+			expectedErr:      ErrInvalidUTF8,
+		},
+		{
+			policy:               UTF8Replace,
+			expectedStrToken:     Token{TokType: STR, Lexeme: "a�b", TokPos: TokenPosition{1, 2, 6, 1}},
+			expectedReplacements: []LexerPosition{{Line: 1, Column: 4, Offset: 3}},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(string(testCase.policy), func(t *testing.T) {
+			lxr := NewLexer(strings.NewReader(input), Options{InvalidUTF8: testCase.policy})
+			tokens, err := lxr.ReadAll(context.Background())
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+
+			assertTokenEquality(t, testCase.expectedStrToken, tokens[1])
+			if !errors.Is(tokens[1].Err, testCase.expectedErr) {
+				t.Errorf("Expected error %v, got %v", testCase.expectedErr, tokens[1].Err)
+			}
+
+			// The tokens after the invalid byte are unaffected
+			for _, tok := range tokens[2:] {
+				if tok.TokType == ILLEGAL {
+					t.Errorf("Expected only the string to be affected, got %+v", tok)
+				}
+			}
+
+			if len(lxr.Replacements) != len(testCase.expectedReplacements) {
+				t.Fatalf("Expected replacements %v, got %v", testCase.expectedReplacements, lxr.Replacements)
+			}
+			for i, expected := range testCase.expectedReplacements {
+				if lxr.Replacements[i] != expected {
+					t.Errorf("Expected replacement at %+v, got %+v", expected, lxr.Replacements[i])
+				}
+			}
+		})
+	}
+}
+
+func TestInvalidUTF8LookAhead(t *testing.T) {
+	// The invalid byte directly following the number is looked ahead, then read again as its own token
+	lxr := NewLexer(strings.NewReader("1\xff"), Options{InvalidUTF8: UTF8Reject})
+	tokens, err := lxr.ReadAll(context.Background())
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(tokens) != 2 || tokens[0].TokType != NUM || tokens[1].TokType != ILLEGAL || !errors.Is(tokens[1].Err, ErrInvalidUTF8) {
+		t.Errorf("Expected a NUM & an ILLEGAL invalid UTF-8 token, got %+v", tokens)
+	}
+}
+
+func TestParseUTF8Policy(t *testing.T) {
+	for name, expected := range map[string]UTF8Policy{"": DefaultUTF8Policy, "accept": UTF8Accept, "reject": UTF8Reject, "replace": UTF8Replace} {
+		if actual, err := ParseUTF8Policy(name); err != nil || actual != expected {
+			t.Errorf("Expected policy %q for %q, got %q (%v)", expected, name, actual, err)
+		}
+	}
+
+	if _, err := ParseUTF8Policy("ignore"); err == nil {
+		t.Errorf("Expected an error for an unknown policy")
+	}
+}
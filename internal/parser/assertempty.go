@@ -0,0 +1,35 @@
+package parser
+
+import "fmt"
+
+// RuleAssertEmpty is the name of the lint rule reporting values which must be empty, but aren't
+const RuleAssertEmpty = "assert-empty"
+
+// CheckAssertEmpty returns an error for each path (as accepted by Lookup) whose value isn't an empty object or array,
+// positioned at the value & reporting its contents. A path w/o a value passes, as an absent section is blank as well.
+func CheckAssertEmpty(node *ASTNode, paths []string) []ParseError {
+	var errs []ParseError
+	for _, path := range paths {
+		if _, err := parsePath(path); err != nil {
+			errs = append(errs, ParseError{Severity: SeverityError, Rule: RuleAssertEmpty, Message: err.Error(), Pos: node.Pos})
+			continue
+		}
+		value, err := Lookup(node, path)
+		if err != nil {
+			continue
+		}
+
+		if (value.Type == "Object" || value.Type == "Array") && len(value.Children) == 0 {
+			continue
+		}
+		errs = append(errs, ParseError{
+			Severity: SeverityError,
+			Rule:     RuleAssertEmpty,
+			Message:  fmt.Sprintf("Value at '%s' must be an empty object or array, got %s", path, compactJSON(value)),
+			Pos:      value.Pos,
+			Path:     rootedPath(path),
+		})
+	}
+
+	return errs
+}
@@ -0,0 +1,66 @@
+package parser
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCheckAssertEmpty(t *testing.T) {
+	input := "{\"overrides\": {},\n \"flags\": [], \"env\": {\"debug\": true}, \"hosts\": [\"a\"], \"level\": 3}"
+
+	// Define test cases
+	testCases := []struct {
+		name           string
+		paths          []string
+		expectedErrors []string
+		expectedPaths  []string
+	}{
+		{name: "no paths"},
+		{name: "empty object", paths: []string{"overrides"}},
+		{name: "empty array", paths: []string{"$.flags"}},
+		{name: "absent value", paths: []string{"secrets", "env.verbose", "hosts[3]"}},
+		{
+			name:           "non-empty values",
+			paths:          []string{"env", "hosts", "overrides"},
+			expectedErrors: []string{"Value at 'env' must be an empty object or array, got {\"debug\":true} at line 2, Column 22:22", "Value at 'hosts' must be an empty object or array, got [\"a\"] at line 2, Column 48:48"},
+			expectedPaths:  []string{"$.env", "$.hosts"},
+		},
+		{
+			name:           "scalar value",
+			paths:          []string{"level"},
+			expectedErrors: []string{"Value at 'level' must be an empty object or array, got 3 at line 2, Column 64:64"},
+			expectedPaths:  []string{"$.level"},
+		},
+		{
+			name:           "invalid path",
+			paths:          []string{"hosts[x]"},
+			expectedErrors: []string{"Invalid path 'hosts[x]', expected an array index, got 'x' at line 1, Column 1:1"},
+			expectedPaths:  []string{""},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			node, err := ParseJSON(lex(input))
+			if err != nil {
+				t.Fatalf("Expected no parse error, got %v", err)
+			}
+
+			var actualErrors, actualPaths []string
+			for _, finding := range CheckAssertEmpty(node, testCase.paths) {
+				if finding.Severity != SeverityError || finding.Rule != RuleAssertEmpty {
+					t.Errorf("Expected an error of the %s rule, got %v of %q", RuleAssertEmpty, finding.Severity, finding.Rule)
+				}
+				actualErrors = append(actualErrors, finding.Error())
+				actualPaths = append(actualPaths, finding.Path)
+			}
+
+			if !reflect.DeepEqual(testCase.expectedErrors, actualErrors) {
+				t.Errorf("Expected errors %q, got %q", testCase.expectedErrors, actualErrors)
+			}
+			if !reflect.DeepEqual(testCase.expectedPaths, actualPaths) {
+				t.Errorf("Expected paths %q, got %q", testCase.expectedPaths, actualPaths)
+			}
+		})
+	}
+}
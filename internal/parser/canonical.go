@@ -0,0 +1,135 @@
+package parser
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+)
+
+// Canonical formats the AST as canonical JSON per the JSON Canonicalization Scheme (JCS, RFC 8785), ex. for signing:
+//   - no whitespace between tokens
+//   - object members sorted by the UTF-16 code units of their keys
+//   - numbers serialized like ECMAScript's Number.prototype.toString (ex. 4.50 => 4.5, 1E30 => 1e+30)
+//   - strings escaping only '"', '\' & control characters, w/ the short escapes where available (ex. \n, \u000f)
+//
+// Since the document must be I-JSON, an error is returned for duplicate keys & numbers which don't fit in a float64.
+func Canonical(node *ASTNode) (string, error) {
+	var sb strings.Builder
+	if err := writeCanonical(&sb, node); err != nil {
+		return "", err
+	}
+	return sb.String(), nil
+}
+
+// writeCanonical writes the node as canonical JSON to sb
+func writeCanonical(sb *strings.Builder, node *ASTNode) error {
+	switch node.Type {
+	case "Object":
+		members := Members(node)
+		seen := make(map[string]bool, len(members))
+		for _, member := range members {
+			if seen[member.Key] {
+				return fmt.Errorf("Cannot canonicalize duplicate key '%s' at line %d, Column %d:%d",
+					member.Key, member.KeyNode.Pos.Line, member.KeyNode.Pos.ColStart, member.KeyNode.Pos.ColEnd)
+			}
+			seen[member.Key] = true
+		}
+		sort.Slice(members, func(i, j int) bool {
+			return lessUTF16(members[i].Key, members[j].Key)
+		})
+
+		sb.WriteString("{")
+		for i, member := range members {
+			if i > 0 {
+				sb.WriteString(",")
+			}
+			writeCanonicalString(sb, member.Key)
+			sb.WriteString(":")
+			if err := writeCanonical(sb, member.Value); err != nil {
+				return err
+			}
+		}
+		sb.WriteString("}")
+	case "Array":
+		sb.WriteString("[")
+		for i, child := range node.Children {
+			if i > 0 {
+				sb.WriteString(",")
+			}
+			if err := writeCanonical(sb, child); err != nil {
+				return err
+			}
+		}
+		sb.WriteString("]")
+	case "String":
+		writeCanonicalString(sb, node.Value.(string))
+	case "Number":
+		num := node.Value.(float64)
+		if isNonFiniteLiteral(node.Raw) {
+			return fmt.Errorf("Cannot canonicalize number '%s' at line %d, Column %d:%d, NaN & Infinity aren't valid JSON",
+				node.Raw, node.Pos.Line, node.Pos.ColStart, node.Pos.ColEnd)
+		}
+		if math.IsInf(num, 0) {
+			return fmt.Errorf("Cannot canonicalize number '%s' at line %d, Column %d:%d, it doesn't fit in a float64",
+				node.Raw, node.Pos.Line, node.Pos.ColStart, node.Pos.ColEnd)
+		}
+		sb.WriteString(canonicalNumber(num))
+	default:
+		writeLiteral(sb, node)
+	}
+	return nil
+}
+
+// lessUTF16 reports whether a sorts before b when comparing their UTF-16 code units, as JCS requires
+func lessUTF16(a, b string) bool {
+	unitsA, unitsB := utf16.Encode([]rune(a)), utf16.Encode([]rune(b))
+	for i := 0; i < len(unitsA) && i < len(unitsB); i++ {
+		if unitsA[i] != unitsB[i] {
+			return unitsA[i] < unitsB[i]
+		}
+	}
+	return len(unitsA) < len(unitsB)
+}
+
+// canonicalNumber serializes the number like ECMAScript's Number.prototype.toString: the shortest representation
+// which round-trips, w/ an exponent only below 1e-6 or from 1e21 on (ex. 1e-7 or 1e+21)
+func canonicalNumber(num float64) string {
+	if num == 0 {
+		return "0" // Negative zero included
+	}
+
+	format := byte('f')
+	if abs := math.Abs(num); abs < 1e-6 || abs >= 1e21 {
+		format = 'e'
+	}
+	str := strconv.FormatFloat(num, format, -1, 64)
+
+	// ECMAScript doesn't pad the exponent to 2 digits (ex. 1e-07 => 1e-7)
+	if format == 'e' {
+		if n := len(str); n >= 4 && str[n-4] == 'e' && str[n-2] == '0' {
+			str = str[:n-2] + str[n-1:]
+		}
+	}
+	return str
+}
+
+// canonicalEscapes are the short escape sequences JCS uses for the characters which must be escaped
+var canonicalEscapes = map[rune]string{'"': `\"`, '\\': `\\`, '\b': `\b`, '\f': `\f`, '\n': `\n`, '\r': `\r`, '\t': `\t`}
+
+// writeCanonicalString writes the string quoted & escaped per JCS to sb
+func writeCanonicalString(sb *strings.Builder, str string) {
+	sb.WriteString(`"`)
+	for _, r := range str {
+		if escape, ok := canonicalEscapes[r]; ok {
+			sb.WriteString(escape)
+		} else if r < 0x20 {
+			fmt.Fprintf(sb, `\u%04x`, r)
+		} else {
+			sb.WriteRune(r)
+		}
+	}
+	sb.WriteString(`"`)
+}
@@ -0,0 +1,123 @@
+package parser
+
+import (
+	"math"
+	"testing"
+)
+
+func TestCanonical(t *testing.T) {
+	// Define test cases, including the examples of RFC 8785
+	testCases := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name: "rfc 8785 example",
+			input: `{
+				"numbers": [333333333.33333329, 1E30, 4.50, 2e-3, 0.000000000000000000000000001],
+				"string": "\u20ac$\u000F\u000aA'\u0042\u0022\u005c\\\"\/",
+				"literals": [null, true, false]
+			}`,
+			expected: `{"literals":[null,true,false],"numbers":[333333333.3333333,1e+30,4.5,0.002,1e-27],"string":"€$\u000f\nA'B\"\\\\\"/"}`,
+		},
+		{
+			name: "rfc 8785 sorting",
+			input: `{
+				"\u20ac": "Euro Sign",
+				"\r": "Carriage Return",
+				"\ufb33": "Hebrew Letter Dalet With Dagesh",
+				"1": "One",
+				"\ud83d\ude00": "Emoji: Grinning Face",
+				"\u0080": "Control",
+				"\u00f6": "Latin Small Letter O With Diaeresis"
+			}`,
+			expected: "{\"\\r\":\"Carriage Return\",\"1\":\"One\",\"\u0080\":\"Control\",\"ö\":\"Latin Small Letter O With Diaeresis\"," +
+				"\"€\":\"Euro Sign\",\"😀\":\"Emoji: Grinning Face\",\"\ufb33\":\"Hebrew Letter Dalet With Dagesh\"}",
+		},
+		{name: "nested objects", input: `{"b": {"d": [], "c": {}}, "a": [{"z": 1, "y": 2}]}`, expected: `{"a":[{"y":2,"z":1}],"b":{"c":{},"d":[]}}`},
+		{name: "scalar root", input: ` "tab\there" `, expected: `"tab\there"`},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			node, err := ParseJSON(lex(testCase.input))
+			if err != nil {
+				t.Fatalf("Expected no parse error, got %v", err)
+			}
+
+			actual, err := Canonical(node)
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if actual != testCase.expected {
+				t.Errorf("Expected %s, got %s", testCase.expected, actual)
+			}
+		})
+	}
+}
+
+func TestCanonicalNumber(t *testing.T) {
+	// Define test cases, from the IEEE 754 examples of RFC 8785 (Appendix B)
+	testCases := []struct {
+		bits     uint64
+		expected string
+	}{
+		{bits: 0x0000000000000000, expected: "0"},
+		{bits: 0x8000000000000000, expected: "0"},
+		{bits: 0x0000000000000001, expected: "5e-324"},
+		{bits: 0x8000000000000001, expected: "-5e-324"},
+		{bits: 0x7fefffffffffffff, expected: "1.7976931348623157e+308"},
+		{bits: 0xffefffffffffffff, expected: "-1.7976931348623157e+308"},
+		{bits: 0x4340000000000000, expected: "9007199254740992"},
+		{bits: 0xc340000000000000, expected: "-9007199254740992"},
+		{bits: 0x4430000000000000, expected: "295147905179352830000"},
+		{bits: 0x44b52d02c7e14af5, expected: "9.999999999999997e+22"},
+		{bits: 0x44b52d02c7e14af6, expected: "1e+23"},
+		{bits: 0x44b52d02c7e14af7, expected: "1.0000000000000001e+23"},
+		{bits: 0x444b1ae4d6e2ef4e, expected: "999999999999999700000"},
+		{bits: 0x444b1ae4d6e2ef4f, expected: "999999999999999900000"},
+		{bits: 0x444b1ae4d6e2ef50, expected: "1e+21"},
+		{bits: 0x3eb0c6f7a0b5ed8c, expected: "9.999999999999997e-7"},
+		{bits: 0x3eb0c6f7a0b5ed8d, expected: "0.000001"},
+		{bits: 0x41b3de4355555553, expected: "333333333.3333332"},
+		{bits: 0x41b3de4355555554, expected: "333333333.33333325"},
+		{bits: 0x41b3de4355555555, expected: "333333333.3333333"},
+		{bits: 0x41b3de4355555556, expected: "333333333.3333334"},
+		{bits: 0x41b3de4355555557, expected: "333333333.33333343"},
+		{bits: 0xbecbf647612f3696, expected: "-0.0000033333333333333333"},
+		{bits: 0x43143ff3c1cb0959, expected: "1424953923781206.2"},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.expected, func(t *testing.T) {
+			if actual := canonicalNumber(math.Float64frombits(testCase.bits)); actual != testCase.expected {
+				t.Errorf("Expected %s, got %s", testCase.expected, actual)
+			}
+		})
+	}
+}
+
+func TestCanonicalInvalid(t *testing.T) {
+	// Define test cases
+	testCases := []struct {
+		input            string
+		expectedErrorMsg string
+	}{
+		{input: `{"a": 1, "a": 2}`, expectedErrorMsg: "Cannot canonicalize duplicate key 'a' at line 1, Column 10:12"},
+		{input: `[1e400]`, expectedErrorMsg: "Cannot canonicalize number '1e400' at line 1, Column 2:6, it doesn't fit in a float64"},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.input, func(t *testing.T) {
+			node, err := ParseJSON(lex(testCase.input))
+			if err != nil {
+				t.Fatalf("Expected no parse error, got %v", err)
+			}
+
+			if _, err := Canonical(node); err == nil || err.Error() != testCase.expectedErrorMsg {
+				t.Errorf("Expected error %q, got %v", testCase.expectedErrorMsg, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,90 @@
+package parser
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+
+	"github.com/pszponder/json-linter_go/internal/lexer"
+)
+
+// DecodeOptions configures how Decode converts the AST into Go values
+type DecodeOptions struct {
+	UseNumber bool // Decode numbers as json.Number instead of float64
+
+	// DuplicateKeys determines whether an object containing the same key more than once is rejected w/ a duplicate-keys
+	// ParseError (DuplicateKeyError) or decoded w/ the last value of the key. Empty means DefaultDuplicateKeyPolicy.
+	DuplicateKeys DuplicateKeyPolicy
+}
+
+// Decode parses the JSON document in data and returns its Go representation.
+//
+// The representation matches what encoding/json.Unmarshal produces for an interface{}:
+//   - object: map[string]interface{}
+//   - array: []interface{}
+//   - string: string
+//   - number: float64
+//   - boolean: bool
+//   - null: nil
+func Decode(data []byte) (interface{}, error) {
+	return DecodeWithOptions(data, DecodeOptions{})
+}
+
+// DecodeWithOptions is like Decode, but lets the caller configure the decoding via opts.
+func DecodeWithOptions(data []byte, opts DecodeOptions) (interface{}, error) {
+	tokens := lexer.LexReader(bytes.NewReader(data))
+
+	rootNode, err := ParseJSON(tokens)
+	if err != nil {
+		return nil, err
+	}
+
+	if opts.DuplicateKeys == DuplicateKeyError {
+		if errs := CheckDuplicateKeysWithPolicy(rootNode, opts.DuplicateKeys); len(errs) > 0 {
+			return nil, errs[0]
+		}
+	}
+
+	return decodeNode(rootNode, opts)
+}
+
+// decodeNode converts an AST node (and its children) into its Go representation
+func decodeNode(node *ASTNode, opts DecodeOptions) (interface{}, error) {
+	switch node.Type {
+	case "Object":
+		// As with encoding/json, the last value wins for duplicate keys
+		obj := make(map[string]interface{}, len(node.Children)/2)
+		for _, member := range Members(node) {
+			value, err := decodeNode(member.Value, opts)
+			if err != nil {
+				return nil, err
+			}
+			obj[member.Key] = value
+		}
+		return obj, nil
+	case "Array":
+		arr := make([]interface{}, 0, len(node.Children))
+		for _, child := range node.Children {
+			value, err := decodeNode(child, opts)
+			if err != nil {
+				return nil, err
+			}
+			arr = append(arr, value)
+		}
+		return arr, nil
+	case "Number":
+		if opts.UseNumber {
+			return json.Number(node.Raw), nil
+		}
+		num := node.Value.(float64)
+		if math.IsInf(num, 0) {
+			return nil, fmt.Errorf("Cannot decode number '%s' into a float64", node.Raw)
+		}
+		return num, nil
+	case "String", "Boolean", "Null":
+		return node.Value, nil
+	default:
+		return nil, fmt.Errorf("Cannot decode AST node of type '%s'", node.Type)
+	}
+}
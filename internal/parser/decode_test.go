@@ -0,0 +1,100 @@
+package parser
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestDecode(t *testing.T) {
+	// Define test cases (each input is also decoded w/ encoding/json for comparison)
+	testCases := []string{
+		`{}`,
+		`[]`,
+		`{"hello": "world", "happy": true, "sad": false, "nothing": null}`,
+		`{"fruits": ["apple", "banana", "orange"], "numbers": [1, 2, 3]}`,
+		`[0, -1, 1.5, -0.25, 1e3, 1.23E-10]`,
+		`{"nested": {"array": [[], {}, [{"deep": [null]}]]}}`,
+		`["", "a\"b", "back\\slash", "\/", "\b\f\n\r\t", "é", "😀", "\u00e9", "\ud83d\ude00", "\ud83d"]`,
+		`{"dup": 1, "dup": 2}`,
+		`"scalar root"`,
+		`42`,
+		`null`,
+	}
+
+	for _, input := range testCases {
+		t.Run(input, func(t *testing.T) {
+			actual, err := Decode([]byte(input))
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+
+			var expected interface{}
+			if err := json.Unmarshal([]byte(input), &expected); err != nil {
+				t.Fatalf("encoding/json failed to decode test input: %v", err)
+			}
+
+			if !reflect.DeepEqual(expected, actual) {
+				t.Errorf("Expected %#v, got %#v", expected, actual)
+			}
+		})
+	}
+}
+
+func TestDecodeUseNumber(t *testing.T) {
+	input := `{"int": 12345678901234567890, "float": 1.50}`
+
+	actual, err := DecodeWithOptions([]byte(input), DecodeOptions{UseNumber: true})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	expected := map[string]interface{}{
+		"int":   json.Number("12345678901234567890"),
+		"float": json.Number("1.50"),
+	}
+	if !reflect.DeepEqual(expected, actual) {
+		t.Errorf("Expected %#v, got %#v", expected, actual)
+	}
+}
+
+func TestDecodeDuplicateKeys(t *testing.T) {
+	input := `{"a": 1, "a": 2}`
+
+	// The last value wins unless duplicates are errors
+	for _, policy := range []DuplicateKeyPolicy{"", DuplicateKeyWarn, DuplicateKeyKeepLast} {
+		actual, err := DecodeWithOptions([]byte(input), DecodeOptions{DuplicateKeys: policy})
+		if err != nil {
+			t.Fatalf("Expected no error w/ policy %q, got %v", policy, err)
+		}
+		if expected := map[string]interface{}{"a": float64(2)}; !reflect.DeepEqual(expected, actual) {
+			t.Errorf("Expected %#v w/ policy %q, got %#v", expected, policy, actual)
+		}
+	}
+
+	_, err := DecodeWithOptions([]byte(input), DecodeOptions{DuplicateKeys: DuplicateKeyError})
+	if expected := "Duplicate key 'a' at line 1, Column 10:12"; err == nil || err.Error() != expected {
+		t.Errorf("Expected error %q, got %v", expected, err)
+	}
+}
+
+func TestDecodeInvalid(t *testing.T) {
+	testCases := []string{
+		``,
+		`{`,
+		`[1,`,
+		`{"key": }`,
+		`{"key": "value",}`,
+		`["bad \x escape"]`,
+		`["\u12"]`,
+		`[1e400]`,
+	}
+
+	for _, input := range testCases {
+		t.Run(input, func(t *testing.T) {
+			if _, err := Decode([]byte(input)); err == nil {
+				t.Errorf("Expected an error decoding %q, got nil", input)
+			}
+		})
+	}
+}
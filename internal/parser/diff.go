@@ -0,0 +1,121 @@
+package parser
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// DiffKind classifies a Difference between two documents
+type DiffKind string
+
+const (
+	DiffAdded   DiffKind = "added"   // The value is only present in the 2nd document
+	DiffRemoved DiffKind = "removed" // The value is only present in the 1st document
+	DiffChanged DiffKind = "changed" // The value differs between the documents
+)
+
+// Difference describes a value which differs between two documents
+type Difference struct {
+	Kind DiffKind
+	Path string   // JSONPath-like location of the value (ex. "$.users[3].email")
+	Old  *ASTNode // Value in the 1st document (nil if added)
+	New  *ASTNode // Value in the 2nd document (nil if removed)
+}
+
+// String formats the difference w/ its path & values (ex. "changed $.a: 1 -> 2")
+func (d Difference) String() string {
+	switch d.Kind {
+	case DiffAdded:
+		return fmt.Sprintf("%s %s: %s", d.Kind, d.Path, compactJSON(d.New))
+	case DiffRemoved:
+		return fmt.Sprintf("%s %s: %s", d.Kind, d.Path, compactJSON(d.Old))
+	default:
+		return fmt.Sprintf("%s %s: %s -> %s", d.Kind, d.Path, compactJSON(d.Old), compactJSON(d.New))
+	}
+}
+
+// Diff compares the ASTs of two documents structurally and returns their differences in document order.
+//
+// Formatting & key order are ignored, and numbers are compared by value (ex. 1.0 equals 1 & 1e2 equals 100)
+// from their literals, w/o the precision lost by converting them to float64.
+// Objects are compared key by key (for duplicate keys, the last value wins, same as Decode),
+// while arrays are compared element by element.
+func Diff(a, b *ASTNode) []Difference {
+	return diffNodes(a, b, "$", nil)
+}
+
+// diffNodes appends the differences between the nodes located at path to diffs
+func diffNodes(a, b *ASTNode, path string, diffs []Difference) []Difference {
+	if a.Type != b.Type {
+		return append(diffs, Difference{Kind: DiffChanged, Path: path, Old: a, New: b})
+	}
+
+	switch a.Type {
+	case "Object":
+		aMembers, aKeys := ObjectMembers(a)
+		bMembers, bKeys := ObjectMembers(b)
+		for _, key := range aKeys {
+			if bValue, ok := bMembers[key]; ok {
+				diffs = diffNodes(aMembers[key], bValue, KeyPath(path, key), diffs)
+			} else {
+				diffs = append(diffs, Difference{Kind: DiffRemoved, Path: KeyPath(path, key), Old: aMembers[key]})
+			}
+		}
+		for _, key := range bKeys {
+			if _, ok := aMembers[key]; !ok {
+				diffs = append(diffs, Difference{Kind: DiffAdded, Path: KeyPath(path, key), New: bMembers[key]})
+			}
+		}
+	case "Array":
+		for i := 0; i < len(a.Children) || i < len(b.Children); i++ {
+			switch {
+			case i >= len(b.Children):
+				diffs = append(diffs, Difference{Kind: DiffRemoved, Path: IndexPath(path, i), Old: a.Children[i]})
+			case i >= len(a.Children):
+				diffs = append(diffs, Difference{Kind: DiffAdded, Path: IndexPath(path, i), New: b.Children[i]})
+			default:
+				diffs = diffNodes(a.Children[i], b.Children[i], IndexPath(path, i), diffs)
+			}
+		}
+	case "Number":
+		// NaN (lexed w/ AllowNaNInf) is the same value in both documents, as the literals are compared
+		if numberKey(a.Raw) != numberKey(b.Raw) {
+			diffs = append(diffs, Difference{Kind: DiffChanged, Path: path, Old: a, New: b})
+		}
+	default:
+		if a.Value != b.Value {
+			diffs = append(diffs, Difference{Kind: DiffChanged, Path: path, Old: a, New: b})
+		}
+	}
+
+	return diffs
+}
+
+// numberKey returns a form of the number literal which is the same for all the literals of the same value,
+// made of the sign, the significant digits & the exponent of the last digit (ex. "1.50E+2", "150" & "0x96" -> "15e1").
+// Zero is "0" whatever its sign, and literals which aren't decimal numbers (ex. NaN) are returned as is.
+func numberKey(raw string) string {
+	parts := numberParts.FindStringSubmatch(NormalizeNumber(raw))
+	if parts == nil {
+		return raw
+	}
+	sign, integer, fraction, exponent := parts[1], parts[2], parts[3], parts[4]+parts[5]
+
+	exp := int64(0)
+	if exponent != "" {
+		var err error
+		if exp, err = strconv.ParseInt(exponent, 10, 64); err != nil {
+			// An exponent this large can't be compared by value, only by its normalized literal
+			return NormalizeNumber(raw)
+		}
+	}
+
+	digits := strings.TrimLeft(integer+fraction, "0")
+	if digits == "" {
+		return "0"
+	}
+	significant := strings.TrimRight(digits, "0")
+	exp += int64(len(digits)-len(significant)) - int64(len(fraction))
+	return fmt.Sprintf("%s%se%d", sign, significant, exp)
+}
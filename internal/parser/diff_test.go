@@ -0,0 +1,86 @@
+package parser
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDiff(t *testing.T) {
+	// Define test cases
+	testCases := []struct {
+		name          string
+		a, b          string
+		expectedDiffs []string
+	}{
+		{name: "formatting & key order", a: `{"a": 1, "b": [true, null]}`, b: "{\n\t\"b\": [ true, null ],\n\t\"a\": 1.0\n}"},
+		{name: "added key", a: `{"a": 1}`, b: `{"a": 1, "b": {"c": [1, "x"]}}`, expectedDiffs: []string{`added $.b: {"c":[1,"x"]}`}},
+		{name: "removed key", a: `{"a": 1, "b": "x"}`, b: `{"a": 1}`, expectedDiffs: []string{`removed $.b: "x"`}},
+		{name: "changed scalar", a: `{"a": {"b": 1}}`, b: `{"a": {"b": 2}}`, expectedDiffs: []string{"changed $.a.b: 1 -> 2"}},
+		{name: "changed type", a: `{"a": "1"}`, b: `{"a": 1}`, expectedDiffs: []string{`changed $.a: "1" -> 1`}},
+		{
+			name: "array elements",
+			a:    `[1, [2, 3], 4]`,
+			b:    `[1, [2], 5, 6]`,
+			expectedDiffs: []string{
+				"removed $[1][1]: 3",
+				"changed $[2]: 4 -> 5",
+				"added $[3]: 6",
+			},
+		},
+		{name: "last duplicate wins", a: `{"a": 1, "a": 2}`, b: `{"a": 2}`},
+		{name: "numbers of the same value", a: `[1.50E+2, 0, 0.001, -12e-1]`, b: `[150, -0.0, 1e-3, -1.2]`},
+		{
+			name:          "numbers differing beyond float64 precision",
+			a:             `[12345678901234567890, 0.1000000000000000001]`,
+			b:             `[12345678901234567891, 0.1]`,
+			expectedDiffs: []string{"changed $[0]: 12345678901234567890 -> 12345678901234567891", "changed $[1]: 0.1000000000000000001 -> 0.1"},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			a, err := ParseJSON(lex(testCase.a))
+			if err != nil {
+				t.Fatalf("Expected no parse error, got %v", err)
+			}
+			b, err := ParseJSON(lex(testCase.b))
+			if err != nil {
+				t.Fatalf("Expected no parse error, got %v", err)
+			}
+
+			var actualDiffs []string
+			for _, diff := range Diff(a, b) {
+				actualDiffs = append(actualDiffs, diff.String())
+			}
+			if !reflect.DeepEqual(testCase.expectedDiffs, actualDiffs) {
+				t.Errorf("Expected differences %q, got %q", testCase.expectedDiffs, actualDiffs)
+			}
+		})
+	}
+}
+
+func TestNumberKey(t *testing.T) {
+	// Define test cases
+	testCases := []struct {
+		raw         string
+		expectedKey string
+	}{
+		{raw: "150", expectedKey: "15e1"},
+		{raw: "1.50E+2", expectedKey: "15e1"},
+		{raw: "0x96", expectedKey: "15e1"},
+		{raw: "-0.0012", expectedKey: "-12e-4"},
+		{raw: "-0", expectedKey: "0"},
+		{raw: "0e10", expectedKey: "0"},
+		{raw: "NaN", expectedKey: "NaN"},
+		{raw: "-Infinity", expectedKey: "-Infinity"},
+		{raw: "1E+99999999999999999999", expectedKey: "1e99999999999999999999"},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.raw, func(t *testing.T) {
+			if key := numberKey(testCase.raw); key != testCase.expectedKey {
+				t.Errorf("Expected key %q, got %q", testCase.expectedKey, key)
+			}
+		})
+	}
+}
@@ -0,0 +1,72 @@
+package parser
+
+import (
+	"strings"
+
+	"github.com/pszponder/json-linter_go/internal/lexer"
+)
+
+// DirectivePrefix starts a comment which disables lint rules for the next line (ex. "// linter-disable duplicate-keys")
+const DirectivePrefix = "linter-disable"
+
+// allRules is used in place of a rule name when a directive disables every rule
+const allRules = "*"
+
+// ApplyDirectives removes the lint findings disabled by directive comments (only available in JSONC mode).
+//
+// A directive applies to the line following the comment and lists the rules to disable, separated by spaces or commas.
+// A directive without any rules disables every rule for that line.
+// Syntax errors (findings w/o a Rule) can't be disabled.
+func ApplyDirectives(tokens []lexer.Token, findings []ParseError) []ParseError {
+	// Map each line to the set of rules disabled on it
+	disabled := make(map[int]map[string]bool)
+	for _, tok := range tokens {
+		if tok.TokType != lexer.COMMENT {
+			continue
+		}
+
+		rules, ok := parseDirective(tok.Lexeme)
+		if !ok {
+			continue
+		}
+
+		// Block comments can span lines, the directive applies to the line after the comment ends
+		nextLine := tok.TokPos.Line + strings.Count(tok.Lexeme, "\n") + 1
+		if disabled[nextLine] == nil {
+			disabled[nextLine] = make(map[string]bool)
+		}
+		for _, rule := range rules {
+			disabled[nextLine][rule] = true
+		}
+	}
+
+	var kept []ParseError
+	for _, finding := range findings {
+		rules := disabled[finding.Pos.Line]
+		if finding.Rule != "" && (rules[finding.Rule] || rules[allRules]) {
+			continue
+		}
+		kept = append(kept, finding)
+	}
+	return kept
+}
+
+// parseDirective returns the rules listed by a directive comment, or false if the comment is not a directive
+func parseDirective(comment string) ([]string, bool) {
+	text := strings.TrimPrefix(comment, "//")
+	if strings.HasPrefix(comment, "/*") {
+		text = strings.TrimSuffix(strings.TrimPrefix(comment, "/*"), "*/")
+	}
+
+	fields := strings.FieldsFunc(text, func(r rune) bool {
+		return r == ',' || r == ' ' || r == '\t' || r == '\n'
+	})
+	if len(fields) == 0 || fields[0] != DirectivePrefix {
+		return nil, false
+	}
+
+	if len(fields) == 1 {
+		return []string{allRules}, true
+	}
+	return fields[1:], true
+}
@@ -0,0 +1,82 @@
+package parser
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/pszponder/json-linter_go/internal/lexer"
+)
+
+func TestApplyDirectives(t *testing.T) {
+	input := `{
+	"a": 1,
+	// linter-disable duplicate-keys
+	"a": 2,
+	"b": {
+		"x": 1,
+		// linter-disable some-other-rule
+		"x": 2,
+		"y": 3,
+		/* linter-disable
+		*/
+		"y": 4
+	},
+	"b": null
+}`
+
+	tokens, err := lexer.LexReaderContext(context.Background(), strings.NewReader(input), lexer.Options{AllowComments: true})
+	if err != nil {
+		t.Fatalf("Expected no lexer error, got %v", err)
+	}
+
+	rootNode, err := ParseJSON(tokens)
+	if err != nil {
+		t.Fatalf("Expected no parse error, got %v", err)
+	}
+
+	// A syntax error (w/o a rule) on a disabled line is never suppressed
+	syntaxError := ParseError{Message: "Syntax error", Pos: lexer.TokenPosition{Line: 4}}
+	findings := append(CheckDuplicateKeys(rootNode), syntaxError)
+
+	kept := ApplyDirectives(tokens, findings)
+
+	// Expected to keep the "x" duplicate (the directive disables a different rule),
+	// the "b" duplicate (no directive) & the syntax error
+	expectedLines := []int{8, 14, 4}
+	if len(kept) != len(expectedLines) {
+		t.Fatalf("Expected %d findings, got %v", len(expectedLines), kept)
+	}
+	for i, line := range expectedLines {
+		if kept[i].Pos.Line != line {
+			t.Errorf("Expected finding on line %d, got %v", line, kept[i])
+		}
+	}
+}
+
+func TestParseDirective(t *testing.T) {
+	testCases := []struct {
+		comment       string
+		expectedRules []string
+		expectedOk    bool
+	}{
+		{comment: "// linter-disable duplicate-keys", expectedRules: []string{"duplicate-keys"}, expectedOk: true},
+		{comment: "//linter-disable a, b", expectedRules: []string{"a", "b"}, expectedOk: true},
+		{comment: "/* linter-disable a */", expectedRules: []string{"a"}, expectedOk: true},
+		{comment: "// linter-disable", expectedRules: []string{allRules}, expectedOk: true},
+		{comment: "// linter-disabled a", expectedOk: false},
+		{comment: "// a regular comment", expectedOk: false},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.comment, func(t *testing.T) {
+			rules, ok := parseDirective(testCase.comment)
+			if ok != testCase.expectedOk {
+				t.Fatalf("Expected ok %v, got %v", testCase.expectedOk, ok)
+			}
+			if strings.Join(rules, ",") != strings.Join(testCase.expectedRules, ",") {
+				t.Errorf("Expected rules %v, got %v", testCase.expectedRules, rules)
+			}
+		})
+	}
+}
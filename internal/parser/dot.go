@@ -0,0 +1,70 @@
+package parser
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// maxDOTLabelLength is the number of characters of a string value kept in its DOT label, longer values are truncated
+const maxDOTLabelLength = 20
+
+// WriteDOT writes the AST as a Graphviz DOT digraph to w.
+// Each value is a node (objects are labeled {} & arrays []), linked to its parent by an edge labeled w/ its key or index.
+func WriteDOT(w io.Writer, node *ASTNode) error {
+	var sb strings.Builder
+	sb.WriteString("digraph json {\n")
+	id := 0
+	writeDOTNode(&sb, node, &id)
+	sb.WriteString("}\n")
+
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+// writeDOTNode writes the node & its descendants to sb, numbering them from *id.
+// Returns the DOT identifier of the node.
+func writeDOTNode(sb *strings.Builder, node *ASTNode, id *int) string {
+	name := fmt.Sprintf("n%d", *id)
+	*id++
+	fmt.Fprintf(sb, "\t%s [label=%s];\n", name, dotQuote(dotLabel(node)))
+
+	step := 1 // Number of children making up a member or an element
+	if node.Type == "Object" {
+		step = 2
+	}
+	for i := 0; i+step-1 < len(node.Children); i += step {
+		edge := strconv.Itoa(i)
+		if step == 2 {
+			edge = node.Children[i].Value.(string)
+		}
+		child := writeDOTNode(sb, node.Children[i+step-1], id)
+		fmt.Fprintf(sb, "\t%s -> %s [label=%s];\n", name, child, dotQuote(edge))
+	}
+
+	return name
+}
+
+// dotLabel returns the short label of the node
+func dotLabel(node *ASTNode) string {
+	switch node.Type {
+	case "Object":
+		return "{}"
+	case "Array":
+		return "[]"
+	case "String":
+		value := []rune(node.Value.(string))
+		if len(value) > maxDOTLabelLength {
+			return `"` + string(value[:maxDOTLabelLength]) + `…"`
+		}
+		return `"` + string(value) + `"`
+	default:
+		return compactJSON(node)
+	}
+}
+
+// dotQuote returns the text as a DOT string literal
+func dotQuote(text string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(text) + `"`
+}
@@ -0,0 +1,36 @@
+package parser
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestWriteDOT(t *testing.T) {
+	rootNode, err := ParseJSON(lex(`{"name": "a \"quoted\" and very long name", "ports": [80, true], "extra": null}`))
+	if err != nil {
+		t.Fatalf("Expected no parse error, got %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := WriteDOT(&buf, rootNode); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	expected := `digraph json {
+	n0 [label="{}"];
+	n1 [label="\"a \"quoted\" and very …\""];
+	n0 -> n1 [label="name"];
+	n2 [label="[]"];
+	n3 [label="80"];
+	n2 -> n3 [label="0"];
+	n4 [label="true"];
+	n2 -> n4 [label="1"];
+	n0 -> n2 [label="ports"];
+	n5 [label="null"];
+	n0 -> n5 [label="extra"];
+}
+`
+	if buf.String() != expected {
+		t.Errorf("Expected:\n%s\ngot:\n%s", expected, buf.String())
+	}
+}
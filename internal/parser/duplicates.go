@@ -0,0 +1,89 @@
+package parser
+
+import "fmt"
+
+// RuleDuplicateKeys is the name of the lint rule reporting duplicate keys within an object
+const RuleDuplicateKeys = "duplicate-keys"
+
+// DuplicateKeyPolicy determines how keys appearing more than once in the same object are handled
+type DuplicateKeyPolicy string
+
+const (
+	DuplicateKeyError    DuplicateKeyPolicy = "error"     // Duplicate keys are reported as errors & can't be decoded
+	DuplicateKeyWarn     DuplicateKeyPolicy = "warn"      // Duplicate keys are reported as warnings, the last value is decoded
+	DuplicateKeyKeepLast DuplicateKeyPolicy = "keep-last" // Duplicate keys aren't reported, the last value is decoded (like encoding/json)
+
+	// DefaultDuplicateKeyPolicy is the policy used when none is specified
+	DefaultDuplicateKeyPolicy = DuplicateKeyKeepLast
+)
+
+// ParseDuplicateKeyPolicy returns the DuplicateKeyPolicy named by name (ex. "keep-last").
+// An empty name selects DefaultDuplicateKeyPolicy.
+func ParseDuplicateKeyPolicy(name string) (DuplicateKeyPolicy, error) {
+	switch policy := DuplicateKeyPolicy(name); policy {
+	case "":
+		return DefaultDuplicateKeyPolicy, nil
+	case DuplicateKeyError, DuplicateKeyWarn, DuplicateKeyKeepLast:
+		return policy, nil
+	default:
+		return "", fmt.Errorf("unknown duplicate key policy %q, expected %q, %q or %q", name, DuplicateKeyError, DuplicateKeyWarn, DuplicateKeyKeepLast)
+	}
+}
+
+// CheckDuplicateKeys walks the AST and returns a warning for each key which appears more than once in the same object.
+//
+// Uniqueness is scoped to each object, so the same key may appear in sibling or nested objects
+// (ex. {"a": {"x": 1}, "b": {"x": 2}} is valid).
+func CheckDuplicateKeys(node *ASTNode) []ParseError {
+	var warnings []ParseError
+
+	// The duplicate keys of an object are found when visiting it, but reported when visiting their value,
+	// so they're reported in document order w/ the duplicates nested in the values of preceding keys
+	duplicates := make(map[*ASTNode]*ASTNode) // Key node of each value whose key is a duplicate
+	WalkPaths(node, func(node *ASTNode, path string) bool {
+		if keyNode, ok := duplicates[node]; ok {
+			warnings = append(warnings, ParseError{
+				Severity: SeverityWarning,
+				Rule:     RuleDuplicateKeys,
+				Message:  fmt.Sprintf("Duplicate key '%s'", keyNode.Value.(string)),
+				Pos:      keyNode.Pos,
+				Path:     path,
+			})
+		}
+		if node.Type != "Object" {
+			return true
+		}
+
+		// Each object gets its own key set
+		keys := make(map[string]bool, len(node.Children)/2)
+		for _, member := range Members(node) {
+			if keys[member.Key] {
+				duplicates[member.Value] = member.KeyNode
+			}
+			keys[member.Key] = true
+		}
+		return true
+	})
+
+	return warnings
+}
+
+// CheckDuplicateKeysWithPolicy is like CheckDuplicateKeys, but reports the duplicate keys according to the policy
+// (errors, warnings or nothing). An empty policy selects DefaultDuplicateKeyPolicy.
+func CheckDuplicateKeysWithPolicy(node *ASTNode, policy DuplicateKeyPolicy) []ParseError {
+	if policy == "" {
+		policy = DefaultDuplicateKeyPolicy
+	}
+	switch policy {
+	case DuplicateKeyKeepLast:
+		return nil
+	case DuplicateKeyError:
+		findings := CheckDuplicateKeys(node)
+		for i := range findings {
+			findings[i].Severity = SeverityError
+		}
+		return findings
+	default:
+		return CheckDuplicateKeys(node)
+	}
+}
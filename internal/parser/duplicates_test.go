@@ -0,0 +1,126 @@
+package parser
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestCheckDuplicateKeys(t *testing.T) {
+	// Define test cases
+	testCases := []struct {
+		input           string
+		expectedWarning string // Empty if no duplicate is expected
+	}{
+		// Same key in sibling objects
+		{input: `{"a": {"x": 1}, "b": {"x": 2}}`},
+		// Same key in a nested object and its parent
+		{input: `{"x": {"x": {"x": 1}}}`},
+		// Same key in objects within an array
+		{input: `[{"x": 1}, {"x": 2}, [{"x": 3}]]`},
+		// Different keys
+		{input: `{"a": 1, "b": 2, "c": {"a": 1, "b": 2}}`},
+		// Duplicate key at the root
+		{
+			input:           `{"a": 1, "a": 2}`,
+			expectedWarning: "Duplicate key 'a' at line 1, Column 10:12",
+		},
+		// Duplicate key in a nested object after a sibling object with the same keys
+		{
+			input:           `{"a": {"x": 1}, "b": {"x": 1, "x": 2}}`,
+			expectedWarning: "Duplicate key 'x' at line 1, Column 31:33",
+		},
+		// Duplicate key inside an array element
+		{
+			input:           `[{"y": 1}, {"y": 1, "z": 2, "y": 3}]`,
+			expectedWarning: "Duplicate key 'y' at line 1, Column 29:31",
+		},
+		// Keys are compared after decoding escape sequences
+		{
+			input:           `{"a": 1, "\u0061": 2}`,
+			expectedWarning: "Duplicate key 'a'",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.input, func(t *testing.T) {
+			rootNode, err := ParseJSON(lex(testCase.input))
+			if err != nil {
+				t.Fatalf("Expected no parse error, got %v", err)
+			}
+
+			warnings := CheckDuplicateKeys(rootNode)
+			if testCase.expectedWarning == "" {
+				if len(warnings) != 0 {
+					t.Errorf("Expected no duplicate keys, got %v", warnings)
+				}
+				return
+			}
+
+			if len(warnings) != 1 {
+				t.Fatalf("Expected 1 warning, got %v", warnings)
+			}
+			if !strings.Contains(warnings[0].Error(), testCase.expectedWarning) {
+				t.Errorf("Expected warning containing %q, got %v", testCase.expectedWarning, warnings[0])
+			}
+			if warnings[0].Severity != SeverityWarning || warnings[0].Rule != RuleDuplicateKeys {
+				t.Errorf("Expected a %s warning, got %v %q", RuleDuplicateKeys, warnings[0].Severity, warnings[0].Rule)
+			}
+		})
+	}
+}
+
+func TestCheckDuplicateKeysWithPolicy(t *testing.T) {
+	rootNode, err := ParseJSON(lex(`{"a": 1, "b": {"c": 2, "c": 3}, "a": 4}`))
+	if err != nil {
+		t.Fatalf("Expected no parse error, got %v", err)
+	}
+
+	// Define test cases
+	testCases := []struct {
+		policy           DuplicateKeyPolicy
+		expectedFindings bool
+		expectedSeverity Severity
+	}{
+		{policy: DuplicateKeyError, expectedFindings: true, expectedSeverity: SeverityError},
+		{policy: DuplicateKeyWarn, expectedFindings: true, expectedSeverity: SeverityWarning},
+		{policy: DuplicateKeyKeepLast},
+		// The default keeps the last value silently, like encoding/json
+		{policy: ""},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(string(testCase.policy), func(t *testing.T) {
+			findings := CheckDuplicateKeysWithPolicy(rootNode, testCase.policy)
+			if !testCase.expectedFindings {
+				if len(findings) != 0 {
+					t.Errorf("Expected no finding, got %v", findings)
+				}
+				return
+			}
+
+			var paths []string
+			for _, finding := range findings {
+				if finding.Severity != testCase.expectedSeverity || finding.Rule != RuleDuplicateKeys {
+					t.Errorf("Expected a %s %s, got %s %s", RuleDuplicateKeys, testCase.expectedSeverity, finding.Rule, finding.Severity)
+				}
+				paths = append(paths, finding.Path)
+			}
+			if expected := []string{"$.b.c", "$.a"}; !reflect.DeepEqual(paths, expected) {
+				t.Errorf("Expected findings at %q, got %q", expected, paths)
+			}
+		})
+	}
+}
+
+func TestParseDuplicateKeyPolicy(t *testing.T) {
+	for name, expected := range map[string]DuplicateKeyPolicy{"": DefaultDuplicateKeyPolicy, "error": DuplicateKeyError, "warn": DuplicateKeyWarn, "keep-last": DuplicateKeyKeepLast} {
+		if actual, err := ParseDuplicateKeyPolicy(name); err != nil || actual != expected {
+			t.Errorf("Expected policy %q for %q, got %q (%v)", expected, name, actual, err)
+		}
+	}
+
+	if _, err := ParseDuplicateKeyPolicy("keep-first"); err == nil {
+		t.Errorf("Expected an error for an unknown policy")
+	}
+}
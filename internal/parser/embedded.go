@@ -0,0 +1,103 @@
+package parser
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/pszponder/json-linter_go/internal/lexer"
+)
+
+// ParseEmbedded parses the JSON document embedded (double-encoded) in the String node, ex. the value of "payload" in
+// {"payload": "{\"id\": 1}"}, and returns the root node of the embedded document.
+//
+// The positions of the embedded document's tokens are mapped back to the escape sequences they were written as
+// in the outer document, so both errors & AST positions point into the outer document.
+func ParseEmbedded(ctx context.Context, node *ASTNode, opts lexer.Options) (*ASTNode, error) {
+	if node.Type != "String" {
+		return nil, fmt.Errorf("Expected a String containing embedded JSON, got %s at line %d, Column %d:%d",
+			node.Type, node.Pos.Line, node.Pos.ColStart, node.Pos.ColEnd)
+	}
+
+	strTok := lexer.Token{TokType: lexer.STR, Lexeme: node.Raw, TokPos: node.Pos}
+	var spans []rawSpan
+	content, err := decodeStringSpans(strTok, &spans)
+	if err != nil {
+		return nil, err
+	}
+
+	tokens, err := lexer.LexReaderContext(ctx, strings.NewReader(content), opts)
+	if err != nil {
+		return nil, err
+	}
+
+	positions := newEmbeddedPositions(strTok, content, spans)
+	for i := range tokens {
+		tokens[i].TokPos = positions.outerPosition(tokens[i])
+	}
+
+	return ParseJSONContext(ctx, tokens)
+}
+
+// embeddedPositions maps byte offsets within the content of a string to positions within the outer document
+type embeddedPositions struct {
+	line      int
+	colStart  []int // Column of the first raw rune written for the decoded byte
+	colEnd    []int // Column of the last raw rune written for the decoded byte
+	offset    []int // Byte offset of the first raw rune written for the decoded byte
+	endCol    int   // Column of the closing quote
+	endOffset int   // Byte offset of the closing quote
+}
+
+// newEmbeddedPositions builds the position mapping of the content decoded from the STR token.
+// A raw string can't contain a newline, so the whole string is on the token's line.
+func newEmbeddedPositions(strTok lexer.Token, content string, spans []rawSpan) embeddedPositions {
+	// Byte offset of each raw rune within the outer document (the content starts after the opening quote)
+	rawOffsets := make([]int, 0, len(strTok.Lexeme))
+	for offset := range strTok.Lexeme {
+		rawOffsets = append(rawOffsets, strTok.TokPos.Offset+1+offset)
+	}
+
+	positions := embeddedPositions{
+		line:      strTok.TokPos.Line,
+		colStart:  make([]int, len(content)),
+		colEnd:    make([]int, len(content)),
+		offset:    make([]int, len(content)),
+		endCol:    strTok.TokPos.ColEnd,
+		endOffset: strTok.TokPos.Offset + 1 + len(strTok.Lexeme),
+	}
+
+	// spans holds one entry per decoded rune, in order
+	spanIdx := 0
+	for offset, r := range content {
+		span := spans[spanIdx]
+		for b := offset; b < offset+utf8.RuneLen(r); b++ {
+			positions.colStart[b] = strTok.TokPos.ColStart + 1 + span.start
+			positions.colEnd[b] = strTok.TokPos.ColStart + 1 + span.end
+			positions.offset[b] = rawOffsets[span.start]
+		}
+		spanIdx++
+	}
+
+	return positions
+}
+
+// outerPosition returns the position within the outer document of a token lexed from the content
+func (p embeddedPositions) outerPosition(tok lexer.Token) lexer.TokenPosition {
+	start := tok.TokPos.Offset
+	if start >= len(p.colStart) {
+		// EOF, positioned at the closing quote
+		return lexer.TokenPosition{Line: p.line, ColStart: p.endCol, ColEnd: p.endCol, Offset: p.endOffset}
+	}
+
+	last := tok.EndOffset() - 1
+	if last < start {
+		last = start
+	}
+	if last >= len(p.colEnd) {
+		last = len(p.colEnd) - 1
+	}
+
+	return lexer.TokenPosition{Line: p.line, ColStart: p.colStart[start], ColEnd: p.colEnd[last], Offset: p.offset[start]}
+}
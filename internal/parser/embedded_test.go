@@ -0,0 +1,92 @@
+package parser
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/pszponder/json-linter_go/internal/lexer"
+)
+
+func TestParseEmbedded(t *testing.T) {
+	// Define test cases, the embedded document is the value of "payload"
+	testCases := []struct {
+		name             string
+		input            string
+		expectedErrorMsg string // Empty if the embedded document is valid
+	}{
+		{name: "valid object", input: `{"payload": "{\"id\": 1, \"tags\": [\"a\"]}"}`},
+		{name: "valid scalar", input: `{"payload": "42"}`},
+		{name: "escaped newlines", input: `{"payload": "{\n\t\"id\": 1\n}"}`},
+		// Positions point at the escape sequences within the outer document
+		{
+			name:             "missing comma",
+			input:            `{"payload": "{\"id\": 1 \"name\": 2}"}`,
+			expectedErrorMsg: "Expected ',' or '}' after object member, got 'name' at line 1, Column 25:32",
+		},
+		{
+			name:             "unicode escapes",
+			input:            `{"payload": "[\u0031, 2,]"}`,
+			expectedErrorMsg: "Invalid JSON Array, trailing comma not allowed at Line 1, Column 24:24",
+		},
+		{
+			name:             "unterminated",
+			input:            `{"payload": "[1, 2"}`,
+			expectedErrorMsg: "got 'EOF' at line 1, Column 19:19",
+		},
+		{name: "not a string", input: `{"payload": {}}`, expectedErrorMsg: "Expected a String containing embedded JSON, got Object at line 1, Column 13:13"},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			root, err := ParseJSON(lex(testCase.input))
+			if err != nil {
+				t.Fatalf("Expected no parse error for the outer document, got %v", err)
+			}
+			node, err := Lookup(root, "payload")
+			if err != nil {
+				t.Fatalf("Expected no lookup error, got %v", err)
+			}
+
+			_, err = ParseEmbedded(context.Background(), node, lexer.Options{})
+			if testCase.expectedErrorMsg == "" {
+				if err != nil {
+					t.Errorf("Expected no error, got %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), testCase.expectedErrorMsg) {
+				t.Errorf("Expected error containing %q, got %v", testCase.expectedErrorMsg, err)
+			}
+		})
+	}
+}
+
+func TestParseEmbeddedPositions(t *testing.T) {
+	root, err := ParseJSON(lex(`{"payload": "{\"id\": \"é\"}"}`))
+	if err != nil {
+		t.Fatalf("Expected no parse error, got %v", err)
+	}
+	node, err := Lookup(root, "payload")
+	if err != nil {
+		t.Fatalf("Expected no lookup error, got %v", err)
+	}
+
+	inner, err := ParseEmbedded(context.Background(), node, lexer.Options{})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	// The key "id" is written as \"id\" starting at column 15, its value as \"é\" spanning columns 23 to 27
+	expected := []lexer.TokenPosition{
+		{Line: 1, ColStart: 14, ColEnd: 14, Offset: 13},
+		{Line: 1, ColStart: 15, ColEnd: 20, Offset: 14},
+		{Line: 1, ColStart: 23, ColEnd: 27, Offset: 22},
+	}
+	actual := []lexer.TokenPosition{inner.Pos, inner.Children[0].Pos, inner.Children[1].Pos}
+	for i := range expected {
+		if expected[i] != actual[i] {
+			t.Errorf("Expected position %v, got %v", expected[i], actual[i])
+		}
+	}
+}
@@ -0,0 +1,37 @@
+package parser
+
+// RuleEmptyContainer is the name of the lint rule reporting empty objects or arrays
+const RuleEmptyContainer = "empty-container"
+
+// CheckEmptyContainers walks the AST and returns a warning positioned at the opener of each empty object
+// (if forbidObject) and each empty array (if forbidArray), at any nesting level.
+func CheckEmptyContainers(node *ASTNode, forbidObject, forbidArray bool) []ParseError {
+	if !forbidObject && !forbidArray {
+		return nil
+	}
+
+	var warnings []ParseError
+	WalkPaths(node, func(node *ASTNode, path string) bool {
+		if len(node.Children) > 0 {
+			return true
+		}
+		if node.Type == "Object" && forbidObject {
+			warnings = append(warnings, emptyContainer(node, path, "object"))
+		} else if node.Type == "Array" && forbidArray {
+			warnings = append(warnings, emptyContainer(node, path, "array"))
+		}
+		return true
+	})
+	return warnings
+}
+
+// emptyContainer returns the warning for the empty container (named kind) located at path
+func emptyContainer(node *ASTNode, path string, kind string) ParseError {
+	return ParseError{
+		Severity: SeverityWarning,
+		Rule:     RuleEmptyContainer,
+		Message:  "Empty " + kind + " not allowed",
+		Pos:      node.Pos,
+		Path:     path,
+	}
+}
@@ -0,0 +1,76 @@
+package parser
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCheckEmptyContainers(t *testing.T) {
+	input := "{\"a\": {}, \"b\": [],\n \"c\": [{}, [[]], {\"d\": {}}], \"e\": [1]}"
+
+	// Define test cases
+	testCases := []struct {
+		name             string
+		input            string
+		forbidObject     bool
+		forbidArray      bool
+		expectedWarnings []string
+		expectedPaths    []string
+	}{
+		{name: "allowed", input: input},
+		{
+			name:             "forbid objects",
+			input:            input,
+			forbidObject:     true,
+			expectedWarnings: []string{"Empty object not allowed at line 1, Column 7:7", "Empty object not allowed at line 2, Column 8:8", "Empty object not allowed at line 2, Column 24:24"},
+			expectedPaths:    []string{"$.a", "$.c[0]", "$.c[2].d"},
+		},
+		{
+			name:             "forbid arrays",
+			input:            input,
+			forbidArray:      true,
+			expectedWarnings: []string{"Empty array not allowed at line 1, Column 16:16", "Empty array not allowed at line 2, Column 13:13"},
+			expectedPaths:    []string{"$.b", "$.c[1][0]"},
+		},
+		{
+			name:         "forbid both",
+			input:        input,
+			forbidObject: true,
+			forbidArray:  true,
+			expectedWarnings: []string{
+				"Empty object not allowed at line 1, Column 7:7",
+				"Empty array not allowed at line 1, Column 16:16",
+				"Empty object not allowed at line 2, Column 8:8",
+				"Empty array not allowed at line 2, Column 13:13",
+				"Empty object not allowed at line 2, Column 24:24",
+			},
+			expectedPaths: []string{"$.a", "$.b", "$.c[0]", "$.c[1][0]", "$.c[2].d"},
+		},
+		{name: "empty root", input: `[]`, forbidArray: true, expectedWarnings: []string{"Empty array not allowed at line 1, Column 1:1"}, expectedPaths: []string{"$"}},
+		{name: "non-empty", input: `{"a": [1, {"b": null}]}`, forbidObject: true, forbidArray: true},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			node, err := ParseJSON(lex(testCase.input))
+			if err != nil {
+				t.Fatalf("Expected no parse error, got %v", err)
+			}
+
+			var actualWarnings, actualPaths []string
+			for _, finding := range CheckEmptyContainers(node, testCase.forbidObject, testCase.forbidArray) {
+				if finding.Severity != SeverityWarning || finding.Rule != RuleEmptyContainer {
+					t.Errorf("Expected a %s warning, got %s %s", RuleEmptyContainer, finding.Severity, finding.Rule)
+				}
+				actualWarnings = append(actualWarnings, finding.Error())
+				actualPaths = append(actualPaths, finding.Path)
+			}
+			if !reflect.DeepEqual(testCase.expectedWarnings, actualWarnings) {
+				t.Errorf("Expected warnings %q, got %q", testCase.expectedWarnings, actualWarnings)
+			}
+			if !reflect.DeepEqual(testCase.expectedPaths, actualPaths) {
+				t.Errorf("Expected paths %q, got %q", testCase.expectedPaths, actualPaths)
+			}
+		})
+	}
+}
@@ -0,0 +1,21 @@
+package parser
+
+import "github.com/pszponder/json-linter_go/internal/lexer"
+
+// RuleInvalidUTF8 is the name of the lint rule reporting invalid UTF-8 bytes replaced by U+FFFD
+const RuleInvalidUTF8 = "invalid-utf8"
+
+// CheckReplacements returns a warning for each invalid UTF-8 byte the lexer replaced by U+FFFD
+// (see lexer.UTF8Replace), positioned at the byte.
+func CheckReplacements(replacements []lexer.LexerPosition) []ParseError {
+	var warnings []ParseError
+	for _, pos := range replacements {
+		warnings = append(warnings, ParseError{
+			Severity: SeverityWarning,
+			Rule:     RuleInvalidUTF8,
+			Message:  "Invalid UTF-8 byte replaced by U+FFFD",
+			Pos:      lexer.TokenPosition{Line: pos.Line, ColStart: pos.Column, ColEnd: pos.Column, Offset: pos.Offset},
+		})
+	}
+	return warnings
+}
@@ -0,0 +1,36 @@
+package parser
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/pszponder/json-linter_go/internal/lexer"
+)
+
+func TestCheckReplacements(t *testing.T) {
+	lxr := lexer.NewLexer(strings.NewReader("{\"a\": \"\xff\",\n \"b\xfe\": 1}"), lexer.Options{InvalidUTF8: lexer.UTF8Replace})
+	tokens, err := lxr.ReadAll(context.Background())
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	// The document is still valid
+	if _, err := ParseJSON(tokens); err != nil {
+		t.Fatalf("Expected no parse error, got %v", err)
+	}
+
+	warnings := CheckReplacements(lxr.Replacements)
+	expected := []string{
+		"Invalid UTF-8 byte replaced by U+FFFD at line 1, Column 8:8",
+		"Invalid UTF-8 byte replaced by U+FFFD at line 2, Column 4:4",
+	}
+	if len(warnings) != len(expected) {
+		t.Fatalf("Expected %d warnings, got %v", len(expected), warnings)
+	}
+	for i, warning := range warnings {
+		if warning.Error() != expected[i] || warning.Rule != RuleInvalidUTF8 || warning.Severity != SeverityWarning {
+			t.Errorf("Expected %s warning %q, got %+v", RuleInvalidUTF8, expected[i], warning)
+		}
+	}
+}
@@ -0,0 +1,56 @@
+package parser
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// RuleEnum is the name of the lint rule reporting values outside of the allowed set of their path
+const RuleEnum = "enum"
+
+// Enum restricts the value located at Path to one of the strings of Values
+type Enum struct {
+	Path   string // Path of the value, as accepted by Lookup (ex. "status" or "users[0].role")
+	Values []string
+}
+
+// CheckEnums returns an error for each enum whose value isn't one of its allowed strings, positioned at the value.
+// A path w/o a value is reported as an error positioned at the root.
+func CheckEnums(node *ASTNode, enums []Enum) []ParseError {
+	var errs []ParseError
+	for _, enum := range enums {
+		path := rootedPath(enum.Path)
+		value, err := Lookup(node, enum.Path)
+		if err != nil {
+			errs = append(errs, ParseError{Severity: SeverityError, Rule: RuleEnum, Message: err.Error(), Pos: node.Pos, Path: path})
+			continue
+		}
+
+		if s, ok := value.Value.(string); ok && value.Type == "String" && slices.Contains(enum.Values, s) {
+			continue
+		}
+		errs = append(errs, ParseError{
+			Severity: SeverityError,
+			Rule:     RuleEnum,
+			Message:  fmt.Sprintf("Value %s at '%s' is not one of the allowed values (%s)", compactJSON(value), enum.Path, strings.Join(enum.Values, ", ")),
+			Pos:      value.Pos,
+			Path:     path,
+		})
+	}
+
+	return errs
+}
+
+// rootedPath returns the path accepted by Lookup in the "$"-rooted form of ParseError.Path (ex. "users[0].role" => "$.users[0].role")
+func rootedPath(path string) string {
+	path = strings.TrimPrefix(strings.TrimPrefix(path, "$"), ".")
+	switch {
+	case path == "":
+		return "$"
+	case strings.HasPrefix(path, "["):
+		return "$" + path
+	default:
+		return "$." + path
+	}
+}
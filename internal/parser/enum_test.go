@@ -0,0 +1,66 @@
+package parser
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCheckEnums(t *testing.T) {
+	input := "{\"status\": \"active\",\n \"users\": [{\"role\": \"root\"}], \"level\": 3}"
+	statuses := []string{"active", "inactive", "pending"}
+
+	// Define test cases
+	testCases := []struct {
+		name           string
+		enums          []Enum
+		expectedErrors []string
+		expectedPaths  []string
+	}{
+		{name: "no enums"},
+		{name: "matching value", enums: []Enum{{Path: "status", Values: statuses}}},
+		{name: "rooted path", enums: []Enum{{Path: "$.status", Values: statuses}}},
+		{
+			name:           "non-matching value",
+			enums:          []Enum{{Path: "users[0].role", Values: []string{"admin", "user"}}},
+			expectedErrors: []string{"Value \"root\" at 'users[0].role' is not one of the allowed values (admin, user) at line 2, Column 21:26"},
+			expectedPaths:  []string{"$.users[0].role"},
+		},
+		{
+			name:           "non-string value",
+			enums:          []Enum{{Path: "level", Values: []string{"3"}}},
+			expectedErrors: []string{"Value 3 at 'level' is not one of the allowed values (3) at line 2, Column 40:40"},
+			expectedPaths:  []string{"$.level"},
+		},
+		{
+			name:           "missing path",
+			enums:          []Enum{{Path: "state", Values: statuses}, {Path: "status", Values: statuses}},
+			expectedErrors: []string{"No value at path 'state', the Object at $ (line 1, Column 1) has no key 'state' at line 1, Column 1:1"},
+			expectedPaths:  []string{"$.state"},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			node, err := ParseJSON(lex(input))
+			if err != nil {
+				t.Fatalf("Expected no parse error, got %v", err)
+			}
+
+			var actualErrors, actualPaths []string
+			for _, finding := range CheckEnums(node, testCase.enums) {
+				if finding.Severity != SeverityError || finding.Rule != RuleEnum {
+					t.Errorf("Expected an error of the %s rule, got %v of %q", RuleEnum, finding.Severity, finding.Rule)
+				}
+				actualErrors = append(actualErrors, finding.Error())
+				actualPaths = append(actualPaths, finding.Path)
+			}
+
+			if !reflect.DeepEqual(testCase.expectedErrors, actualErrors) {
+				t.Errorf("Expected errors %q, got %q", testCase.expectedErrors, actualErrors)
+			}
+			if !reflect.DeepEqual(testCase.expectedPaths, actualPaths) {
+				t.Errorf("Expected paths %q, got %q", testCase.expectedPaths, actualPaths)
+			}
+		})
+	}
+}
@@ -0,0 +1,119 @@
+package parser
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/pszponder/json-linter_go/internal/lexer"
+)
+
+// Sentinel errors matched w/ errors.Is by the syntax errors of the parser, classifying them w/o parsing their message
+var (
+	// ErrUnexpectedToken is matched by the errors about a token which can't appear where it is (ex. the 2nd ',' in [1,,2])
+	ErrUnexpectedToken = errors.New("unexpected token")
+	// ErrUnexpectedEOF is matched by the errors about a document ending before its last value is complete (ex. [1, 2)
+	ErrUnexpectedEOF = errors.New("unexpected end of input")
+	// ErrTrailingComma is matched by the errors about a comma following the last member or element (ex. [1, 2,])
+	ErrTrailingComma = errors.New("trailing comma")
+)
+
+// Severity classifies how serious a ParseError is
+type Severity int
+
+const (
+	SeverityError   Severity = iota // The document is invalid
+	SeverityWarning                 // The document is valid, but a lint rule found a problem
+)
+
+// String returns the name of the severity
+func (s Severity) String() string {
+	if s == SeverityWarning {
+		return "warning"
+	}
+	return "error"
+}
+
+// ParseError describes a problem found in a JSON document along w/ its position
+type ParseError struct {
+	Severity Severity
+	Rule     string // Name of the lint rule which reported the problem (ex. "duplicate-keys"), empty for syntax errors
+	Message  string
+	Pos      lexer.TokenPosition
+	Path     string // JSONPath-like location of the value the problem was found in (ex. "$.users[3].email")
+	Err      error  // Syntax error which stopped parsing, its message already includes the position (nil for lint findings)
+}
+
+// Error formats the ParseError in the same style as the parser's other error messages
+func (e ParseError) Error() string {
+	if e.Err != nil {
+		return e.Err.Error()
+	}
+	return fmt.Sprintf("%s at line %d, Column %d:%d", e.Message, e.Pos.Line, e.Pos.ColStart, e.Pos.ColEnd)
+}
+
+// Unwrap returns the syntax error which stopped parsing, if any
+func (e ParseError) Unwrap() error {
+	return e.Err
+}
+
+// SyntaxError is returned by the parser for an invalid document.
+// It adds the JSONPath-like location of the innermost value being parsed to the underlying error.
+type SyntaxError struct {
+	Path string              // Location of the value being parsed (ex. "$.users[3].email"), "$" for the root
+	Pos  lexer.TokenPosition // Position of the token being parsed when parsing stopped
+	Err  error
+}
+
+// Error returns the message of the underlying error, which already includes the position
+func (e *SyntaxError) Error() string {
+	return e.Err.Error()
+}
+
+// Unwrap returns the underlying error
+func (e *SyntaxError) Unwrap() error {
+	return e.Err
+}
+
+// tokenError is a syntax error about a token, whose message already includes the position of the token.
+// It matches the sentinel errors classifying it, as well as the reason given by the lexer for an ILLEGAL token.
+type tokenError struct {
+	message string
+	errs    []error
+}
+
+// Error returns the message of the error
+func (e *tokenError) Error() string {
+	return e.message
+}
+
+// Unwrap returns the sentinel errors & the reason given by the lexer, if any
+func (e *tokenError) Unwrap() []error {
+	return e.errs
+}
+
+// unexpectedToken returns the error w/ the formatted message about tok, which matches ErrUnexpectedEOF if tok is
+// the end of the input & ErrUnexpectedToken otherwise, as well as tok.Err & the extra sentinel errors
+func unexpectedToken(tok lexer.Token, extra []error, format string, args ...any) error {
+	sentinel := ErrUnexpectedToken
+	if tok.TokType == lexer.EOF {
+		sentinel = ErrUnexpectedEOF
+	}
+
+	errs := append([]error{sentinel}, extra...)
+	if tok.Err != nil {
+		errs = append(errs, tok.Err)
+	}
+	return &tokenError{message: fmt.Sprintf(format, args...), errs: errs}
+}
+
+// atPath wraps the error into a SyntaxError located at path.
+// Errors which are already located (by a deeper value) and context errors are returned unchanged.
+func atPath(err error, path string) error {
+	var syntaxErr *SyntaxError
+	if err == nil || errors.As(err, &syntaxErr) ||
+		errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return err
+	}
+	return &SyntaxError{Path: path, Err: err}
+}
@@ -0,0 +1,62 @@
+package parser
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/pszponder/json-linter_go/internal/lexer"
+)
+
+func TestSyntaxErrorSentinels(t *testing.T) {
+	sentinels := []error{ErrUnexpectedToken, ErrUnexpectedEOF, ErrTrailingComma, lexer.ErrUnterminatedString, lexer.ErrInvalidNumber}
+
+	// Define test cases
+	testCases := []struct {
+		input             string
+		expectedSentinels []error // Sentinel errors the error must match, it must not match the other ones
+	}{
+		{input: `[1,,2]`, expectedSentinels: []error{ErrUnexpectedToken}},
+		{input: `{"a" 1}`, expectedSentinels: []error{ErrUnexpectedToken}},
+		{input: `[1] 2`, expectedSentinels: []error{ErrUnexpectedToken}},
+		{input: `{"a": 1]`, expectedSentinels: []error{ErrUnexpectedToken}},
+		{input: `[1, 2`, expectedSentinels: []error{ErrUnexpectedEOF}},
+		{input: `{"a":`, expectedSentinels: []error{ErrUnexpectedEOF}},
+		{input: `[1, 2,]`, expectedSentinels: []error{ErrUnexpectedToken, ErrTrailingComma}},
+		{input: `{"a": 1,}`, expectedSentinels: []error{ErrUnexpectedToken, ErrTrailingComma}},
+		// The reason given by the lexer for an ILLEGAL token is matched as well
+		{input: `["abc`, expectedSentinels: []error{ErrUnexpectedToken, lexer.ErrUnterminatedString}},
+		{input: `{"a": 01}`, expectedSentinels: []error{ErrUnexpectedToken, lexer.ErrInvalidNumber}},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.input, func(t *testing.T) {
+			_, err := ParseJSON(lex(testCase.input))
+			if err == nil {
+				t.Fatal("Expected an error, got nil")
+			}
+
+			for _, sentinel := range sentinels {
+				expected := false
+				for _, expectedSentinel := range testCase.expectedSentinels {
+					expected = expected || sentinel == expectedSentinel
+				}
+				if errors.Is(err, sentinel) != expected {
+					t.Errorf("Expected errors.Is(err, %q) to be %v, got %v (%v)", sentinel, expected, !expected, err)
+				}
+			}
+		})
+	}
+
+	// The sentinel errors are matched through the ParseError reported by Parse, along w/ the SyntaxError
+	_, errs := NewParser(lex(`{"a": [1, 2,]}`), ParseOptions{}).Parse()
+	if len(errs) != 1 {
+		t.Fatalf("Expected 1 error, got %v", errs)
+	}
+	if !errors.Is(errs[0], ErrTrailingComma) {
+		t.Errorf("Expected the error to match %q, got %v", ErrTrailingComma, errs[0])
+	}
+	var syntaxErr *SyntaxError
+	if !errors.As(errs[0], &syntaxErr) || syntaxErr.Path != "$.a" {
+		t.Errorf("Expected a *SyntaxError at $.a, got %#v", errs[0])
+	}
+}
@@ -0,0 +1,124 @@
+package parser
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf16"
+
+	"github.com/pszponder/json-linter_go/internal/lexer"
+)
+
+// escapes maps the character following a backslash to the rune it represents.
+// The \u escape is handled separately as it is followed by 4 hex digits.
+var escapes = map[rune]rune{
+	'"':  '"',
+	'\\': '\\',
+	'/':  '/',
+	'b':  '\b',
+	'f':  '\f',
+	'n':  '\n',
+	'r':  '\r',
+	't':  '\t',
+}
+
+// rawSpan is the range of runes (inclusive indices within the lexeme) which a decoded rune was written as
+type rawSpan struct {
+	start, end int
+}
+
+// decodeString decodes the escape sequences in the lexeme of a STR token
+// and returns the string value it represents.
+//
+// Returns an error if the lexeme contains an invalid escape sequence or an unescaped control character.
+func decodeString(tok lexer.Token) (string, error) {
+	return decodeStringSpans(tok, nil)
+}
+
+// decodeStringSpans is like decodeString, but also records the rawSpan of each decoded rune in spans if not nil
+func decodeStringSpans(tok lexer.Token, spans *[]rawSpan) (string, error) {
+	runes := []rune(tok.Lexeme)
+
+	var sb strings.Builder
+	start := 0 // Index of the first raw rune of the decoded rune being written
+	write := func(r rune, end int) {
+		sb.WriteRune(r)
+		if spans != nil {
+			*spans = append(*spans, rawSpan{start: start, end: end})
+		}
+	}
+
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		start = i
+
+		// Control characters must be escaped within JSON strings
+		if r < 0x20 {
+			return "", fmt.Errorf("Invalid JSON string, unescaped control character %U at line %d, Column %d:%d",
+				r, tok.TokPos.Line, tok.TokPos.ColStart, tok.TokPos.ColEnd)
+		}
+
+		if r != '\\' {
+			write(r, i)
+			continue
+		}
+
+		// Consume '\'
+		i++
+		if i >= len(runes) {
+			return "", fmt.Errorf("Invalid JSON string, incomplete escape sequence at line %d, Column %d:%d",
+				tok.TokPos.Line, tok.TokPos.ColStart, tok.TokPos.ColEnd)
+		}
+
+		if escaped, ok := escapes[runes[i]]; ok {
+			write(escaped, i)
+			continue
+		}
+
+		if runes[i] != 'u' {
+			return "", fmt.Errorf("Invalid JSON string, unknown escape sequence '\\%c' at line %d, Column %d:%d",
+				runes[i], tok.TokPos.Line, tok.TokPos.ColStart, tok.TokPos.ColEnd)
+		}
+
+		r1, ok := readHex4(runes, i+1)
+		if !ok {
+			return "", fmt.Errorf("Invalid JSON string, expected 4 hex digits after '\\u' at line %d, Column %d:%d",
+				tok.TokPos.Line, tok.TokPos.ColStart, tok.TokPos.ColEnd)
+		}
+		i += 4
+
+		// Combine UTF-16 surrogate pairs into a single rune
+		if utf16.IsSurrogate(r1) && i+2 < len(runes) && runes[i+1] == '\\' && runes[i+2] == 'u' {
+			if r2, ok := readHex4(runes, i+3); ok {
+				if combined := utf16.DecodeRune(r1, r2); combined != unicode.ReplacementChar {
+					i += 6
+					write(combined, i)
+					continue
+				}
+			}
+		}
+
+		// Lone surrogates are replaced w/ the Unicode replacement character (same as encoding/json)
+		if utf16.IsSurrogate(r1) {
+			r1 = unicode.ReplacementChar
+		}
+		write(r1, i)
+	}
+
+	return sb.String(), nil
+}
+
+// readHex4 reads 4 hex digits starting at position start and returns the rune they represent.
+func readHex4(runes []rune, start int) (rune, bool) {
+	if start+4 > len(runes) {
+		return 0, false
+	}
+
+	value, err := strconv.ParseUint(string(runes[start:start+4]), 16, 32)
+	if err != nil {
+		return 0, false
+	}
+
+	return rune(value), true
+}
@@ -0,0 +1,72 @@
+package parser
+
+import "testing"
+
+func TestParseJSONStringEscapes(t *testing.T) {
+	// Define test cases
+	testCases := []struct {
+		input          string
+		expectedLexeme string // Raw lexeme of the STR token (w/o the quotes)
+		expectedValue  string // Decoded value of the String node
+	}{
+		{input: `"a\bb"`, expectedLexeme: `a\bb`, expectedValue: "a\bb"},
+		{input: `"a\fb"`, expectedLexeme: `a\fb`, expectedValue: "a\fb"},
+		{input: `"\b\f"`, expectedLexeme: `\b\f`, expectedValue: "\u0008\u000c"},
+		{input: `"\"\\\/\n\r\t"`, expectedLexeme: `\"\\\/\n\r\t`, expectedValue: "\"\\/\n\r\t"},
+		{input: `"\u0041\u00e9"`, expectedLexeme: `\u0041\u00e9`, expectedValue: "Aé"},
+		{input: `"a\u0000b"`, expectedLexeme: `a\u0000b`, expectedValue: "a\x00b"},
+		// The quote escaped right before the closing one doesn't terminate the string
+		{input: `"\""`, expectedLexeme: `\"`, expectedValue: `"`},
+		{input: `"\\"`, expectedLexeme: `\\`, expectedValue: `\`},
+		{input: `"\\\""`, expectedLexeme: `\\\"`, expectedValue: `\"`},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.input, func(t *testing.T) {
+			tokens := lex(testCase.input)
+			if tokens[0].Lexeme != testCase.expectedLexeme {
+				t.Errorf("Expected lexeme %q, got %q", testCase.expectedLexeme, tokens[0].Lexeme)
+			}
+
+			node, err := ParseJSON(tokens)
+			if err != nil {
+				t.Fatalf("Expected no parse error, got %v", err)
+			}
+			if node.Value != testCase.expectedValue {
+				t.Errorf("Expected value %q, got %q", testCase.expectedValue, node.Value)
+			}
+		})
+	}
+
+	// \b & \f decode to backspace (U+0008) & form feed (U+000C)
+	node, err := ParseJSON(lex(`"\b\f"`))
+	if err != nil {
+		t.Fatalf("Expected no parse error, got %v", err)
+	}
+	if runes := []rune(node.Value.(string)); len(runes) != 2 || runes[0] != 0x08 || runes[1] != 0x0C {
+		t.Errorf("Expected runes [U+0008 U+000C], got %U", runes)
+	}
+
+	// \u0000 decodes to a NUL rune w/o terminating the string
+	tokens := lex(`["a\u0000b", 1]`)
+	node, err = ParseJSON(tokens)
+	if err != nil {
+		t.Fatalf("Expected no parse error, got %v", err)
+	}
+	value := node.Children[0].Value.(string)
+	if len(value) != 3 || value[1] != 0 {
+		t.Errorf("Expected value of length 3 w/ a NUL in the middle, got %q", value)
+	}
+	if len(node.Children) != 2 || node.Children[1].Raw != "1" {
+		t.Errorf("Expected the element after the string to be parsed, got %v", node.Children)
+	}
+
+	// A string made only of an escaped quote is followed by the next element, not merged w/ it
+	node, err = ParseJSON(lex(`["\"", "a"]`))
+	if err != nil {
+		t.Fatalf("Expected no parse error, got %v", err)
+	}
+	if len(node.Children) != 2 || node.Children[0].Value != `"` || node.Children[1].Value != "a" {
+		t.Errorf("Expected the elements [%q %q], got %v", `"`, "a", node.Children)
+	}
+}
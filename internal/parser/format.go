@@ -0,0 +1,97 @@
+package parser
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Format formats the AST as JSON w/ each member & element on its own line, indented by indent per nesting level
+// (ex. "\t" or "  "). An empty indent still puts each member & element on its own line, w/o leading whitespace.
+//
+// Literals are kept as written (ex. 1.0 & "A" are not normalized), except hexadecimal integers (lexed in relaxed mode)
+// which are written in decimal as JSON has no hexadecimal numbers. Empty containers are written as {} & [].
+func Format(node *ASTNode, indent string) string {
+	var sb strings.Builder
+	writeFormatted(&sb, node, indent, 0)
+	sb.WriteString("\n")
+	return sb.String()
+}
+
+// writeFormatted writes the node, nested level times within containers, as formatted JSON to sb
+func writeFormatted(sb *strings.Builder, node *ASTNode, indent string, level int) {
+	if (node.Type != "Object" && node.Type != "Array") || len(node.Children) == 0 {
+		writeCompact(sb, node)
+		return
+	}
+
+	opener, closer := "{", "}"
+	step := 1 // Number of children making up a member or an element
+	if node.Type == "Array" {
+		opener, closer = "[", "]"
+	} else {
+		step = 2
+	}
+
+	sb.WriteString(opener)
+	for i := 0; i < len(node.Children); i += step {
+		if i > 0 {
+			sb.WriteString(",")
+		}
+		sb.WriteString("\n" + strings.Repeat(indent, level+1))
+		if step == 2 {
+			writeLiteral(sb, node.Children[i])
+			sb.WriteString(": ")
+		}
+		writeFormatted(sb, node.Children[i+step-1], indent, level+1)
+	}
+	sb.WriteString("\n" + strings.Repeat(indent, level) + closer)
+}
+
+// compactJSON formats the node as JSON w/o any whitespace, keeping the literals as written
+func compactJSON(node *ASTNode) string {
+	var sb strings.Builder
+	writeCompact(&sb, node)
+	return sb.String()
+}
+
+// writeCompact writes the node as compact JSON to sb
+func writeCompact(sb *strings.Builder, node *ASTNode) {
+	switch node.Type {
+	case "Object", "Array":
+		opener, closer := "{", "}"
+		if node.Type == "Array" {
+			opener, closer = "[", "]"
+		}
+		sb.WriteString(opener)
+		for i, child := range node.Children {
+			switch {
+			case node.Type == "Object" && i%2 == 1:
+				sb.WriteString(":")
+			case i > 0:
+				sb.WriteString(",")
+			}
+			writeCompact(sb, child)
+		}
+		sb.WriteString(closer)
+	default:
+		writeLiteral(sb, node)
+	}
+}
+
+// writeLiteral writes the literal of a node which is neither an object nor an array to sb
+func writeLiteral(sb *strings.Builder, node *ASTNode) {
+	switch node.Type {
+	case "Key", "String":
+		sb.WriteString(`"` + node.Raw + `"`)
+	case "Number":
+		if isHexLiteral(node.Raw) {
+			sb.WriteString(decimalHexLiteral(node.Raw))
+		} else {
+			sb.WriteString(node.Raw)
+		}
+	case "Boolean":
+		fmt.Fprintf(sb, "%t", node.Value)
+	default:
+		sb.WriteString("null")
+	}
+}
@@ -0,0 +1,122 @@
+package parser
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/pszponder/json-linter_go/internal/lexer"
+)
+
+func TestFormat(t *testing.T) {
+	input := `{"name": "jl", "tags": ["cli", {"a": 1.0}], "empty": {}, "none": [], "ok": true, "nil": null}`
+
+	// Define test cases
+	testCases := []struct {
+		name     string
+		indent   string
+		expected string
+	}{
+		{
+			name:   "2 spaces",
+			indent: "  ",
+			expected: `{
+  "name": "jl",
+  "tags": [
+    "cli",
+    {
+      "a": 1.0
+    }
+  ],
+  "empty": {},
+  "none": [],
+  "ok": true,
+  "nil": null
+}
+`,
+		},
+		{
+			name:   "4 spaces",
+			indent: "    ",
+			expected: `{
+    "name": "jl",
+    "tags": [
+        "cli",
+        {
+            "a": 1.0
+        }
+    ],
+    "empty": {},
+    "none": [],
+    "ok": true,
+    "nil": null
+}
+`,
+		},
+		{
+			name:     "tab",
+			indent:   "\t",
+			expected: "{\n\t\"name\": \"jl\",\n\t\"tags\": [\n\t\t\"cli\",\n\t\t{\n\t\t\t\"a\": 1.0\n\t\t}\n\t],\n\t\"empty\": {},\n\t\"none\": [],\n\t\"ok\": true,\n\t\"nil\": null\n}\n",
+		},
+		{
+			name:   "no indent",
+			indent: "",
+			expected: `{
+"name": "jl",
+"tags": [
+"cli",
+{
+"a": 1.0
+}
+],
+"empty": {},
+"none": [],
+"ok": true,
+"nil": null
+}
+`,
+		},
+	}
+
+	rootNode, err := ParseJSON(lex(input))
+	if err != nil {
+		t.Fatalf("Expected no parse error, got %v", err)
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			if actual := Format(rootNode, testCase.indent); actual != testCase.expected {
+				t.Errorf("Expected:\n%s\ngot:\n%s", testCase.expected, actual)
+			}
+		})
+	}
+
+	// Scalars are written as is
+	rootNode, err = ParseJSON(lex(` "a\n" `))
+	if err != nil {
+		t.Fatalf("Expected no parse error, got %v", err)
+	}
+	if actual := Format(rootNode, "  "); actual != "\"a\\n\"\n" {
+		t.Errorf("Expected the string as written, got %q", actual)
+	}
+}
+
+func TestFormatHexNumbers(t *testing.T) {
+	tokens, err := lexer.LexReaderContext(context.Background(), strings.NewReader(`{"a": 0x1F, "b": [-0xff, 1.0]}`), lexer.Options{Relaxed: true})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	node, err := ParseJSON(tokens)
+	if err != nil {
+		t.Fatalf("Expected no parse error, got %v", err)
+	}
+
+	// Hexadecimal integers are written in decimal, so the output is JSON
+	expected := "{\n  \"a\": 31,\n  \"b\": [\n    -255,\n    1.0\n  ]\n}\n"
+	if actual := Format(node, "  "); actual != expected {
+		t.Errorf("Expected:\n%s\ngot:\n%s", expected, actual)
+	}
+	if actual := Format(NormalizeNumbers(node), "  "); actual != strings.Replace(expected, "1.0", "1", 1) {
+		t.Errorf("Expected normalized numbers, got:\n%s", actual)
+	}
+}
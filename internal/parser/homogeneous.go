@@ -0,0 +1,38 @@
+package parser
+
+import (
+	"fmt"
+	"strings"
+)
+
+// RuleHomogeneousArrays is the name of the lint rule reporting arrays which mix value types
+const RuleHomogeneousArrays = "homogeneous-arrays"
+
+// CheckHomogeneousArrays walks the AST and returns a warning for each array whose elements aren't all of the same type,
+// positioned at the first element whose type differs from the first element's (ex. the "a" in [1, 2, "a"]).
+// Objects are of the same type regardless of their keys, as are arrays regardless of their elements.
+func CheckHomogeneousArrays(node *ASTNode) []ParseError {
+	var warnings []ParseError
+
+	WalkPaths(node, func(node *ASTNode, path string) bool {
+		if node.Type != "Array" {
+			return true
+		}
+		for i, child := range node.Children {
+			if first := node.Children[0]; child.Type != first.Type {
+				warnings = append(warnings, ParseError{
+					Severity: SeverityWarning,
+					Rule:     RuleHomogeneousArrays,
+					Message: fmt.Sprintf("Array mixes element types, expected %s like the 1st element, got %s",
+						strings.ToLower(first.Type), strings.ToLower(child.Type)),
+					Pos:  child.Pos,
+					Path: IndexPath(path, i),
+				})
+				break
+			}
+		}
+		return true
+	})
+
+	return warnings
+}
@@ -0,0 +1,65 @@
+package parser
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCheckHomogeneousArrays(t *testing.T) {
+	// Define test cases
+	testCases := []struct {
+		name             string
+		input            string
+		expectedWarnings []string
+		expectedPaths    []string
+	}{
+		{name: "empty array", input: `[]`},
+		{name: "numbers", input: `[1, 2.5, -3e2]`},
+		{name: "booleans", input: `[true, false]`},
+		{name: "objects of different shapes", input: `[{"a": 1}, {"b": "x"}, {}]`},
+		{name: "arrays of different elements", input: `[[1], ["a"], []]`},
+		{
+			name:             "mixed",
+			input:            `[1, 2, "3", null]`,
+			expectedWarnings: []string{"Array mixes element types, expected number like the 1st element, got string at line 1, Column 8:10"},
+			expectedPaths:    []string{"$[2]"},
+		},
+		{
+			name:             "nested mixed",
+			input:            "{\"a\": [[1, 2], [\"x\",\n true]], \"b\": [{}, []]}",
+			expectedWarnings: []string{"Array mixes element types, expected string like the 1st element, got boolean at line 2, Column 2:5", "Array mixes element types, expected object like the 1st element, got array at line 2, Column 20:20"},
+			expectedPaths:    []string{"$.a[1][1]", "$.b[1]"},
+		},
+		// The outer array is reported before the elements it contains
+		{
+			name:             "mixed at both levels",
+			input:            `[[1, "a"], 2]`,
+			expectedWarnings: []string{"Array mixes element types, expected array like the 1st element, got number at line 1, Column 12:12", "Array mixes element types, expected number like the 1st element, got string at line 1, Column 6:8"},
+			expectedPaths:    []string{"$[1]", "$[0][1]"},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			node, err := ParseJSON(lex(testCase.input))
+			if err != nil {
+				t.Fatalf("Expected no parse error, got %v", err)
+			}
+
+			var actualWarnings, actualPaths []string
+			for _, finding := range CheckHomogeneousArrays(node) {
+				if finding.Severity != SeverityWarning || finding.Rule != RuleHomogeneousArrays {
+					t.Errorf("Expected a %s warning, got %s %s", RuleHomogeneousArrays, finding.Severity, finding.Rule)
+				}
+				actualWarnings = append(actualWarnings, finding.Error())
+				actualPaths = append(actualPaths, finding.Path)
+			}
+			if !reflect.DeepEqual(testCase.expectedWarnings, actualWarnings) {
+				t.Errorf("Expected warnings %q, got %q", testCase.expectedWarnings, actualWarnings)
+			}
+			if !reflect.DeepEqual(testCase.expectedPaths, actualPaths) {
+				t.Errorf("Expected paths %q, got %q", testCase.expectedPaths, actualPaths)
+			}
+		})
+	}
+}
@@ -0,0 +1,116 @@
+package parser
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pszponder/json-linter_go/internal/lexer"
+)
+
+// RuleMixedIndentation is the name of the lint rule reporting documents indented w/ both tabs & spaces
+const RuleMixedIndentation = "mixed-indentation"
+
+// CheckMixedIndentation returns a warning for the first line whose indentation doesn't match the indentation style
+// (tabs or spaces) of the first indented line, including a line indented w/ both.
+//
+// The indentation is read from the trivia of the tokens, so they must be lexed w/ lexer.Options.PreserveTrivia.
+func CheckMixedIndentation(tokens []lexer.Token) []ParseError {
+	var style string // "tabs" or "spaces", set by the first indented line
+	styleLine := 0
+
+	for i, tok := range tokens {
+		indent := lineIndent(tokens, i)
+		if indent == "" {
+			continue
+		}
+
+		lineStyle := "spaces"
+		switch {
+		case strings.Contains(indent, " ") && strings.Contains(indent, "\t"):
+			lineStyle = "tabs & spaces"
+		case strings.Contains(indent, "\t"):
+			lineStyle = "tabs"
+		}
+
+		var message string
+		switch {
+		case lineStyle == "tabs & spaces":
+			message = "Mixed indentation, line indented w/ both tabs & spaces"
+		case style == "":
+			style, styleLine = lineStyle, tok.TokPos.Line
+			continue
+		case lineStyle != style:
+			message = fmt.Sprintf("Mixed indentation, line indented w/ %s while line %d is indented w/ %s", lineStyle, styleLine, style)
+		default:
+			continue
+		}
+
+		return []ParseError{{
+			Severity: SeverityWarning,
+			Rule:     RuleMixedIndentation,
+			Message:  message,
+			Pos:      lexer.TokenPosition{Line: tok.TokPos.Line, ColStart: 1, ColEnd: len([]rune(indent)), Offset: tok.TokPos.Offset - len(indent)},
+		}}
+	}
+
+	return nil
+}
+
+// Indentation styles returned by DetectIndent besides tabs & spaces
+const (
+	IndentTabs    = "tabs"    // Lines indented w/ tabs only
+	IndentMixed   = "mixed"   // Lines indented w/ tabs & others w/ spaces, or w/ both on the same line
+	IndentUnknown = "unknown" // No line is indented (ex. a minified document)
+)
+
+// DetectIndent infers the indentation style of the document: IndentTabs, the number of spaces of a nesting level
+// (ex. "2 spaces", the greatest common divisor of the indentation widths), IndentMixed or IndentUnknown.
+//
+// The indentation is read from the trivia of the tokens, so they must be lexed w/ lexer.Options.PreserveTrivia.
+func DetectIndent(tokens []lexer.Token) string {
+	tabs, spaces := false, 0 // spaces is the GCD of the widths of the lines indented w/ spaces
+	for i := range tokens {
+		indent := lineIndent(tokens, i)
+		switch {
+		case indent == "":
+			continue
+		case strings.Trim(indent, "\t") == "":
+			tabs = true
+		case strings.Trim(indent, " ") == "":
+			spaces = gcd(spaces, len(indent))
+		default:
+			return IndentMixed
+		}
+	}
+
+	switch {
+	case tabs && spaces > 0:
+		return IndentMixed
+	case tabs:
+		return IndentTabs
+	case spaces == 1:
+		return "1 space"
+	case spaces > 0:
+		return fmt.Sprintf("%d spaces", spaces)
+	}
+	return IndentUnknown
+}
+
+// lineIndent returns the indentation of the line the token at index starts, or "" if it doesn't start a line
+func lineIndent(tokens []lexer.Token, index int) string {
+	// Only the first token of a line is preceded by the indentation of the line
+	leading := tokens[index].LeadingTrivia
+	startsLine := index == 0 || strings.HasSuffix(tokens[index-1].TrailingTrivia, "\n") || strings.Contains(leading, "\n")
+	if !startsLine {
+		return ""
+	}
+	return leading[strings.LastIndex(leading, "\n")+1:]
+}
+
+// gcd returns the greatest common divisor of a & b (b if a is 0)
+func gcd(a, b int) int {
+	for a != 0 {
+		a, b = b%a, a
+	}
+	return b
+}
@@ -0,0 +1,96 @@
+package parser
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/pszponder/json-linter_go/internal/lexer"
+)
+
+func TestCheckMixedIndentation(t *testing.T) {
+	// Define test cases
+	testCases := []struct {
+		name            string
+		input           string
+		expectedWarning string // Empty if no warning is expected
+	}{
+		{name: "tabs only", input: "{\n\t\"a\": [\n\t\t1\n\t]\n}\n"},
+		{name: "spaces only", input: "{\n  \"a\": [\n    1\n  ]\n}\n"},
+		{name: "not indented", input: "{\"a\": [1, 2]}"},
+		// Blank lines & whitespace between tokens on the same line don't count as indentation
+		{name: "whitespace within lines", input: "{\n\t\"a\":  [1,\t2],\n  \n\t\"b\": 1\n}"},
+		{
+			name:            "spaces after tabs",
+			input:           "{\n\t\"a\": [\n\t\t1\n    ]\n}\n",
+			expectedWarning: "Mixed indentation, line indented w/ spaces while line 2 is indented w/ tabs at line 4, Column 1:4",
+		},
+		{
+			name:            "tabs after spaces",
+			input:           "[\n  1,\n  2,\n\t3\n]",
+			expectedWarning: "Mixed indentation, line indented w/ tabs while line 2 is indented w/ spaces at line 4, Column 1:1",
+		},
+		{
+			name:            "tabs & spaces on a line",
+			input:           "{\n\t \"a\": 1\n}",
+			expectedWarning: "Mixed indentation, line indented w/ both tabs & spaces at line 2, Column 1:2",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			tokens, err := lexer.LexReaderContext(context.Background(), strings.NewReader(testCase.input), lexer.Options{PreserveTrivia: true})
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+
+			warnings := CheckMixedIndentation(tokens)
+			if testCase.expectedWarning == "" {
+				if len(warnings) != 0 {
+					t.Errorf("Expected no warning, got %v", warnings)
+				}
+				return
+			}
+
+			if len(warnings) != 1 || warnings[0].Error() != testCase.expectedWarning {
+				t.Fatalf("Expected warning %q, got %v", testCase.expectedWarning, warnings)
+			}
+			if warnings[0].Severity != SeverityWarning || warnings[0].Rule != RuleMixedIndentation {
+				t.Errorf("Expected a %s warning, got %+v", RuleMixedIndentation, warnings[0])
+			}
+		})
+	}
+}
+
+func TestDetectIndent(t *testing.T) {
+	// Define test cases
+	testCases := []struct {
+		name          string
+		input         string
+		expectedStyle string
+	}{
+		{name: "2 spaces", input: "{\n  \"a\": [\n    1\n  ]\n}\n", expectedStyle: "2 spaces"},
+		{name: "4 spaces", input: "{\n    \"a\": [\n        1\n    ]\n}\n", expectedStyle: "4 spaces"},
+		{name: "1 space", input: "[\n 1,\n 2\n]", expectedStyle: "1 space"},
+		// The widths are multiples of the indentation of a nesting level, which may not be on a line of its own
+		{name: "deeper lines only", input: "{\"a\": {\"b\": [\n      1,\n      2\n    ]}\n}", expectedStyle: "2 spaces"},
+		{name: "tabs", input: "{\n\t\"a\": [\n\t\t1\n\t]\n}\n", expectedStyle: IndentTabs},
+		{name: "tabs & spaces on different lines", input: "{\n\t\"a\": [\n    1\n\t]\n}\n", expectedStyle: IndentMixed},
+		{name: "tabs & spaces on a line", input: "{\n\t \"a\": 1\n}", expectedStyle: IndentMixed},
+		{name: "not indented", input: "{\"a\": [1, 2]}", expectedStyle: IndentUnknown},
+		{name: "lines w/o indentation", input: "[\n1,\n2\n]", expectedStyle: IndentUnknown},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			tokens, err := lexer.LexReaderContext(context.Background(), strings.NewReader(testCase.input), lexer.Options{PreserveTrivia: true})
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+
+			if style := DetectIndent(tokens); style != testCase.expectedStyle {
+				t.Errorf("Expected indentation %q, got %q", testCase.expectedStyle, style)
+			}
+		})
+	}
+}
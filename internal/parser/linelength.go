@@ -0,0 +1,25 @@
+package parser
+
+import (
+	"fmt"
+
+	"github.com/pszponder/json-linter_go/internal/lexer"
+)
+
+// RuleMaxLineLength is the name of the lint rule reporting lines longer than the maximum line length
+const RuleMaxLineLength = "max-line-length"
+
+// CheckLineLength returns a warning for the first line longer than maxLength characters, as recorded by the LongLine
+// of the lexer (see lexer.Options.MaxLineLength), positioned at its first character past the limit.
+// Returns nil if no line is that long (longLine is the zero position).
+func CheckLineLength(longLine lexer.LexerPosition, maxLength int) []ParseError {
+	if longLine.Line == 0 {
+		return nil
+	}
+	return []ParseError{{
+		Severity: SeverityWarning,
+		Rule:     RuleMaxLineLength,
+		Message:  fmt.Sprintf("Line %d is longer than %d characters, the file may be minified", longLine.Line, maxLength),
+		Pos:      lexer.TokenPosition{Line: longLine.Line, ColStart: longLine.Column, ColEnd: longLine.Column, Offset: longLine.Offset},
+	}}
+}
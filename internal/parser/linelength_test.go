@@ -0,0 +1,46 @@
+package parser
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/pszponder/json-linter_go/internal/lexer"
+)
+
+func TestCheckLineLength(t *testing.T) {
+	// Define test cases
+	testCases := []struct {
+		name             string
+		input            string
+		expectedWarnings []string
+	}{
+		{name: "under the limit", input: "{\n  \"name\": \"jl\",\n  \"tags\": [\"json\"]\n}\n"},
+		{name: "over the limit", input: "{\"name\": \"jl\", \"tags\": [\"json\"]}\n", expectedWarnings: []string{"Line 1 is longer than 20 characters, the file may be minified at line 1, Column 21:21"}},
+		// Only the first long line is reported
+		{name: "several long lines", input: "[\n  \"short\",\n  \"a long string value\",\n  \"another long string value\"\n]", expectedWarnings: []string{"Line 3 is longer than 20 characters, the file may be minified at line 3, Column 21:21"}},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			lxr := lexer.NewLexer(strings.NewReader(testCase.input), lexer.Options{MaxLineLength: 20})
+			tokens, err := lxr.ReadAll(context.Background())
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if _, err := ParseJSON(tokens); err != nil {
+				t.Fatalf("Expected no parse error, got %v", err)
+			}
+
+			warnings := CheckLineLength(lxr.LongLine, 20)
+			if len(warnings) != len(testCase.expectedWarnings) {
+				t.Fatalf("Expected %d warnings, got %v", len(testCase.expectedWarnings), warnings)
+			}
+			for i, warning := range warnings {
+				if warning.Error() != testCase.expectedWarnings[i] || warning.Rule != RuleMaxLineLength || warning.Severity != SeverityWarning {
+					t.Errorf("Expected %s warning %q, got %+v", RuleMaxLineLength, testCase.expectedWarnings[i], warning)
+				}
+			}
+		})
+	}
+}
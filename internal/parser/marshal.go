@@ -0,0 +1,80 @@
+package parser
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+// MarshalJSON serializes the node (and its children) back to standard JSON, so the AST (ex. once modified)
+// can be handed to any API based on encoding/json, as a field of a struct being marshaled or a json.RawMessage.
+//
+// The members of objects keep their order (duplicate keys included), and strings are escaped like encoding/json does.
+// Numbers are written as a json.Number of their literal so they don't lose precision, or from their Value if the
+// literal is empty or isn't a decimal JSON number (ex. 0x1F lexed in relaxed mode). NaN & Infinity can't be marshaled.
+func (node *ASTNode) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	if err := marshalNode(&buf, node); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// marshalNode writes the standard JSON serialization of the node (and its children) to buf
+func marshalNode(buf *bytes.Buffer, node *ASTNode) error {
+	switch node.Type {
+	case "Object":
+		buf.WriteByte('{')
+		for i, member := range Members(node) {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			if err := marshalValue(buf, member.Key); err != nil {
+				return err
+			}
+			buf.WriteByte(':')
+			if err := marshalNode(buf, member.Value); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte('}')
+	case "Array":
+		buf.WriteByte('[')
+		for i, child := range node.Children {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			if err := marshalNode(buf, child); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte(']')
+	case "Number":
+		var value interface{} = json.Number(node.Raw)
+		if isHexLiteral(node.Raw) {
+			// Converted to decimal w/o losing precision, unlike the float64 value
+			value = json.Number(decimalHexLiteral(node.Raw))
+		} else if node.Raw == "" || isNonFiniteLiteral(node.Raw) {
+			value = node.Value
+		}
+		if err := marshalValue(buf, value); err != nil {
+			return fmt.Errorf("Cannot marshal number '%s' at line %d, Column %d:%d: %w",
+				node.Raw, node.Pos.Line, node.Pos.ColStart, node.Pos.ColEnd, err)
+		}
+	case "String", "Boolean", "Null":
+		return marshalValue(buf, node.Value)
+	default:
+		return fmt.Errorf("Cannot marshal AST node of type '%s'", node.Type)
+	}
+	return nil
+}
+
+// marshalValue writes the encoding/json serialization of a scalar value to buf
+func marshalValue(buf *bytes.Buffer, value interface{}) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	buf.Write(data)
+	return nil
+}
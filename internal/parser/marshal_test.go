@@ -0,0 +1,114 @@
+package parser
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/pszponder/json-linter_go/internal/lexer"
+)
+
+func TestMarshalJSON(t *testing.T) {
+	// Define test cases
+	testCases := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{name: "scalars", input: `[1, -2.50, 1E3, "a\"bé", true, false, null]`, expected: `[1,-2.50,1E3,"a\"bé",true,false,null]`},
+		{name: "explicitly positive exponent", input: `[1E+3, 2e+0]`, expected: `[1E+3,2e+0]`},
+		{name: "key order & duplicates", input: `{"b": 1, "a": {"c": []}, "b": {}}`, expected: `{"b":1,"a":{"c":[]},"b":{}}`},
+		// Numbers keep the precision a float64 would lose
+		{name: "precise numbers", input: `[12345678901234567890, 0.10000000000000000001]`, expected: `[12345678901234567890,0.10000000000000000001]`},
+		// Strings are escaped like encoding/json does
+		{name: "escaping", input: `"<a href=\"x\">&\n\t</a>"`, expected: `"\u003ca href=\"x\"\u003e\u0026\n\t\u003c/a\u003e"`},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			node, err := ParseJSON(lex(testCase.input))
+			if err != nil {
+				t.Fatalf("Expected no parse error, got %v", err)
+			}
+
+			data, err := json.Marshal(node)
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if !json.Valid(data) {
+				t.Errorf("Expected valid JSON, got %s", data)
+			}
+			if string(data) != testCase.expected {
+				t.Errorf("Expected %s, got %s", testCase.expected, data)
+			}
+		})
+	}
+}
+
+func TestMarshalJSONRoundTrip(t *testing.T) {
+	input := `{"name": "jl", "version": 1.10, "tags": ["json", "lint"], "limits": {"max": 9007199254740993, "ratio": -1.5e-3}, "owner": null}`
+	node, err := ParseJSON(lex(input))
+	if err != nil {
+		t.Fatalf("Expected no parse error, got %v", err)
+	}
+
+	// The AST can be embedded in values marshaled by encoding/json
+	data, err := json.Marshal(struct {
+		Document *ASTNode `json:"document"`
+	}{node})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !json.Valid(data) {
+		t.Fatalf("Expected valid JSON, got %s", data)
+	}
+
+	// The document decodes to the same values as the input, numbers included
+	var expected, actual struct {
+		Document interface{} `json:"document"`
+	}
+	decoder := json.NewDecoder(strings.NewReader(`{"document": ` + input + `}`))
+	decoder.UseNumber()
+	if err := decoder.Decode(&expected); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	decoder = json.NewDecoder(strings.NewReader(string(data)))
+	decoder.UseNumber()
+	if err := decoder.Decode(&actual); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !reflect.DeepEqual(expected, actual) {
+		t.Errorf("Expected %v, got %v", expected, actual)
+	}
+}
+
+func TestMarshalJSONNonDecimalNumbers(t *testing.T) {
+	// Hexadecimal integers are converted to decimal, exactly even past the precision of a float64
+	tokens, err := lexer.LexReaderContext(context.Background(), strings.NewReader(`[0x1F, -0xff, 0x1FFFFFFFFFFFFFFFFF]`), lexer.Options{Relaxed: true})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	node, err := ParseJSON(tokens)
+	if err != nil {
+		t.Fatalf("Expected no parse error, got %v", err)
+	}
+	if data, err := json.Marshal(node); err != nil || string(data) != `[31,-255,590295810358705651711]` {
+		t.Errorf("Expected [31,-255,590295810358705651711], got %s (%v)", data, err)
+	}
+
+	// NaN & Infinity have no standard JSON representation
+	tokens, err = lexer.LexReaderContext(context.Background(), strings.NewReader(`[1, NaN]`), lexer.Options{AllowNaNInf: true})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	node, err = ParseJSON(tokens)
+	if err != nil {
+		t.Fatalf("Expected no parse error, got %v", err)
+	}
+	expectedErrorMsg := "Cannot marshal number 'NaN' at line 1, Column 5:7: json: unsupported value: NaN"
+	if _, err := node.MarshalJSON(); err == nil || err.Error() != expectedErrorMsg {
+		t.Errorf("Expected error %q, got %v", expectedErrorMsg, err)
+	}
+}
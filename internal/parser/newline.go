@@ -0,0 +1,39 @@
+package parser
+
+import (
+	"fmt"
+
+	"github.com/pszponder/json-linter_go/internal/lexer"
+)
+
+// RuleFinalNewline is the name of the lint rule reporting documents which don't end as configured w/ a newline
+const RuleFinalNewline = "final-newline"
+
+// CheckFinalNewline returns a warning if a document ending w/ finalNewlines newlines doesn't end w/ exactly one newline
+// (if required), or ends w/ any newline (otherwise). The warning is positioned at the end of the last token.
+func CheckFinalNewline(tokens []lexer.Token, finalNewlines int, required bool) []ParseError {
+	var message string
+	switch {
+	case required && finalNewlines == 0:
+		message = "Missing final newline"
+	case required && finalNewlines > 1:
+		message = fmt.Sprintf("Expected a single final newline, got %d", finalNewlines)
+	case !required && finalNewlines > 0:
+		message = "Unexpected final newline"
+	default:
+		return nil
+	}
+
+	var pos lexer.TokenPosition
+	if len(tokens) > 0 {
+		last := tokens[len(tokens)-1].TokPos
+		pos = lexer.TokenPosition{Line: last.Line, ColStart: last.ColEnd, ColEnd: last.ColEnd}
+	}
+
+	return []ParseError{{
+		Severity: SeverityWarning,
+		Rule:     RuleFinalNewline,
+		Message:  message,
+		Pos:      pos,
+	}}
+}
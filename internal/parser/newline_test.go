@@ -0,0 +1,54 @@
+package parser
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/pszponder/json-linter_go/internal/lexer"
+)
+
+func TestCheckFinalNewline(t *testing.T) {
+	// Define test cases
+	testCases := []struct {
+		name            string
+		input           string
+		required        bool
+		expectedWarning string // Empty if no warning is expected
+	}{
+		{name: "required, none", input: `{"a": 1}`, required: true, expectedWarning: "Missing final newline at line 1, Column 8:8"},
+		{name: "required, one", input: "{\"a\": 1}\n", required: true},
+		{name: "required, multiple", input: "[\n1\n]\n\n\n", required: true, expectedWarning: "Expected a single final newline, got 3 at line 3, Column 1:1"},
+		{name: "forbidden, none", input: `{"a": 1}`},
+		{name: "forbidden, one", input: "{\"a\": 1}\n", expectedWarning: "Unexpected final newline at line 1, Column 8:8"},
+		{name: "forbidden, multiple", input: "{\"a\": 1}\n\n", expectedWarning: "Unexpected final newline at line 1, Column 8:8"},
+		{name: "required, one CRLF", input: "{\"a\": 1}\r\n", required: true},
+		{name: "required, multiple CRLF", input: "[\r\n1\r\n]\r\n\r\n", required: true, expectedWarning: "Expected a single final newline, got 2 at line 3, Column 1:1"},
+		{name: "forbidden, one CRLF", input: "{\"a\": 1}\r\n", expectedWarning: "Unexpected final newline at line 1, Column 8:8"},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			lxr := lexer.NewLexer(strings.NewReader(testCase.input), lexer.Options{})
+			tokens, err := lxr.ReadAll(context.Background())
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+
+			warnings := CheckFinalNewline(tokens, lxr.FinalNewlines, testCase.required)
+			if testCase.expectedWarning == "" {
+				if len(warnings) != 0 {
+					t.Errorf("Expected no warning, got %v", warnings)
+				}
+				return
+			}
+
+			if len(warnings) != 1 || warnings[0].Error() != testCase.expectedWarning {
+				t.Fatalf("Expected warning %q, got %v", testCase.expectedWarning, warnings)
+			}
+			if warnings[0].Severity != SeverityWarning || warnings[0].Rule != RuleFinalNewline {
+				t.Errorf("Expected a %s warning, got %+v", RuleFinalNewline, warnings[0])
+			}
+		})
+	}
+}
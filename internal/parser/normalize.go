@@ -0,0 +1,56 @@
+package parser
+
+import (
+	"regexp"
+	"strings"
+)
+
+// numberParts captures the sign, integer, fraction, exponent sign & exponent digits of a JSON number literal
+var numberParts = regexp.MustCompile(`^(-?)(\d+)(?:\.(\d+))?(?:[eE]([+-]?)(\d+))?$`)
+
+// NormalizeNumber rewrites the JSON number literal in a normalized form w/o changing its value or precision:
+// the trailing zeros of the fraction are removed (w/ the '.' if no digit is left), the exponent is lowercased
+// & stripped of its '+' sign & leading zeros, and an exponent of 0 is removed (ex. "1.50E+05" -> "1.5e5").
+// The sign of -0 is kept, and hexadecimal integers (lexed in relaxed mode) are converted to decimal (ex. "0x1F" -> "31").
+// Other literals which aren't decimal JSON numbers (ex. NaN) are returned as is.
+func NormalizeNumber(lexeme string) string {
+	if isHexLiteral(lexeme) {
+		return decimalHexLiteral(lexeme)
+	}
+	parts := numberParts.FindStringSubmatch(lexeme)
+	if parts == nil {
+		return lexeme
+	}
+	sign, integer, fraction, expSign, exponent := parts[1], parts[2], parts[3], parts[4], parts[5]
+
+	var sb strings.Builder
+	sb.WriteString(sign + integer)
+	if fraction = strings.TrimRight(fraction, "0"); fraction != "" {
+		sb.WriteString("." + fraction)
+	}
+	if exponent = strings.TrimLeft(exponent, "0"); exponent != "" {
+		if expSign == "+" {
+			expSign = ""
+		}
+		sb.WriteString("e" + expSign + exponent)
+	}
+	return sb.String()
+}
+
+// NormalizeNumbers returns a copy of the AST w/ the literal of each number normalized by NormalizeNumber,
+// ex. to format a document w/ consistent numbers. The values of the numbers are unchanged.
+func NormalizeNumbers(node *ASTNode) *ASTNode {
+	normalized := *node
+	if node.Type == "Number" {
+		normalized.Raw = NormalizeNumber(node.Raw)
+		normalized.IsInteger = isIntegerLiteral(normalized.Raw)
+	}
+
+	if node.Children != nil {
+		normalized.Children = make([]*ASTNode, len(node.Children))
+		for i, child := range node.Children {
+			normalized.Children[i] = NormalizeNumbers(child)
+		}
+	}
+	return &normalized
+}
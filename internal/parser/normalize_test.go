@@ -0,0 +1,73 @@
+package parser
+
+import "testing"
+
+func TestNormalizeNumber(t *testing.T) {
+	// Define test cases
+	testCases := []struct {
+		lexeme   string
+		expected string
+	}{
+		{lexeme: "0", expected: "0"},
+		{lexeme: "-0", expected: "-0"},
+		{lexeme: "-0.0", expected: "-0"},
+		{lexeme: "10", expected: "10"},
+		{lexeme: "1.0", expected: "1"},
+		{lexeme: "1.50", expected: "1.5"},
+		{lexeme: "0.000", expected: "0"},
+		{lexeme: "100.001", expected: "100.001"},
+		{lexeme: "1E5", expected: "1e5"},
+		{lexeme: "1e+5", expected: "1e5"},
+		{lexeme: "1E-5", expected: "1e-5"},
+		{lexeme: "2.500E+010", expected: "2.5e10"},
+		{lexeme: "-1.0e-007", expected: "-1e-7"},
+		{lexeme: "1e0", expected: "1"},
+		{lexeme: "1.10E-00", expected: "1.1"},
+		// Precision is kept as written
+		{lexeme: "12345678901234567890.12345678901234567890", expected: "12345678901234567890.1234567890123456789"},
+		// Hexadecimal integers are converted to decimal, w/o losing precision
+		{lexeme: "0x1F", expected: "31"},
+		{lexeme: "-0Xff", expected: "-255"},
+		{lexeme: "0xFFFFFFFFFFFFFFFFFF", expected: "4722366482869645213695"},
+		{lexeme: "NaN", expected: "NaN"},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.lexeme, func(t *testing.T) {
+			if actual := NormalizeNumber(testCase.lexeme); actual != testCase.expected {
+				t.Errorf("Expected %q, got %q", testCase.expected, actual)
+			}
+		})
+	}
+}
+
+func TestFormatNormalizedNumbers(t *testing.T) {
+	input := `{"a": 1.0, "b": [2.50E3, -0.0, 1e-05, 7], "c": "1.0"}`
+	node, err := ParseJSON(lex(input))
+	if err != nil {
+		t.Fatalf("Expected no parse error, got %v", err)
+	}
+
+	expected := `{
+  "a": 1,
+  "b": [
+    2.5e3,
+    -0,
+    1e-5,
+    7
+  ],
+  "c": "1.0"
+}
+`
+	if actual := Format(NormalizeNumbers(node), "  "); actual != expected {
+		t.Errorf("Expected:\n%s\ngot:\n%s", expected, actual)
+	}
+
+	// The original AST is unchanged
+	if actual := compactJSON(node); actual != `{"a":1.0,"b":[2.50E3,-0.0,1e-05,7],"c":"1.0"}` {
+		t.Errorf("Expected the AST to be unchanged, got %s", actual)
+	}
+	if node.Children[1].IsInteger {
+		t.Errorf("Expected 1.0 to still not be an integer literal")
+	}
+}
@@ -0,0 +1,73 @@
+package parser
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/pszponder/json-linter_go/internal/lexer"
+)
+
+// ParseOptions configures optional checks of the parser (the zero value is the default behavior)
+type ParseOptions struct {
+	// AllowedRootTypes restricts the type of the root value to the token types starting it
+	// (ex. LBRACE for objects, TRUE & FALSE for booleans). Empty means any value, per RFC 8259.
+	AllowedRootTypes []lexer.TokenType
+
+	// Trace receives a line for each decision of the parser (ex. entering an object or reading a key),
+	// along w/ the current token & its position. Nil disables tracing.
+	Trace io.Writer
+
+	// DuplicateKeys controls how Parser.Parse reports keys repeated within an object
+	// (empty means DefaultDuplicateKeyPolicy). ParseJSON ignores it since it only reports syntax errors.
+	DuplicateKeys DuplicateKeyPolicy
+
+	// ForbidEmptyObject & ForbidEmptyArray make Parser.Parse warn about each empty object ({}) or array ([])
+	ForbidEmptyObject bool
+	ForbidEmptyArray  bool
+
+	// HomogeneousArrays makes Parser.Parse warn about each array whose elements aren't all of the same type
+	HomogeneousArrays bool
+
+	// MaxArrayElements & MaxObjectMembers reject arrays w/ more elements & objects w/ more members than this (0 means no limit).
+	// Parsing stops at the 1st element or member over the limit, guarding against huge containers.
+	MaxArrayElements int
+	MaxObjectMembers int
+
+	// RelaxedKeys accepts identifiers (ex. name or max_size) & single-quoted strings (ex. 'name') as object keys,
+	// as lexed w/ lexer.Options.RelaxedKeys. Values must still be strict JSON.
+	RelaxedKeys bool
+
+	// End is the position just past the end of the input (see lexer.Lexer.End), where the errors about a document
+	// ending early are reported (ex. "[1, 2" w/o its ']'). The zero value positions them just past the last token.
+	End lexer.TokenPosition
+}
+
+// valueTypeNames names the value started by each token type, for error messages
+var valueTypeNames = map[lexer.TokenType]string{
+	lexer.LBRACE:   "object",
+	lexer.LBRACKET: "array",
+	lexer.STR:      "string",
+	lexer.NUM:      "number",
+	lexer.TRUE:     "true",
+	lexer.FALSE:    "false",
+	lexer.NULL:     "null",
+}
+
+// checkRootType returns an error if the token starting the root value is not of an allowed root type
+func (opts ParseOptions) checkRootType(tok lexer.Token) error {
+	if len(opts.AllowedRootTypes) == 0 || !tok.TokType.IsValue() {
+		return nil
+	}
+
+	allowed := make([]string, 0, len(opts.AllowedRootTypes))
+	for _, tokType := range opts.AllowedRootTypes {
+		if tokType == tok.TokType {
+			return nil
+		}
+		allowed = append(allowed, valueTypeNames[tokType])
+	}
+
+	return fmt.Errorf("Invalid JSON root, got %s but only %s allowed at line %d, Column %d:%d",
+		valueTypeNames[tok.TokType], strings.Join(allowed, ", "), tok.TokPos.Line, tok.TokPos.ColStart, tok.TokPos.ColEnd)
+}
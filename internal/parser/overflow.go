@@ -0,0 +1,28 @@
+package parser
+
+import "math"
+
+// RuleNumberOverflow is the name of the lint rule reporting numbers too large for a float64
+const RuleNumberOverflow = "number-overflow"
+
+// CheckNumberOverflow walks the AST and returns a warning for each number whose literal is valid JSON
+// but whose value overflows a float64 (ex. 1e400), as it's decoded to ±Inf by most implementations.
+// NaN, Infinity & -Infinity literals (lexed w/ AllowNaNInf) aren't reported.
+func CheckNumberOverflow(node *ASTNode) []ParseError {
+	var warnings []ParseError
+
+	WalkPaths(node, func(node *ASTNode, path string) bool {
+		if num, ok := node.Value.(float64); ok && node.Type == "Number" && math.IsInf(num, 0) && !isNonFiniteLiteral(node.Raw) {
+			warnings = append(warnings, ParseError{
+				Severity: SeverityWarning,
+				Rule:     RuleNumberOverflow,
+				Message:  "Number '" + node.Raw + "' overflows a 64-bit float",
+				Pos:      node.Pos,
+				Path:     path,
+			})
+		}
+		return true
+	})
+
+	return warnings
+}
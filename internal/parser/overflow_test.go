@@ -0,0 +1,55 @@
+package parser
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/pszponder/json-linter_go/internal/lexer"
+)
+
+func TestCheckNumberOverflow(t *testing.T) {
+	// Define test cases
+	testCases := []struct {
+		input            string
+		expectedWarnings []string
+	}{
+		{input: `[0e0, 1E+000, 1e-0, 1e0000000000, 1.7976931348623157e308, 1e-400]`},
+		{
+			input:            `{"a": [1e400, -1E+999999999999]}`,
+			expectedWarnings: []string{"Number '1e400' overflows a 64-bit float at line 1, Column 8:12", "Number '-1E+999999999999' overflows a 64-bit float at line 1, Column 15:30"},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.input, func(t *testing.T) {
+			node, err := ParseJSON(lex(testCase.input))
+			if err != nil {
+				t.Fatalf("Expected no parse error, got %v", err)
+			}
+
+			warnings := CheckNumberOverflow(node)
+			if len(warnings) != len(testCase.expectedWarnings) {
+				t.Fatalf("Expected %d warnings, got %v", len(testCase.expectedWarnings), warnings)
+			}
+			for i, warning := range warnings {
+				if warning.Error() != testCase.expectedWarnings[i] || warning.Rule != RuleNumberOverflow || warning.Severity != SeverityWarning {
+					t.Errorf("Expected %s warning %q, got %+v", RuleNumberOverflow, testCase.expectedWarnings[i], warning)
+				}
+			}
+		})
+	}
+
+	// Infinity literals aren't overflows
+	tokens, err := lexer.LexReaderContext(context.Background(), strings.NewReader(`[Infinity, -Infinity]`), lexer.Options{AllowNaNInf: true})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	node, err := ParseJSON(tokens)
+	if err != nil {
+		t.Fatalf("Expected no parse error, got %v", err)
+	}
+	if warnings := CheckNumberOverflow(node); len(warnings) != 0 {
+		t.Errorf("Expected no warnings, got %v", warnings)
+	}
+}
@@ -2,7 +2,13 @@
 package parser
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+	"unicode"
 
 	"github.com/pszponder/json-linter_go/internal/lexer"
 )
@@ -12,71 +18,375 @@ type ASTNode struct {
 	Type     string
 	Value    interface{}
 	Children []*ASTNode
+	Pos      lexer.TokenPosition // Position of the token which starts the node
+
+	// Literal lexeme of Number, String & Key nodes (ex. "1.0" or a\"b w/o the quotes),
+	// which preserves the precision lost by the float64 Value of numbers & the escape sequences of strings
+	Raw string
+
+	// Number nodes only
+	IsInteger bool // True if the literal has neither a fraction nor an exponent
 }
 
 // ParseJSON parses the tokens generated by the lexer and returns the root node of the AST
 func ParseJSON(tokens []lexer.Token) (*ASTNode, error) {
-	if len(tokens) == 0 {
+	return ParseJSONContext(context.Background(), tokens)
+}
+
+// ParseJSONContext is like ParseJSON, but stops w/ the context's error if the context is cancelled while parsing.
+func ParseJSONContext(ctx context.Context, tokens []lexer.Token) (*ASTNode, error) {
+	return ParseJSONWithOptions(ctx, tokens, ParseOptions{})
+}
+
+// ParseJSONWithOptions is like ParseJSONContext, but also runs the optional checks configured by opts.
+func ParseJSONWithOptions(ctx context.Context, tokens []lexer.Token, opts ParseOptions) (*ASTNode, error) {
+	return NewParser(tokens, opts).parse(ctx)
+}
+
+// Parser holds the state of parsing a list of tokens into an AST, configured by its ParseOptions
+type Parser struct {
+	opts   ParseOptions
+	tokens []lexer.Token // Significant tokens of the document (w/o comments & whitespace)
+	index  int           // Index of the token currently being parsed
+}
+
+// NewParser returns a Parser for the tokens generated by the lexer, configured by opts
+func NewParser(tokens []lexer.Token, opts ParseOptions) *Parser {
+	// The significant tokens are followed by an EOF token positioned at the end of the input
+	end := opts.End
+	if end.Line == 0 {
+		end = endOfTokens(tokens)
+	}
+	significant := append(significantTokens(tokens), lexer.Token{TokType: lexer.EOF, Lexeme: "EOF", TokPos: end})
+	return &Parser{opts: opts, tokens: significant}
+}
+
+// Parse parses the tokens and returns the root node of the AST, along w/ the problems found in the document.
+// A syntax error stops parsing & is returned as the only problem w/ a nil root node,
+// otherwise the problems are the findings of the checks configured by the options (ex. duplicate keys or empty containers).
+func (p *Parser) Parse() (*ASTNode, []ParseError) {
+	return p.ParseContext(context.Background())
+}
+
+// ParseContext is like Parse, but stops w/ the context's error if the context is cancelled while parsing.
+func (p *Parser) ParseContext(ctx context.Context) (*ASTNode, []ParseError) {
+	rootNode, err := p.parse(ctx)
+	if err != nil {
+		return nil, []ParseError{p.syntaxError(err)}
+	}
+	findings := CheckDuplicateKeysWithPolicy(rootNode, p.opts.DuplicateKeys)
+	findings = append(findings, CheckEmptyContainers(rootNode, p.opts.ForbidEmptyObject, p.opts.ForbidEmptyArray)...)
+	if p.opts.HomogeneousArrays {
+		findings = append(findings, CheckHomogeneousArrays(rootNode)...)
+	}
+	return rootNode, findings
+}
+
+// syntaxError converts the error which stopped parsing into a ParseError positioned at the token being parsed
+func (p *Parser) syntaxError(err error) ParseError {
+	path := "$"
+	var syntaxErr *SyntaxError
+	if errors.As(err, &syntaxErr) {
+		path = syntaxErr.Path
+	}
+	return ParseError{Severity: SeverityError, Message: err.Error(), Pos: tokenAt(p.tokens, p.index).TokPos, Path: path, Err: err}
+}
+
+// parse parses the tokens from the start & returns the root node of the AST or the error which stopped parsing,
+// positioning a SyntaxError at the token being parsed
+func (p *Parser) parse(ctx context.Context) (*ASTNode, error) {
+	rootNode, err := p.parseDocument(ctx)
+	var syntaxErr *SyntaxError
+	if errors.As(err, &syntaxErr) {
+		syntaxErr.Pos = tokenAt(p.tokens, p.index).TokPos
+	}
+	return rootNode, err
+}
+
+// parseDocument parses the root value, which must be followed by nothing but whitespace
+func (p *Parser) parseDocument(ctx context.Context) (*ASTNode, error) {
+	if tokenAt(p.tokens, 0).TokType == lexer.EOF {
 		return nil, fmt.Errorf("No Tokens provided")
 	}
+	p.index = 0
+
+	// A closer at the start of the document can't have a matching opener
+	if err := unmatchedCloser(p.tokens[p.index]); err != nil {
+		return nil, atPath(err, "$")
+	}
 
-	// idx tracks current position in slice of tokens being parsed.
-	// Pass a reference to this index to parseObject & parseArray
-	// to make sure that the same index is updated.
-	idx := 0
+	// Neither can a ',' or ':' start it (ex. a file which isn't JSON at all)
+	if tok := p.tokens[p.index]; tok.TokType == lexer.COMMA || tok.TokType == lexer.COLON {
+		return nil, atPath(unexpectedToken(tok, nil, "Expected a JSON value at the start of the document, got '%v' at line %d, Column %d:%d",
+			tok.Lexeme, tok.TokPos.Line, tok.TokPos.ColStart, tok.TokPos.ColEnd), "$")
+	}
 
-	// 1st Token must be { or [ to be valid JSON
-	switch tokens[idx].TokType {
-	case lexer.LBRACE:
-		rootNode, err := parseObject(tokens, &idx)
-		if err != nil {
-			return nil, err
+	// Per RFC 8259, the root of a JSON document can be any JSON value, unless restricted by the options
+	if err := p.opts.checkRootType(p.tokens[p.index]); err != nil {
+		return nil, atPath(err, "$")
+	}
+	rootNode, err := p.parseValue(ctx, "$")
+	if err != nil {
+		return nil, err
+	}
+
+	// Nothing but whitespace may follow the root value
+	if tok := tokenAt(p.tokens, p.index); tok.TokType != lexer.EOF {
+		if err := unmatchedCloser(tok); err != nil {
+			return nil, atPath(err, "$")
 		}
-		return rootNode, nil
-	case lexer.LBRACKET:
-		rootNode, err := parseArray(tokens, &idx)
-		if err != nil {
-			return nil, err
+		return nil, atPath(unexpectedToken(tok, nil, "Unexpected '%v' after the end of the JSON document at line %d, Column %d:%d",
+			tok.Lexeme, tok.TokPos.Line, tok.TokPos.ColStart, tok.TokPos.ColEnd), "$")
+	}
+
+	return rootNode, nil
+}
+
+// closers maps each opening token type to the token type which closes it
+var closers = map[lexer.TokenType]lexer.TokenType{
+	lexer.LBRACE:   lexer.RBRACE,
+	lexer.LBRACKET: lexer.RBRACKET,
+}
+
+// unmatchedCloser returns an error if the token is a '}' or ']' which has no opener left to close
+func unmatchedCloser(tok lexer.Token) error {
+	if tok.TokType == lexer.RBRACE || tok.TokType == lexer.RBRACKET {
+		return unexpectedToken(tok, nil, "Unexpected '%v' with no matching opener at line %d, Column %d:%d",
+			tok.Lexeme, tok.TokPos.Line, tok.TokPos.ColStart, tok.TokPos.ColEnd)
+	}
+	return nil
+}
+
+// mismatchedCloser returns an error if the token at index is a '}' or ']' which does not close the opener
+// (ex. the ']' in {"a": 1]).
+func mismatchedCloser(tokens []lexer.Token, index int, opener lexer.Token) error {
+	tok := tokenAt(tokens, index)
+	if (tok.TokType == lexer.RBRACE || tok.TokType == lexer.RBRACKET) && tok.TokType != closers[opener.TokType] {
+		return unexpectedToken(tok, nil, "Mismatched '%v' at line %d, Column %d:%d, expected a closer for '%v' opened at line %d, Column %d",
+			tok.Lexeme, tok.TokPos.Line, tok.TokPos.ColStart, tok.TokPos.ColEnd,
+			opener.Lexeme, opener.TokPos.Line, opener.TokPos.ColStart)
+	}
+	return nil
+}
+
+// significantTokens returns the tokens which make up the structure of the document, dropping any comments & whitespace
+func significantTokens(tokens []lexer.Token) []lexer.Token {
+	significant := make([]lexer.Token, 0, len(tokens))
+	for _, tok := range tokens {
+		if !tok.TokType.IsTrivia() {
+			significant = append(significant, tok)
 		}
-		return rootNode, nil
-	default:
-		return nil, fmt.Errorf("Invalid top-level construct in JSON at Line %d, Column %d", tokens[0].TokPos.Line, tokens[0].TokPos.ColStart)
 	}
+	return significant
+}
+
+// tokenAt returns the token at the specified index.
+// If the index is past the end of the tokens, the EOF token ending them is returned instead
+// (or one positioned just past the last token if they don't end w/ an EOF token).
+func tokenAt(tokens []lexer.Token, index int) lexer.Token {
+	if index < len(tokens) {
+		return tokens[index]
+	}
+	if len(tokens) > 0 && tokens[len(tokens)-1].TokType == lexer.EOF {
+		return tokens[len(tokens)-1]
+	}
+	return lexer.Token{TokType: lexer.EOF, Lexeme: "EOF", TokPos: endOfTokens(tokens)}
+}
+
+// endOfTokens returns the position just past the last of the tokens (line 1, Column 1 if there are none)
+func endOfTokens(tokens []lexer.Token) lexer.TokenPosition {
+	if len(tokens) == 0 {
+		return lexer.TokenPosition{Line: 1, ColStart: 1, ColEnd: 1}
+	}
+	last := tokens[len(tokens)-1]
+	column := last.TokPos.ColEnd + 1
+	return lexer.TokenPosition{Line: last.TokPos.Line, ColStart: column, ColEnd: column, Offset: last.EndOffset()}
 }
 
 // expectedToken checks if the current token has the expected type and returns an error if not
 func expectedToken(tokens []lexer.Token, index int, expectedType lexer.TokenType, errorMsg string) error {
-	if tokens[index].TokType != expectedType {
-		return fmt.Errorf("%s at line %d, Column %d:%d",
-			errorMsg, tokens[index].TokPos.Line, tokens[index].TokPos.ColStart, tokens[index].TokPos.ColEnd)
+	tok := tokenAt(tokens, index)
+	if tok.TokType != expectedType {
+		return unexpectedToken(tok, nil, "%s at line %d, Column %d:%d",
+			errorMsg, tok.TokPos.Line, tok.TokPos.ColStart, tok.TokPos.ColEnd)
+	}
+	return nil
+}
+
+// unexpectedPunctuation returns an error if the token at index is a ',' or ':' where a key or value (expected) should start
+func unexpectedPunctuation(tokens []lexer.Token, index int, expected string) error {
+	tok := tokenAt(tokens, index)
+	if tok.TokType == lexer.COMMA || tok.TokType == lexer.COLON {
+		return unexpectedToken(tok, nil, "Unexpected '%v' at line %d, Column %d:%d, expected %s",
+			tok.Lexeme, tok.TokPos.Line, tok.TokPos.ColStart, tok.TokPos.ColEnd, expected)
 	}
 	return nil
 }
 
-// parseObject parses a JSON object and returns its AST Representation
-func parseObject(tokens []lexer.Token, index *int) (*ASTNode, error) {
-	objectNode := &ASTNode{Type: "Object", Children: []*ASTNode{}}
+// objectMemberInArray returns an error if the token at index, where an element of an array should start,
+// is a string followed by a ':' as in an object member
+func objectMemberInArray(tokens []lexer.Token, index int) error {
+	if tokenAt(tokens, index).TokType != lexer.STR {
+		return nil
+	}
+	if colon := tokenAt(tokens, index+1); colon.TokType == lexer.COLON {
+		return unexpectedToken(colon, nil, "Unexpected ':', object member syntax is not valid inside an array (use '{' & '}' for an object) at line %d, Column %d:%d",
+			colon.TokPos.Line, colon.TokPos.ColStart, colon.TokPos.ColEnd)
+	}
+	return nil
+}
+
+// expectedSeparator returns an error if the token at index, which follows a member or element of the container
+// started by opener, is neither a comma nor a closer (ex. the missing comma in [1 2]).
+func expectedSeparator(tokens []lexer.Token, index int, opener lexer.Token) error {
+	tok := tokenAt(tokens, index)
+	switch tok.TokType {
+	case lexer.COMMA, lexer.RBRACE, lexer.RBRACKET:
+		// Mismatched closers are reported when the loop checks for the closer
+		return nil
+	}
+
+	closer, element := "}", "object member"
+	if opener.TokType == lexer.LBRACKET {
+		closer, element = "]", "array element"
+	}
+	return unexpectedToken(tok, nil, "Expected ',' or '%s' after %s, got '%v' at line %d, Column %d:%d",
+		closer, element, tok.Lexeme, tok.TokPos.Line, tok.TokPos.ColStart, tok.TokPos.ColEnd)
+}
+
+// tooManyValues returns an error positioned at the opener if the container it starts, which already holds count
+// members or elements, would exceed max (0 means no limit) by holding another one
+func tooManyValues(opener lexer.Token, count, max int) error {
+	if max <= 0 || count < max {
+		return nil
+	}
+
+	container, values := "Object", "members"
+	if opener.TokType == lexer.LBRACKET {
+		container, values = "Array", "elements"
+	}
+	return fmt.Errorf("%s exceeds the maximum of %d %s at line %d, Column %d:%d",
+		container, max, values, opener.TokPos.Line, opener.TokPos.ColStart, opener.TokPos.ColEnd)
+}
+
+// relaxedKey converts an identifier or single-quoted string (lexed w/ lexer.Options.RelaxedKeys) into the STR token
+// of the equivalent double-quoted key, other tokens are returned unchanged
+func relaxedKey(tok lexer.Token) lexer.Token {
+	switch {
+	case tok.TokType == lexer.ILLEGAL && errors.Is(tok.Err, lexer.ErrSingleQuoted):
+		// Unescape the single quotes & escape the double quotes of the content
+		content := []rune(tok.Lexeme[1 : len(tok.Lexeme)-1])
+		var sb strings.Builder
+		for i := 0; i < len(content); i++ {
+			switch {
+			case content[i] == '\\' && i+1 < len(content) && content[i+1] == '\'':
+				sb.WriteRune('\'')
+				i++
+			case content[i] == '\\' && i+1 < len(content):
+				sb.WriteRune(content[i])
+				sb.WriteRune(content[i+1])
+				i++
+			case content[i] == '"':
+				sb.WriteString(`\"`)
+			default:
+				sb.WriteRune(content[i])
+			}
+		}
+		tok.Lexeme = sb.String()
+	case tok.TokType == lexer.ILLEGAL && isIdentifier(tok.Lexeme),
+		tok.TokType == lexer.TRUE, tok.TokType == lexer.FALSE, tok.TokType == lexer.NULL:
+	default:
+		return tok
+	}
+
+	tok.TokType = lexer.STR
+	tok.Err = nil
+	return tok
+}
+
+// isIdentifier reports whether s is a key accepted unquoted in relaxed keys mode:
+// letters, digits, '_' & '$', not starting w/ a digit
+func isIdentifier(s string) bool {
+	for i, r := range s {
+		if !unicode.IsLetter(r) && r != '_' && r != '$' && (i == 0 || !unicode.IsDigit(r)) {
+			return false
+		}
+	}
+	return s != ""
+}
+
+// parseObject parses the JSON object located at path and returns its AST Representation.
+// Syntax errors are returned as a *SyntaxError located at the innermost value being parsed.
+func (p *Parser) parseObject(ctx context.Context, path string) (*ASTNode, error) {
+	opener := p.tokens[p.index]
+	objectNode := &ASTNode{Type: "Object", Children: []*ASTNode{}, Pos: opener.TokPos}
+	p.trace("enter object", path, opener)
 
 	// Consume '{'
-	*index++
+	p.index++
 
 	// Iterate through tokens until we hit the closing brace
-	for tokens[*index].TokType != lexer.RBRACE {
-		// Parse key
-		if err := expectedToken(tokens, *index, lexer.STR, "Invalid JSON key"); err != nil {
+	for tokenAt(p.tokens, p.index).TokType != lexer.RBRACE {
+		if err := ctx.Err(); err != nil {
 			return nil, err
 		}
-		keyNode := &ASTNode{Type: "Key", Value: tokens[*index].Lexeme}
-		*index++
+
+		// A ']' can't close an object
+		if err := mismatchedCloser(p.tokens, p.index, opener); err != nil {
+			return nil, atPath(err, path)
+		}
+
+		// A comma or colon can't take the place of a member (ex. {,"a": 1}, {"a": 1,,"b": 2} or {:})
+		if err := unexpectedPunctuation(p.tokens, p.index, "a key"); err != nil {
+			return nil, atPath(err, path)
+		}
+
+		// Stop before parsing a member over the limit
+		if err := tooManyValues(opener, len(objectNode.Children)/2, p.opts.MaxObjectMembers); err != nil {
+			return nil, atPath(err, path)
+		}
+
+		// Identifiers & single-quoted strings are keys in relaxed keys mode (ex. {name: 1} or {'name': 1})
+		if p.opts.RelaxedKeys && p.index < len(p.tokens) {
+			p.tokens[p.index] = relaxedKey(p.tokens[p.index])
+		}
+
+		// Parse key, w/ the reason given by the lexer if the key is invalid
+		if tok := tokenAt(p.tokens, p.index); tok.Err != nil {
+			return nil, atPath(unexpectedToken(tok, nil, "Invalid JSON key, %v at line %d, Column %d:%d",
+				tok.Err, tok.TokPos.Line, tok.TokPos.ColStart, tok.TokPos.ColEnd), path)
+		}
+		if tok := tokenAt(p.tokens, p.index); tok.TokType.IsValue() && tok.TokType != lexer.STR {
+			return nil, atPath(unexpectedToken(tok, nil, "Expected string key, got %v at line %d, Column %d:%d",
+				tok.TokType, tok.TokPos.Line, tok.TokPos.ColStart, tok.TokPos.ColEnd), path)
+		}
+		if err := expectedToken(p.tokens, p.index, lexer.STR, "Invalid JSON key"); err != nil {
+			return nil, atPath(err, path)
+		}
+		key, err := decodeString(p.tokens[p.index])
+		if err != nil {
+			return nil, atPath(err, path)
+		}
+		keyNode := &ASTNode{Type: "Key", Value: key, Pos: p.tokens[p.index].TokPos, Raw: p.tokens[p.index].Lexeme}
+		p.trace("read key", KeyPath(path, key), p.tokens[p.index])
+		p.index++
 
 		// Consume ':'
-		if err := expectedToken(tokens, *index, lexer.COLON, "Invalid JSON, expected ':'"); err != nil {
-			return nil, err
+		p.trace("expect ':'", KeyPath(path, key), tokenAt(p.tokens, p.index))
+		if err := expectedToken(p.tokens, p.index, lexer.COLON, "Invalid JSON, expected ':'"); err != nil {
+			return nil, atPath(err, path)
+		}
+		p.index++
+
+		// The colon must be followed by a value, not by a '}', ',' or the end of the input (ex. {"a":})
+		switch tok := tokenAt(p.tokens, p.index); tok.TokType {
+		case lexer.RBRACE, lexer.RBRACKET, lexer.COMMA, lexer.COLON, lexer.EOF:
+			return nil, atPath(unexpectedToken(tok, nil, "Expected value after ':', got '%v' at line %d, Column %d:%d",
+				tok.Lexeme, tok.TokPos.Line, tok.TokPos.ColStart, tok.TokPos.ColEnd), KeyPath(path, key))
 		}
-		*index++
 
 		// Parse value
-		valueNode, err := parseValue(tokens, index)
+		valueNode, err := p.parseValue(ctx, KeyPath(path, key))
 		if err != nil {
 			return nil, err
 		}
@@ -85,33 +395,67 @@ func parseObject(tokens []lexer.Token, index *int) (*ASTNode, error) {
 		objectNode.Children = append(objectNode.Children, keyNode, valueNode)
 
 		// Check for trailing commas at end of object
-		if tokens[*index].TokType == lexer.COMMA && tokens[*index+1].TokType == lexer.RBRACE {
-			return nil, fmt.Errorf("Invalid JSON Object, trailing comma not allowed at Line %d, Column %d:%d", tokens[*index].TokPos.Line, tokens[*index].TokPos.ColStart, tokens[*index].TokPos.ColEnd)
+		if tokenAt(p.tokens, p.index).TokType == lexer.COMMA && tokenAt(p.tokens, p.index+1).TokType == lexer.RBRACE {
+			return nil, atPath(unexpectedToken(p.tokens[p.index], []error{ErrTrailingComma}, "Invalid JSON Object, trailing comma not allowed at Line %d, Column %d:%d", p.tokens[p.index].TokPos.Line, p.tokens[p.index].TokPos.ColStart, p.tokens[p.index].TokPos.ColEnd), path)
+		}
+
+		// Members must be separated by a comma
+		p.trace("expect ',' or '}'", path, tokenAt(p.tokens, p.index))
+		if err := expectedSeparator(p.tokens, p.index, opener); err != nil {
+			return nil, atPath(err, path)
 		}
 
 		// If there's a comma, consume it
-		if tokens[*index].TokType == lexer.COMMA {
-			*index++
+		if tokenAt(p.tokens, p.index).TokType == lexer.COMMA {
+			p.index++
 		}
 	}
 
 	// Consume '}'
-	*index++
+	p.trace("exit object", path, p.tokens[p.index])
+	p.index++
 
 	return objectNode, nil
 }
 
-// parseArray parses a JSON array and returns its AST representation.
-func parseArray(tokens []lexer.Token, index *int) (*ASTNode, error) {
-	arrayNode := &ASTNode{Type: "Array", Children: []*ASTNode{}}
+// parseArray parses the JSON array located at path and returns its AST representation.
+// Syntax errors are returned as a *SyntaxError located at the innermost value being parsed.
+func (p *Parser) parseArray(ctx context.Context, path string) (*ASTNode, error) {
+	opener := p.tokens[p.index]
+	arrayNode := &ASTNode{Type: "Array", Children: []*ASTNode{}, Pos: opener.TokPos}
+	p.trace("enter array", path, opener)
 
 	// Consume '['
-	*index++
+	p.index++
 
 	// Iterate through tokens until we hit the closing bracket
-	for tokens[*index].TokType != lexer.RBRACKET {
+	for tokenAt(p.tokens, p.index).TokType != lexer.RBRACKET {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		// A '}' can't close an array
+		if err := mismatchedCloser(p.tokens, p.index, opener); err != nil {
+			return nil, atPath(err, path)
+		}
+
+		// A comma or colon can't take the place of an element (ex. [,1], [1,,2] or [:])
+		if err := unexpectedPunctuation(p.tokens, p.index, "a value"); err != nil {
+			return nil, atPath(err, path)
+		}
+
+		// A key & colon can't take the place of an element, the array was likely meant to be an object (ex. ["a": 1])
+		if err := objectMemberInArray(p.tokens, p.index); err != nil {
+			return nil, atPath(err, path)
+		}
+
+		// Stop before parsing an element over the limit
+		if err := tooManyValues(opener, len(arrayNode.Children), p.opts.MaxArrayElements); err != nil {
+			return nil, atPath(err, path)
+		}
+
 		// Parse array element
-		elementNode, err := parseValue(tokens, index)
+		elementNode, err := p.parseValue(ctx, IndexPath(path, len(arrayNode.Children)))
 		if err != nil {
 			return nil, err
 		}
@@ -120,52 +464,128 @@ func parseArray(tokens []lexer.Token, index *int) (*ASTNode, error) {
 		arrayNode.Children = append(arrayNode.Children, elementNode)
 
 		// Check for trailing commas at end of array
-		if tokens[*index].TokType == lexer.COMMA && tokens[*index+1].TokType == lexer.RBRACKET {
-			return nil, fmt.Errorf("Invalid JSON Array, trailing comma not allowed at Line %d, Column %d:%d", tokens[*index].TokPos.Line, tokens[*index].TokPos.ColStart, tokens[*index].TokPos.ColEnd)
+		if tokenAt(p.tokens, p.index).TokType == lexer.COMMA && tokenAt(p.tokens, p.index+1).TokType == lexer.RBRACKET {
+			return nil, atPath(unexpectedToken(p.tokens[p.index], []error{ErrTrailingComma}, "Invalid JSON Array, trailing comma not allowed at Line %d, Column %d:%d", p.tokens[p.index].TokPos.Line, p.tokens[p.index].TokPos.ColStart, p.tokens[p.index].TokPos.ColEnd), path)
+		}
+
+		// Elements must be separated by a comma
+		p.trace("expect ',' or ']'", path, tokenAt(p.tokens, p.index))
+		if err := expectedSeparator(p.tokens, p.index, opener); err != nil {
+			return nil, atPath(err, path)
 		}
 
 		// If there's a comma, consume it
-		if tokens[*index].TokType == lexer.COMMA {
-			*index++
+		if tokenAt(p.tokens, p.index).TokType == lexer.COMMA {
+			p.index++
 		}
 	}
 
 	// Consume ']'
-	*index++
+	p.trace("exit array", path, p.tokens[p.index])
+	p.index++
 
 	return arrayNode, nil
 }
 
-// parseValue parses a JSON value and returns its AST Representation
-func parseValue(tokens []lexer.Token, index *int) (*ASTNode, error) {
-	valueNode := &ASTNode{Type: "Value"}
+// parseValue parses the JSON value located at path and returns its AST Representation
+func (p *Parser) parseValue(ctx context.Context, path string) (*ASTNode, error) {
+	tok := tokenAt(p.tokens, p.index)
+	p.trace("parse value", path, tok)
+	if !tok.TokType.IsValue() {
+		return nil, atPath(invalidValue(tok), path)
+	}
 
-	switch tokens[*index].TokType {
+	switch tok.TokType {
 	case lexer.LBRACE:
 		// Object
-		var err error
-		valueNode, err = parseObject(tokens, index)
-		if err != nil {
-			return nil, err
-		}
+		return p.parseObject(ctx, path)
 	case lexer.LBRACKET:
 		// Array
-		var err error
-		valueNode, err = parseArray(tokens, index)
+		return p.parseArray(ctx, path)
+	case lexer.STR:
+		// String, stored with its escape sequences decoded
+		str, err := decodeString(tok)
 		if err != nil {
-			return nil, err
+			return nil, atPath(err, path)
 		}
-	case lexer.STR, lexer.NUM, lexer.TRUE, lexer.FALSE, lexer.NULL:
-		// String, Number, True, False, Null
-		valueNode.Value = tokens[*index].Lexeme
-		*index++
+		p.index++
+		return &ASTNode{Type: "String", Value: str, Pos: tok.TokPos, Raw: tok.Lexeme}, nil
+	case lexer.NUM:
+		// Number, the literal lexeme is kept as well since the float64 value can lose precision
+		p.index++
+		return &ASTNode{
+			Type:      "Number",
+			Value:     parseNumber(tok.Lexeme),
+			Pos:       tok.TokPos,
+			Raw:       tok.Lexeme,
+			IsInteger: isIntegerLiteral(tok.Lexeme),
+		}, nil
+	case lexer.TRUE, lexer.FALSE:
+		// Boolean
+		p.index++
+		return &ASTNode{Type: "Boolean", Value: tok.TokType == lexer.TRUE, Pos: tok.TokPos}, nil
 	default:
-		// Default case for unknown token types
-		return nil, fmt.Errorf("Invalid JSON value '%v' at line %d, Column %d:%d",
-			tokens[*index].Lexeme, tokens[*index].TokPos.Line, tokens[*index].TokPos.ColStart, tokens[*index].TokPos.ColEnd)
+		// Null, the only value type left
+		p.index++
+		return &ASTNode{Type: "Null", Pos: tok.TokPos}, nil
 	}
+}
+
+// invalidValue returns the error for a token which can't start a value, w/ the reason given by the lexer if any
+func invalidValue(tok lexer.Token) error {
+	if tok.Err != nil {
+		return unexpectedToken(tok, nil, "Invalid JSON value '%v', %v at line %d, Column %d:%d",
+			tok.Lexeme, tok.Err, tok.TokPos.Line, tok.TokPos.ColStart, tok.TokPos.ColEnd)
+	}
+	return unexpectedToken(tok, nil, "Invalid JSON value '%v' at line %d, Column %d:%d",
+		tok.Lexeme, tok.TokPos.Line, tok.TokPos.ColStart, tok.TokPos.ColEnd)
+}
+
+// parseNumber converts the lexeme of a NUM token into a float64.
+// Numbers too large for a float64 become ±Inf (the lexeme is still valid JSON).
+// Negative zero keeps its sign (ex. "-0" & "-0.0" yield -0, check w/ math.Signbit).
+// Hexadecimal integers (lexed in relaxed mode, ex. "0x1F") are converted as well,
+// and NaN, Infinity & -Infinity (lexed w/ AllowNaNInf) yield NaN, +Inf & -Inf.
+func parseNumber(lexeme string) float64 {
+	if isHexLiteral(lexeme) {
+		// ParseFloat only accepts hexadecimal mantissas w/ a binary exponent
+		lexeme += "p0"
+	}
+	num, _ := strconv.ParseFloat(lexeme, 64)
+	return num
+}
+
+// isIntegerLiteral reports whether the lexeme of a NUM token is an integer, i.e. w/o a fraction or an exponent
+func isIntegerLiteral(lexeme string) bool {
+	if isNonFiniteLiteral(lexeme) {
+		return false
+	}
+	return isHexLiteral(lexeme) || !strings.ContainsAny(lexeme, ".eE")
+}
+
+// isNonFiniteLiteral reports whether the lexeme of a NUM token is NaN, Infinity or -Infinity (lexed w/ AllowNaNInf)
+func isNonFiniteLiteral(lexeme string) bool {
+	return lexeme == "NaN" || lexeme == "Infinity" || lexeme == "-Infinity"
+}
+
+// decimalHexLiteral returns the decimal literal of the hexadecimal integer lexeme of a NUM token (ex. "-0x1F" -> "-31"),
+// w/o losing precision, so it's valid JSON
+func decimalHexLiteral(lexeme string) string {
+	sign := ""
+	if strings.HasPrefix(lexeme, "-") {
+		sign, lexeme = "-", lexeme[1:]
+	}
+	num, ok := new(big.Int).SetString(lexeme[2:], 16)
+	if !ok {
+		return sign + lexeme
+	}
+	return sign + num.String()
+}
 
-	return valueNode, nil
+// isHexLiteral reports whether the lexeme of a NUM token is a hexadecimal integer (ex. "0x1F" or "-0xabc")
+func isHexLiteral(lexeme string) bool {
+	lexeme = strings.TrimPrefix(lexeme, "-")
+	return strings.HasPrefix(lexeme, "0x") || strings.HasPrefix(lexeme, "0X")
 }
 
 // PrintAST prints the AST in a readable format
@@ -0,0 +1,809 @@
+package parser
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/pszponder/json-linter_go/internal/lexer"
+)
+
+// lex is a helper which tokenizes the input string
+func lex(input string) []lexer.Token {
+	return lexer.LexReader(bytes.NewReader([]byte(input)))
+}
+
+func TestParseJSONContextCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := ParseJSONContext(ctx, lex(`{"numbers": [1, 2, 3]}`))
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected %v, got %v", context.Canceled, err)
+	}
+}
+
+func TestParseJSONLeadingPunctuation(t *testing.T) {
+	// Define test cases
+	testCases := []struct {
+		input            string
+		expectedErrorMsg string
+	}{
+		{input: `,`, expectedErrorMsg: "Expected a JSON value at the start of the document, got ',' at line 1, Column 1:1"},
+		{input: `:`, expectedErrorMsg: "Expected a JSON value at the start of the document, got ':' at line 1, Column 1:1"},
+		{input: "\n  , 1", expectedErrorMsg: "Expected a JSON value at the start of the document, got ',' at line 2, Column 3:3"},
+		{input: `: {"a": 1}`, expectedErrorMsg: "Expected a JSON value at the start of the document, got ':' at line 1, Column 1:1"},
+		{input: `}`, expectedErrorMsg: "Unexpected '}' with no matching opener at line 1, Column 1:1"},
+		{input: `]`, expectedErrorMsg: "Unexpected ']' with no matching opener at line 1, Column 1:1"},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.input, func(t *testing.T) {
+			_, err := ParseJSON(lex(testCase.input))
+			if err == nil || err.Error() != testCase.expectedErrorMsg {
+				t.Errorf("Expected error %q, got %v", testCase.expectedErrorMsg, err)
+			}
+			if !errors.Is(err, ErrUnexpectedToken) {
+				t.Errorf("Expected the error to match %q, got %v", ErrUnexpectedToken, err)
+			}
+		})
+	}
+}
+
+func TestParseJSONClosers(t *testing.T) {
+	// Define test cases
+	testCases := []struct {
+		input            string
+		expectedErrorMsg string
+	}{
+		// Lone closers
+		{input: `}`, expectedErrorMsg: "Unexpected '}' with no matching opener at line 1, Column 1:1"},
+		{input: `]`, expectedErrorMsg: "Unexpected ']' with no matching opener at line 1, Column 1:1"},
+		// Mismatched closers
+		{input: `{]`, expectedErrorMsg: "Mismatched ']' at line 1, Column 2:2, expected a closer for '{' opened at line 1, Column 1"},
+		{input: `[}`, expectedErrorMsg: "Mismatched '}' at line 1, Column 2:2, expected a closer for '[' opened at line 1, Column 1"},
+		{input: `{"a": 1]`, expectedErrorMsg: "Mismatched ']' at line 1, Column 8:8"},
+		{input: `[1, {"a": [2}]`, expectedErrorMsg: "Mismatched '}' at line 1, Column 13:13, expected a closer for '[' opened at line 1, Column 11"},
+		// Extra closers after a complete document
+		{input: `{}}`, expectedErrorMsg: "Unexpected '}' with no matching opener at line 1, Column 3:3"},
+		{input: "[[]]\n]", expectedErrorMsg: "Unexpected ']' with no matching opener at line 2, Column 1:1"},
+		// Other content after a complete document
+		{input: `{} {}`, expectedErrorMsg: "Unexpected '{' after the end of the JSON document at line 1, Column 4:4"},
+		{input: `"a" "b"`, expectedErrorMsg: "Unexpected 'b' after the end of the JSON document at line 1, Column 5:7"},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.input, func(t *testing.T) {
+			_, err := ParseJSON(lex(testCase.input))
+			if err == nil || !strings.Contains(err.Error(), testCase.expectedErrorMsg) {
+				t.Errorf("Expected error containing %q, got %v", testCase.expectedErrorMsg, err)
+			}
+		})
+	}
+}
+
+func TestParseJSONCommas(t *testing.T) {
+	// Define test cases
+	testCases := []struct {
+		input            string
+		expectedErrorMsg string
+	}{
+		// Leading commas
+		{input: `[,1]`, expectedErrorMsg: "Unexpected ',' at line 1, Column 2:2, expected a value"},
+		{input: `{,"a":1}`, expectedErrorMsg: "Unexpected ',' at line 1, Column 2:2, expected a key"},
+		// Double commas
+		{input: `[1,,2]`, expectedErrorMsg: "Unexpected ',' at line 1, Column 4:4, expected a value"},
+		{input: `{"a":1,,"b":2}`, expectedErrorMsg: "Unexpected ',' at line 1, Column 8:8, expected a key"},
+		// Trailing commas are reported separately
+		{input: `[1,]`, expectedErrorMsg: "Invalid JSON Array, trailing comma not allowed"},
+		{input: `{"a":1,}`, expectedErrorMsg: "Invalid JSON Object, trailing comma not allowed"},
+		// Missing commas
+		{input: `[1 2]`, expectedErrorMsg: "Expected ',' or ']' after array element, got '2' at line 1, Column 4:4"},
+		{input: `{"a":1 "b":2}`, expectedErrorMsg: "Expected ',' or '}' after object member, got 'b' at line 1, Column 8:10"},
+		{input: `[1`, expectedErrorMsg: "Expected ',' or ']' after array element, got 'EOF'"},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.input, func(t *testing.T) {
+			_, err := ParseJSON(lex(testCase.input))
+			if err == nil || !strings.Contains(err.Error(), testCase.expectedErrorMsg) {
+				t.Errorf("Expected error containing %q, got %v", testCase.expectedErrorMsg, err)
+			}
+		})
+	}
+}
+
+func TestParseJSONNumbers(t *testing.T) {
+	// Define test cases
+	testCases := []struct {
+		input             string
+		expectedValue     float64
+		expectedIsInteger bool
+	}{
+		{input: `1`, expectedValue: 1, expectedIsInteger: true},
+		{input: `1.0`, expectedValue: 1, expectedIsInteger: false},
+		{input: `1e2`, expectedValue: 100, expectedIsInteger: false},
+		{input: `-0`, expectedValue: 0, expectedIsInteger: true},
+		{input: `-12.5E-1`, expectedValue: -1.25, expectedIsInteger: false},
+		{input: `12345678901234567890`, expectedValue: 12345678901234567890, expectedIsInteger: true},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.input, func(t *testing.T) {
+			node, err := ParseJSON(lex(testCase.input))
+			if err != nil {
+				t.Fatalf("Expected no parse error, got %v", err)
+			}
+
+			if node.Type != "Number" {
+				t.Fatalf("Expected a Number node, got %s", node.Type)
+			}
+			if node.Value != testCase.expectedValue {
+				t.Errorf("Expected value %v, got %v", testCase.expectedValue, node.Value)
+			}
+			if node.IsInteger != testCase.expectedIsInteger {
+				t.Errorf("Expected IsInteger %v, got %v", testCase.expectedIsInteger, node.IsInteger)
+			}
+			if node.Raw != testCase.input {
+				t.Errorf("Expected raw literal %q, got %q", testCase.input, node.Raw)
+			}
+		})
+	}
+}
+
+func TestParseJSONHexNumbers(t *testing.T) {
+	// Define test cases, hexadecimal integers are only lexed in relaxed mode
+	testCases := []struct {
+		input         string
+		expectedValue float64
+	}{
+		{input: `0x1F`, expectedValue: 31},
+		{input: `0xabc`, expectedValue: 2748},
+		{input: `-0XE`, expectedValue: -14},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.input, func(t *testing.T) {
+			tokens, err := lexer.LexReaderContext(context.Background(), strings.NewReader(testCase.input), lexer.Options{Relaxed: true})
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			node, err := ParseJSON(tokens)
+			if err != nil {
+				t.Fatalf("Expected no parse error, got %v", err)
+			}
+
+			if node.Type != "Number" || node.Value != testCase.expectedValue || !node.IsInteger {
+				t.Errorf("Expected the integer %v, got %s %v (IsInteger %v)", testCase.expectedValue, node.Type, node.Value, node.IsInteger)
+			}
+			if node.Raw != testCase.input {
+				t.Errorf("Expected raw literal %q, got %q", testCase.input, node.Raw)
+			}
+		})
+	}
+}
+
+func TestParseJSONRelaxedKeys(t *testing.T) {
+	// Define test cases, identifiers & single-quoted strings are only lexed as keys in relaxed keys mode
+	testCases := []struct {
+		name             string
+		input            string
+		expectedKeys     []string
+		expectedRawKeys  []string
+		expectedErrorMsg string // Empty if the document is valid
+	}{
+		{name: "double-quoted", input: `{"name": "jl", "a\"b": 1}`, expectedKeys: []string{"name", `a"b`}, expectedRawKeys: []string{"name", `a\"b`}},
+		{name: "unquoted", input: `{name: "jl", max_size2: 1, $ref: null, true: false}`, expectedKeys: []string{"name", "max_size2", "$ref", "true"}, expectedRawKeys: []string{"name", "max_size2", "$ref", "true"}},
+		{name: "single-quoted", input: `{'name': "jl", 'it\'s "x"': 1, '\u0041': 2}`, expectedKeys: []string{"name", `it's "x"`, "A"}, expectedRawKeys: []string{"name", `it's \"x\"`, `\u0041`}},
+		{name: "mixed", input: `{a: {'b': {"c": 1}}}`, expectedKeys: []string{"a"}, expectedRawKeys: []string{"a"}},
+		{
+			name:             "single-quoted value",
+			input:            `{name: 'jl'}`,
+			expectedErrorMsg: "Invalid JSON value ''jl'', single-quoted strings are only allowed as object keys at line 1, Column 8:11",
+		},
+		{
+			name:             "unquoted value",
+			input:            `{name: jl}`,
+			expectedErrorMsg: "Invalid JSON value 'jl' at line 1, Column 8:9",
+		},
+		{
+			name:             "key starting w/ a digit",
+			input:            `{1st: true}`,
+			expectedErrorMsg: "Invalid JSON key, invalid number: unexpected character 's' at line 1, Column 2:4",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			tokens, err := lexer.LexReaderContext(context.Background(), strings.NewReader(testCase.input), lexer.Options{RelaxedKeys: true})
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			node, err := ParseJSONWithOptions(context.Background(), tokens, ParseOptions{RelaxedKeys: true})
+
+			if testCase.expectedErrorMsg != "" {
+				if err == nil || err.Error() != testCase.expectedErrorMsg {
+					t.Errorf("Expected error %q, got %v", testCase.expectedErrorMsg, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Expected no parse error, got %v", err)
+			}
+
+			var actualKeys, actualRawKeys []string
+			for i := 0; i < len(node.Children); i += 2 {
+				actualKeys = append(actualKeys, node.Children[i].Value.(string))
+				actualRawKeys = append(actualRawKeys, node.Children[i].Raw)
+			}
+			if !reflect.DeepEqual(testCase.expectedKeys, actualKeys) {
+				t.Errorf("Expected keys %q, got %q", testCase.expectedKeys, actualKeys)
+			}
+			if !reflect.DeepEqual(testCase.expectedRawKeys, actualRawKeys) {
+				t.Errorf("Expected raw keys %q, got %q", testCase.expectedRawKeys, actualRawKeys)
+			}
+		})
+	}
+
+	// The keys are only relaxed by the parser option
+	tokens, err := lexer.LexReaderContext(context.Background(), strings.NewReader(`{name: 1}`), lexer.Options{RelaxedKeys: true})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if _, err := ParseJSON(tokens); err == nil {
+		t.Errorf("Expected an error for an unquoted key w/o the RelaxedKeys option")
+	}
+}
+
+func TestParseJSONNaNInf(t *testing.T) {
+	tokens, err := lexer.LexReaderContext(context.Background(), strings.NewReader(`[NaN, Infinity, -Infinity, 1]`), lexer.Options{AllowNaNInf: true})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	node, err := ParseJSON(tokens)
+	if err != nil {
+		t.Fatalf("Expected no parse error, got %v", err)
+	}
+
+	values := make([]float64, len(node.Children))
+	for i, child := range node.Children {
+		values[i] = child.Value.(float64)
+		if child.IsInteger != (i == 3) {
+			t.Errorf("Expected %s to be an integer: %v, got %v", child.Raw, i == 3, child.IsInteger)
+		}
+	}
+	if !math.IsNaN(values[0]) || !math.IsInf(values[1], 1) || !math.IsInf(values[2], -1) || values[3] != 1 {
+		t.Errorf("Expected values [NaN +Inf -Inf 1], got %v", values)
+	}
+
+	// The canonical form is strict JSON
+	expectedErrorMsg := "Cannot canonicalize number 'NaN' at line 1, Column 2:4, NaN & Infinity aren't valid JSON"
+	if _, err := Canonical(node); err == nil || err.Error() != expectedErrorMsg {
+		t.Errorf("Expected error %q, got %v", expectedErrorMsg, err)
+	}
+
+	// NaN is the same value in both documents
+	if diffs := Diff(node, node); len(diffs) != 0 {
+		t.Errorf("Expected no differences, got %v", diffs)
+	}
+}
+
+func TestParseJSONNegativeZero(t *testing.T) {
+	testCases := []struct {
+		input             string
+		expectedIsInteger bool
+	}{
+		{input: `-0`, expectedIsInteger: true},
+		{input: `-0.0`, expectedIsInteger: false},
+		{input: `-0e0`, expectedIsInteger: false},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.input, func(t *testing.T) {
+			node, err := ParseJSON(lex(testCase.input))
+			if err != nil {
+				t.Fatalf("Expected no parse error, got %v", err)
+			}
+
+			value := node.Value.(float64)
+			if value != 0 || !math.Signbit(value) {
+				t.Errorf("Expected negative zero, got %v", value)
+			}
+			if node.IsInteger != testCase.expectedIsInteger {
+				t.Errorf("Expected IsInteger %v, got %v", testCase.expectedIsInteger, node.IsInteger)
+			}
+		})
+	}
+
+	// Positive zero must stay distinguishable from negative zero
+	node, err := ParseJSON(lex(`0`))
+	if err != nil {
+		t.Fatalf("Expected no parse error, got %v", err)
+	}
+	if math.Signbit(node.Value.(float64)) {
+		t.Errorf("Expected positive zero for '0', got negative zero")
+	}
+}
+
+func TestParseJSONMalformedNumbers(t *testing.T) {
+	// Define test cases
+	testCases := []struct {
+		input            string
+		expectedErrorMsg string
+	}{
+		{input: `123abc`, expectedErrorMsg: "Invalid JSON value '123abc', invalid number: unexpected character 'a' at line 1, Column 1:6"},
+		{input: `[1.2.3]`, expectedErrorMsg: "Invalid JSON value '1.2.3', invalid number: multiple decimal points at line 1, Column 2:6"},
+		{input: `{"a": 1e2e3}`, expectedErrorMsg: "Invalid JSON value '1e2e3', invalid number: multiple exponents at line 1, Column 7:11"},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.input, func(t *testing.T) {
+			_, err := ParseJSON(lex(testCase.input))
+			if err == nil || !strings.Contains(err.Error(), testCase.expectedErrorMsg) {
+				t.Errorf("Expected error containing %q, got %v", testCase.expectedErrorMsg, err)
+			}
+		})
+	}
+}
+
+func TestParseJSONMaxStringLength(t *testing.T) {
+	// Define test cases
+	testCases := []struct {
+		input            string
+		expectedErrorMsg string
+	}{
+		{input: `["abc", "abcd"]`, expectedErrorMsg: "Invalid JSON value '\"', string too long: exceeds the maximum length of 3 characters at line 1, Column 9:14"},
+		{input: `{"abcd": 1}`, expectedErrorMsg: "Invalid JSON key, string too long: exceeds the maximum length of 3 characters at line 1, Column 2:7"},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.input, func(t *testing.T) {
+			tokens, err := lexer.LexReaderContext(context.Background(), strings.NewReader(testCase.input), lexer.Options{MaxStringLength: 3})
+			if err != nil {
+				t.Fatalf("Expected no lexing error, got %v", err)
+			}
+
+			_, err = ParseJSON(tokens)
+			if err == nil || !strings.Contains(err.Error(), testCase.expectedErrorMsg) {
+				t.Errorf("Expected error containing %q, got %v", testCase.expectedErrorMsg, err)
+			}
+		})
+	}
+}
+
+func TestParseJSONEmptyContainers(t *testing.T) {
+	// Define test cases
+	testCases := []struct {
+		input            string
+		expectedType     string // Type of the empty root container, if valid
+		expectedErrorMsg string // Empty if the input is valid
+	}{
+		// Empty containers need no members or elements
+		{input: `{}`, expectedType: "Object"},
+		{input: `[]`, expectedType: "Array"},
+		{input: "{ \n\t}", expectedType: "Object"},
+		{input: "[\n]", expectedType: "Array"},
+		// Near-empty containers
+		{input: `{,}`, expectedErrorMsg: "Unexpected ',' at line 1, Column 2:2, expected a key"},
+		{input: `[,]`, expectedErrorMsg: "Unexpected ',' at line 1, Column 2:2, expected a value"},
+		{input: `{:}`, expectedErrorMsg: "Unexpected ':' at line 1, Column 2:2, expected a key"},
+		{input: `[:]`, expectedErrorMsg: "Unexpected ':' at line 1, Column 2:2, expected a value"},
+		{input: `{"a":}`, expectedErrorMsg: "Expected value after ':', got '}' at line 1, Column 6:6"},
+		{input: `{"a"}`, expectedErrorMsg: "Invalid JSON, expected ':' at line 1, Column 5:5"},
+		{input: `{`, expectedErrorMsg: "Invalid JSON key at line 1, Column 2:2"},
+		// Values in the position of a key
+		{input: `{1: 2}`, expectedErrorMsg: "Expected string key, got NUM at line 1, Column 2:2"},
+		{input: `{"a": 1, -2.5: 2}`, expectedErrorMsg: "Expected string key, got NUM at line 1, Column 10:13"},
+		{input: `{true: 1}`, expectedErrorMsg: "Expected string key, got TRUE at line 1, Column 2:5"},
+		{input: `{false: 1}`, expectedErrorMsg: "Expected string key, got FALSE at line 1, Column 2:6"},
+		{input: `{null: 1}`, expectedErrorMsg: "Expected string key, got NULL at line 1, Column 2:5"},
+		{input: `{[]: 1}`, expectedErrorMsg: "Expected string key, got LBRACKET at line 1, Column 2:2"},
+		// Unquoted identifiers are reported by the lexer
+		{input: `{abc: 1}`, expectedErrorMsg: "Invalid JSON key"},
+		{input: `[`, expectedErrorMsg: "Invalid JSON value 'EOF' at line 1, Column 2:2"},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.input, func(t *testing.T) {
+			node, err := ParseJSON(lex(testCase.input))
+
+			if testCase.expectedErrorMsg != "" {
+				if err == nil || !strings.Contains(err.Error(), testCase.expectedErrorMsg) {
+					t.Errorf("Expected error containing %q, got %v", testCase.expectedErrorMsg, err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if node.Type != testCase.expectedType || len(node.Children) != 0 {
+				t.Errorf("Expected an empty %s, got %s w/ %d children", testCase.expectedType, node.Type, len(node.Children))
+			}
+		})
+	}
+}
+
+func TestParseJSONMissingValue(t *testing.T) {
+	// Define test cases
+	testCases := []struct {
+		input            string
+		expectedErrorMsg string
+		expectedPath     string
+	}{
+		{input: `{"a":}`, expectedErrorMsg: "Expected value after ':', got '}' at line 1, Column 6:6", expectedPath: "$.a"},
+		{input: `{"a": ,}`, expectedErrorMsg: "Expected value after ':', got ',' at line 1, Column 7:7", expectedPath: "$.a"},
+		{input: `{"a": 1, "b": ]`, expectedErrorMsg: "Expected value after ':', got ']' at line 1, Column 15:15", expectedPath: "$.b"},
+		{input: `{"a"::1}`, expectedErrorMsg: "Expected value after ':', got ':' at line 1, Column 6:6", expectedPath: "$.a"},
+		// At the end of the input, the error points at the colon
+		{input: `{"a":`, expectedErrorMsg: "Expected value after ':', got 'EOF' at line 1, Column 6:6", expectedPath: "$.a"},
+		// A missing colon is reported as such
+		{input: `{"a" 1}`, expectedErrorMsg: "Invalid JSON, expected ':' at line 1, Column 6:6", expectedPath: "$"},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.input, func(t *testing.T) {
+			_, err := ParseJSON(lex(testCase.input))
+			if err == nil || err.Error() != testCase.expectedErrorMsg {
+				t.Errorf("Expected error %q, got %v", testCase.expectedErrorMsg, err)
+			}
+
+			var syntaxErr *SyntaxError
+			if !errors.As(err, &syntaxErr) || syntaxErr.Path != testCase.expectedPath {
+				t.Errorf("Expected a SyntaxError at %s, got %#v", testCase.expectedPath, err)
+			}
+		})
+	}
+}
+
+func TestParseJSONObjectMemberInArray(t *testing.T) {
+	// Define test cases
+	testCases := []struct {
+		input            string
+		expectedErrorMsg string
+		expectedPath     string
+	}{
+		{input: `[ "a": 1 ]`, expectedErrorMsg: "Unexpected ':', object member syntax is not valid inside an array (use '{' & '}' for an object) at line 1, Column 6:6", expectedPath: "$"},
+		{input: `{"list": [1, "b": 2]}`, expectedErrorMsg: "Unexpected ':', object member syntax is not valid inside an array (use '{' & '}' for an object) at line 1, Column 17:17", expectedPath: "$.list"},
+		{input: `[["a": 1]]`, expectedErrorMsg: "Unexpected ':', object member syntax is not valid inside an array (use '{' & '}' for an object) at line 1, Column 6:6", expectedPath: "$[0]"},
+		// A colon after any other element is a missing comma
+		{input: `[1: 2]`, expectedErrorMsg: "Expected ',' or ']' after array element, got ':' at line 1, Column 3:3", expectedPath: "$"},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.input, func(t *testing.T) {
+			_, err := ParseJSON(lex(testCase.input))
+			if err == nil || err.Error() != testCase.expectedErrorMsg {
+				t.Errorf("Expected error %q, got %v", testCase.expectedErrorMsg, err)
+			}
+
+			var syntaxErr *SyntaxError
+			if !errors.As(err, &syntaxErr) || syntaxErr.Path != testCase.expectedPath {
+				t.Errorf("Expected a SyntaxError at %s, got %#v", testCase.expectedPath, err)
+			}
+		})
+	}
+}
+
+func TestParseJSONTrailingWhitespace(t *testing.T) {
+	// Define test cases
+	testCases := []struct {
+		name          string
+		input         string
+		allowComments bool
+	}{
+		{name: "trailing spaces", input: `{"a": 1}   `},
+		{name: "trailing tabs", input: "[1, 2]\t\t"},
+		{name: "trailing newline", input: "{\"a\": 1}\n"},
+		{name: "trailing newlines", input: "true\n\n\n"},
+		{name: "trailing mixed whitespace", input: "\"a\" \t\n \n\t"},
+		{name: "trailing CRLF", input: "{\"a\": 1}\r\n"},
+		{name: "CRLF between lines", input: "{\r\n\t\"a\": 1\r\n}\r\n"},
+		{name: "number followed by a newline", input: "12\n"},
+		{name: "trailing line comment", input: "{\"a\": 1} // done\n", allowComments: true},
+		{name: "trailing block comment", input: "[1]\n/* done */\n\n", allowComments: true},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			opts := lexer.Options{AllowComments: testCase.allowComments}
+			tokens, err := lexer.LexReaderContext(context.Background(), strings.NewReader(testCase.input), opts)
+			if err != nil {
+				t.Fatalf("Expected no lexer error, got %v", err)
+			}
+
+			if _, err := ParseJSON(tokens); err != nil {
+				t.Errorf("Expected no parse error, got %v", err)
+			}
+		})
+	}
+
+	// Whitespace tokens are ignored as well
+	tokens, err := lexer.LexReaderContext(context.Background(), strings.NewReader("{\n\t\"a\": [1, 2]\n}\n"), lexer.Options{EmitWhitespace: true})
+	if err != nil {
+		t.Fatalf("Expected no lexer error, got %v", err)
+	}
+	if _, err := ParseJSON(tokens); err != nil {
+		t.Errorf("Expected no parse error w/ whitespace tokens, got %v", err)
+	}
+
+	// Content other than whitespace & comments is still rejected
+	if _, err := ParseJSON(lex("{}\n x")); err == nil {
+		t.Errorf("Expected an error for content after the document")
+	}
+}
+
+func TestParseJSONAllowedRootTypes(t *testing.T) {
+	containers := []lexer.TokenType{lexer.LBRACE, lexer.LBRACKET}
+	noNull := []lexer.TokenType{lexer.LBRACE, lexer.LBRACKET, lexer.STR, lexer.NUM, lexer.TRUE, lexer.FALSE}
+
+	// Define test cases
+	testCases := []struct {
+		name             string
+		input            string
+		allowedRootTypes []lexer.TokenType
+		expectedErrorMsg string // Empty if the root is allowed
+	}{
+		{name: "default allows null", input: `null`},
+		{name: "default allows strings", input: `"a"`},
+		{name: "object in containers", input: `{}`, allowedRootTypes: containers},
+		{name: "array in containers", input: `[null]`, allowedRootTypes: containers},
+		{
+			name:             "string not in containers",
+			input:            `"a"`,
+			allowedRootTypes: containers,
+			expectedErrorMsg: "Invalid JSON root, got string but only object, array allowed at line 1, Column 1:3",
+		},
+		{name: "boolean w/o null", input: `false`, allowedRootTypes: noNull},
+		{
+			name:             "null w/o null",
+			input:            `null`,
+			allowedRootTypes: noNull,
+			expectedErrorMsg: "Invalid JSON root, got null but only object, array, string, number, true, false allowed at line 1, Column 1:4",
+		},
+		// Syntax errors take precedence over the root type
+		{
+			name:             "invalid root",
+			input:            `nul`,
+			allowedRootTypes: containers,
+			expectedErrorMsg: "Invalid JSON value 'nul', unknown literal 'nul'; did you mean 'null'? at line 1, Column 1:3",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			opts := ParseOptions{AllowedRootTypes: testCase.allowedRootTypes}
+			_, err := ParseJSONWithOptions(context.Background(), lex(testCase.input), opts)
+
+			if testCase.expectedErrorMsg == "" {
+				if err != nil {
+					t.Errorf("Expected no error, got %v", err)
+				}
+			} else if err == nil || err.Error() != testCase.expectedErrorMsg {
+				t.Errorf("Expected error %q, got %v", testCase.expectedErrorMsg, err)
+			}
+		})
+	}
+}
+
+func TestParseJSONMaxValues(t *testing.T) {
+	// Define test cases
+	testCases := []struct {
+		name             string
+		input            string
+		maxElements      int
+		maxMembers       int
+		expectedErrorMsg string // Empty if the containers are within the limits
+		expectedPath     string
+	}{
+		{name: "no limits", input: `[1, 2, 3, {"a": 1, "b": 2}]`},
+		{name: "array at the limit", input: `[1, 2, 3]`, maxElements: 3},
+		{name: "empty array", input: `[]`, maxElements: 1},
+		{
+			name:             "array over the limit",
+			input:            `[1, 2, 3, 4]`,
+			maxElements:      3,
+			expectedErrorMsg: "Array exceeds the maximum of 3 elements at line 1, Column 1:1",
+			expectedPath:     "$",
+		},
+		{
+			name:             "nested array over the limit",
+			input:            "{\"a\": [[1, 2],\n  [1, 2, 3]]}",
+			maxElements:      2,
+			expectedErrorMsg: "Array exceeds the maximum of 2 elements at line 2, Column 3:3",
+			expectedPath:     "$.a[1]",
+		},
+		{name: "object at the limit", input: `{"a": 1, "b": {"c": 2}}`, maxMembers: 2},
+		{name: "empty object", input: `{}`, maxMembers: 1},
+		{
+			name:             "object over the limit",
+			input:            `[{"a": 1, "b": 2, "c": 3}]`,
+			maxMembers:       2,
+			expectedErrorMsg: "Object exceeds the maximum of 2 members at line 1, Column 2:2",
+			expectedPath:     "$[0]",
+		},
+		{
+			name:             "duplicate keys count",
+			input:            `{"a": 1, "a": 2, "a": 3}`,
+			maxMembers:       2,
+			expectedErrorMsg: "Object exceeds the maximum of 2 members at line 1, Column 1:1",
+			expectedPath:     "$",
+		},
+		{name: "limits are independent", input: `{"a": [1, 2, 3], "b": 2}`, maxElements: 3, maxMembers: 2},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			opts := ParseOptions{MaxArrayElements: testCase.maxElements, MaxObjectMembers: testCase.maxMembers}
+			_, err := ParseJSONWithOptions(context.Background(), lex(testCase.input), opts)
+
+			if testCase.expectedErrorMsg == "" {
+				if err != nil {
+					t.Errorf("Expected no error, got %v", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != testCase.expectedErrorMsg {
+				t.Fatalf("Expected error %q, got %v", testCase.expectedErrorMsg, err)
+			}
+			var syntaxErr *SyntaxError
+			if !errors.As(err, &syntaxErr) || syntaxErr.Path != testCase.expectedPath {
+				t.Errorf("Expected a syntax error at %q, got %#v", testCase.expectedPath, err)
+			}
+		})
+	}
+}
+
+func TestParserParse(t *testing.T) {
+	containers := []lexer.TokenType{lexer.LBRACE, lexer.LBRACKET}
+
+	// Define test cases
+	testCases := []struct {
+		name             string
+		input            string
+		opts             ParseOptions
+		expectedRoot     bool     // True if a root node is expected
+		expectedProblems []string // Errors of the problems found, in order
+		expectedSeverity Severity // Severity of the first problem, if any
+		expectedPath     string   // Path of the first problem, if any
+	}{
+		{name: "default options", input: `{"a": 1}`, expectedRoot: true},
+		{name: "duplicate key kept by default", input: `{"a": 1, "a": 2}`, expectedRoot: true},
+		{
+			name:             "duplicate key as warning",
+			input:            `{"a": 1, "a": 2}`,
+			opts:             ParseOptions{DuplicateKeys: DuplicateKeyWarn},
+			expectedRoot:     true,
+			expectedProblems: []string{"Duplicate key 'a' at line 1, Column 10:12"},
+			expectedSeverity: SeverityWarning,
+			expectedPath:     "$.a",
+		},
+		{
+			name:             "duplicate key as error",
+			input:            `{"a": 1, "a": 2}`,
+			opts:             ParseOptions{DuplicateKeys: DuplicateKeyError},
+			expectedRoot:     true,
+			expectedProblems: []string{"Duplicate key 'a' at line 1, Column 10:12"},
+			expectedSeverity: SeverityError,
+			expectedPath:     "$.a",
+		},
+		{
+			name:         "duplicate key kept last w/ containers only",
+			input:        `[{"a": 1, "a": 2}]`,
+			opts:         ParseOptions{AllowedRootTypes: containers, DuplicateKeys: DuplicateKeyKeepLast},
+			expectedRoot: true,
+		},
+		{
+			name:             "empty containers forbidden",
+			input:            `{"a": {}, "a": []}`,
+			opts:             ParseOptions{DuplicateKeys: DuplicateKeyKeepLast, ForbidEmptyObject: true, ForbidEmptyArray: true},
+			expectedRoot:     true,
+			expectedProblems: []string{"Empty object not allowed at line 1, Column 7:7", "Empty array not allowed at line 1, Column 16:16"},
+			expectedSeverity: SeverityWarning,
+			expectedPath:     "$.a",
+		},
+		{
+			name:             "homogeneous arrays",
+			input:            `{"a": [1, "2"], "b": [true, false]}`,
+			opts:             ParseOptions{HomogeneousArrays: true},
+			expectedRoot:     true,
+			expectedProblems: []string{"Array mixes element types, expected number like the 1st element, got string at line 1, Column 11:13"},
+			expectedSeverity: SeverityWarning,
+			expectedPath:     "$.a[1]",
+		},
+		{
+			name:             "root type not allowed",
+			input:            `1`,
+			opts:             ParseOptions{AllowedRootTypes: containers, DuplicateKeys: DuplicateKeyError},
+			expectedProblems: []string{"Invalid JSON root, got number but only object, array allowed at line 1, Column 1:1"},
+			expectedPath:     "$",
+		},
+		// A syntax error stops parsing before any check runs
+		{
+			name:             "syntax error",
+			input:            `{"a": 1, "a": [1 2]}`,
+			opts:             ParseOptions{AllowedRootTypes: containers},
+			expectedProblems: []string{"Expected ',' or ']' after array element, got '2' at line 1, Column 18:18"},
+			expectedPath:     "$.a",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			root, problems := NewParser(lex(testCase.input), testCase.opts).Parse()
+
+			if (root != nil) != testCase.expectedRoot {
+				t.Errorf("Expected root node %v, got %v", testCase.expectedRoot, root)
+			}
+			actual := make([]string, len(problems))
+			for i, problem := range problems {
+				actual[i] = problem.Error()
+			}
+			if strings.Join(actual, "\n") != strings.Join(testCase.expectedProblems, "\n") {
+				t.Fatalf("Expected problems %q, got %q", testCase.expectedProblems, actual)
+			}
+			if len(problems) > 0 {
+				if problems[0].Severity != testCase.expectedSeverity {
+					t.Errorf("Expected severity %v, got %v", testCase.expectedSeverity, problems[0].Severity)
+				}
+				if problems[0].Path != testCase.expectedPath {
+					t.Errorf("Expected path %q, got %q", testCase.expectedPath, problems[0].Path)
+				}
+			}
+		})
+	}
+}
+
+func TestParserParseTwice(t *testing.T) {
+	// The parser restarts from the first token on each call
+	var trace strings.Builder
+	p := NewParser(lex(`[true]`), ParseOptions{Trace: &trace})
+	for i := 0; i < 2; i++ {
+		if root, problems := p.Parse(); root == nil || len(problems) > 0 {
+			t.Fatalf("Expected a root node w/o problems, got %v, %v", root, problems)
+		}
+	}
+
+	if count := strings.Count(trace.String(), "trace: enter array $,"); count != 2 {
+		t.Errorf("Expected 2 traces entering the array, got %d:\n%s", count, trace.String())
+	}
+}
+
+func TestParseJSONEndPosition(t *testing.T) {
+	// Define test cases
+	testCases := []struct {
+		input            string
+		expectedErrorMsg string
+	}{
+		{input: "[1, 2", expectedErrorMsg: "Expected ',' or ']' after array element, got 'EOF' at line 1, Column 6:6"},
+		{input: "[1, 2  ", expectedErrorMsg: "Expected ',' or ']' after array element, got 'EOF' at line 1, Column 8:8"},
+		// After a final newline, the end of the input is at the start of the next line
+		{input: "[1, 2\n", expectedErrorMsg: "Expected ',' or ']' after array element, got 'EOF' at line 2, Column 1:1"},
+		{input: "{\"a\":\n\n", expectedErrorMsg: "Expected value after ':', got 'EOF' at line 3, Column 1:1"},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.input, func(t *testing.T) {
+			lxr := lexer.NewLexer(strings.NewReader(testCase.input), lexer.Options{})
+			tokens, err := lxr.ReadAll(context.Background())
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+
+			_, err = ParseJSONWithOptions(context.Background(), tokens, ParseOptions{End: lxr.End()})
+			if err == nil || err.Error() != testCase.expectedErrorMsg {
+				t.Errorf("Expected error %q, got %v", testCase.expectedErrorMsg, err)
+			}
+		})
+	}
+
+	// W/o the end of the input, the errors point just past the last token
+	expectedErrorMsg := "Expected ',' or ']' after array element, got 'EOF' at line 1, Column 6:6"
+	if _, err := ParseJSON(lex("[1, 2\n")); err == nil || err.Error() != expectedErrorMsg {
+		t.Errorf("Expected error %q, got %v", expectedErrorMsg, err)
+	}
+}
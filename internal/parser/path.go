@@ -0,0 +1,164 @@
+package parser
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// pathSegment is a single step of a path, either an object key or an array index
+type pathSegment struct {
+	key     string
+	index   int
+	isIndex bool
+}
+
+// Lookup returns the node located at the path within the AST.
+//
+// The path is a dot-separated list of keys w/ bracketed array indices, optionally starting w/ "$"
+// (ex. "event.records[0].body" or "$.event.records[0].body"). "$" or an empty path refers to the node itself.
+// For duplicate keys, the last value wins (same as Decode).
+func Lookup(node *ASTNode, path string) (*ASTNode, error) {
+	segments, err := parsePath(path)
+	if err != nil {
+		return nil, err
+	}
+
+	current := node
+	for i, segment := range segments {
+		next := lookupSegment(current, segment)
+		if next == nil {
+			return nil, fmt.Errorf("No value at path '%s', %s has no %s", path, describeNode(current, segments[:i]), segment)
+		}
+		current = next
+	}
+
+	return current, nil
+}
+
+// lookupSegment returns the child of the node matching the segment, or nil if there is none
+func lookupSegment(node *ASTNode, segment pathSegment) *ASTNode {
+	if segment.isIndex {
+		if node.Type != "Array" || segment.index >= len(node.Children) {
+			return nil
+		}
+		return node.Children[segment.index]
+	}
+
+	if node.Type != "Object" {
+		return nil
+	}
+	values, _ := ObjectMembers(node) // The last value wins for duplicate keys
+	return values[segment.key]
+}
+
+// parsePath splits the path into its segments
+func parsePath(path string) ([]pathSegment, error) {
+	rest := strings.TrimPrefix(path, "$")
+
+	var segments []pathSegment
+	for rest != "" {
+		// Bracket-quoted key (ex. ["a.b"]), as written by KeyPath
+		if strings.HasPrefix(rest, `["`) {
+			key, n, err := quotedKey(rest[1:])
+			if err != nil || !strings.HasPrefix(rest[1+n:], "]") {
+				return nil, fmt.Errorf("Invalid path '%s', unterminated quoted key", path)
+			}
+			segments = append(segments, pathSegment{key: key})
+			rest = rest[1+n+1:]
+			continue
+		}
+
+		// Array index
+		if rest[0] == '[' {
+			end := strings.IndexByte(rest, ']')
+			if end < 0 {
+				return nil, fmt.Errorf("Invalid path '%s', unterminated '['", path)
+			}
+			index, err := strconv.Atoi(rest[1:end])
+			if err != nil || index < 0 {
+				return nil, fmt.Errorf("Invalid path '%s', expected an array index, got '%s'", path, rest[1:end])
+			}
+			segments = append(segments, pathSegment{index: index, isIndex: true})
+			rest = rest[end+1:]
+			continue
+		}
+
+		// Object key, runs up to the next segment
+		rest = strings.TrimPrefix(rest, ".")
+		end := strings.IndexAny(rest, ".[")
+		if end < 0 {
+			end = len(rest)
+		}
+		if end == 0 {
+			return nil, fmt.Errorf("Invalid path '%s', empty key", path)
+		}
+		segments = append(segments, pathSegment{key: rest[:end]})
+		rest = rest[end:]
+	}
+
+	return segments, nil
+}
+
+// quotedKey unquotes the double-quoted key starting s, returning it along w/ the length of its quoted form
+func quotedKey(s string) (string, int, error) {
+	for i := 1; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++ // Skip the escaped character
+		case '"':
+			key, err := strconv.Unquote(s[:i+1])
+			return key, i + 1, err
+		}
+	}
+	return "", 0, fmt.Errorf("unterminated quoted key")
+}
+
+// String returns the segment as it is written in a path
+func (s pathSegment) String() string {
+	if s.isIndex {
+		return fmt.Sprintf("index [%d]", s.index)
+	}
+	return fmt.Sprintf("key '%s'", s.key)
+}
+
+// describeNode describes the node reached by the segments for error messages
+func describeNode(node *ASTNode, segments []pathSegment) string {
+	path := "$"
+	for _, segment := range segments {
+		if segment.isIndex {
+			path = IndexPath(path, segment.index)
+		} else {
+			path = KeyPath(path, segment.key)
+		}
+	}
+	return fmt.Sprintf("the %s at %s (line %d, Column %d)", node.Type, path, node.Pos.Line, node.Pos.ColStart)
+}
+
+// KeyPath returns the path of the value of the key within the object located at path (ex. "$.a" + "b" => "$.a.b").
+// Other keys than isPathKey ones are written bracket-quoted (ex. "$.a" + "b.c" => `$.a["b.c"]`), so the path stays unambiguous.
+func KeyPath(path, key string) string {
+	if isPathKey(key) {
+		return path + "." + key
+	}
+	return path + "[" + strconv.Quote(key) + "]"
+}
+
+// isPathKey reports whether the key can be written as is in a path, i.e. it's made of letters, digits, '_', '-' & '$'
+func isPathKey(key string) bool {
+	if key == "" {
+		return false
+	}
+	for _, r := range key {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '_' && r != '-' && r != '$' {
+			return false
+		}
+	}
+	return true
+}
+
+// IndexPath returns the path of the element at index within the array located at path (ex. "$.a" + 0 => "$.a[0]")
+func IndexPath(path string, index int) string {
+	return path + "[" + strconv.Itoa(index) + "]"
+}
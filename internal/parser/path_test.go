@@ -0,0 +1,160 @@
+package parser
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestLookup(t *testing.T) {
+	input := `{"event": {"records": [{"body": "a"}, {"body": "b", "body": "c"}]}, "id": 1, "a.b": {"x[0]": "d", "q\"uote": "e"}}`
+
+	// Define test cases
+	testCases := []struct {
+		path             string
+		expectedValue    interface{}
+		expectedErrorMsg string
+	}{
+		{path: "id", expectedValue: "1"},
+		{path: "$.id", expectedValue: "1"},
+		{path: "event.records[0].body", expectedValue: "a"},
+		{path: "$.event.records[0].body", expectedValue: "a"},
+		// The last value wins for duplicate keys
+		{path: "event.records[1].body", expectedValue: "c"},
+		{path: "$", expectedValue: "Object"},
+		{path: "", expectedValue: "Object"},
+		{path: "missing", expectedErrorMsg: "No value at path 'missing', the Object at $ (line 1, Column 1) has no key 'missing'"},
+		{path: "event.records[2]", expectedErrorMsg: "the Array at $.event.records (line 1, Column 23) has no index [2]"},
+		{path: "id.x", expectedErrorMsg: "the Number at $.id (line 1, Column 75) has no key 'x'"},
+		{path: "event..records", expectedErrorMsg: "Invalid path 'event..records', empty key"},
+		{path: "event.records[x]", expectedErrorMsg: "expected an array index, got 'x'"},
+		{path: "event.records[0", expectedErrorMsg: "unterminated '['"},
+		{path: `$["a.b"]["x[0]"]`, expectedValue: "d"},
+		{path: `["a.b"]["q\"uote"]`, expectedValue: "e"},
+		{path: `["a.b"].y`, expectedErrorMsg: `the Object at $["a.b"] (line 1, Column 85) has no key 'y'`},
+		{path: `["a.b`, expectedErrorMsg: "unterminated quoted key"},
+	}
+
+	root, err := ParseJSON(lex(input))
+	if err != nil {
+		t.Fatalf("Expected no parse error, got %v", err)
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.path, func(t *testing.T) {
+			node, err := Lookup(root, testCase.path)
+			if testCase.expectedErrorMsg != "" {
+				if err == nil || !strings.Contains(err.Error(), testCase.expectedErrorMsg) {
+					t.Errorf("Expected error containing %q, got %v", testCase.expectedErrorMsg, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+
+			// Compare containers by type, numbers by lexeme & strings by value
+			var actual interface{}
+			switch node.Type {
+			case "Object", "Array":
+				actual = node.Type
+			case "Number":
+				actual = node.Raw
+			default:
+				actual = node.Value
+			}
+			if actual != testCase.expectedValue {
+				t.Errorf("Expected %v, got %v", testCase.expectedValue, actual)
+			}
+		})
+	}
+}
+
+func TestSyntaxErrorPath(t *testing.T) {
+	// Define test cases, the error is positioned at the token being parsed (all on line 1)
+	testCases := []struct {
+		input          string
+		expectedPath   string
+		expectedColumn int
+	}{
+		{input: `nul`, expectedPath: "$", expectedColumn: 1},
+		{input: `[1] 2`, expectedPath: "$", expectedColumn: 5},
+		{input: `{"users": [{"name": "a"}, {"name": "b", "email": tru}]}`, expectedPath: "$.users[1].email", expectedColumn: 50},
+		{input: `{"users": [{"name": "a"}, {"name": "b" "email": 1}]}`, expectedPath: "$.users[1]", expectedColumn: 40},
+		{input: `[[1, 2], [3, [4, 5,]]]`, expectedPath: "$[1][1]", expectedColumn: 19},
+		{input: `{"a": {"b": [1, "\x"]}}`, expectedPath: "$.a.b[1]", expectedColumn: 17},
+		{input: `{"a": {"b": [1, 2}}`, expectedPath: "$.a.b", expectedColumn: 18},
+		// Keys which can't be written as is are bracket-quoted, so the paths stay distinct
+		{input: `{"a.b": {"c": [1,]}}`, expectedPath: `$["a.b"].c`, expectedColumn: 17},
+		{input: `{"a": {"b": {"c": [1,]}}}`, expectedPath: "$.a.b.c", expectedColumn: 21},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.input, func(t *testing.T) {
+			_, err := ParseJSON(lex(testCase.input))
+
+			var syntaxErr *SyntaxError
+			if !errors.As(err, &syntaxErr) {
+				t.Fatalf("Expected a *SyntaxError, got %v", err)
+			}
+			if syntaxErr.Path != testCase.expectedPath {
+				t.Errorf("Expected path %q, got %q (%v)", testCase.expectedPath, syntaxErr.Path, err)
+			}
+			if syntaxErr.Pos.Line != 1 || syntaxErr.Pos.ColStart != testCase.expectedColumn {
+				t.Errorf("Expected position 1:%d, got %d:%d (%v)", testCase.expectedColumn, syntaxErr.Pos.Line, syntaxErr.Pos.ColStart, err)
+			}
+		})
+	}
+}
+
+func TestKeyPath(t *testing.T) {
+	// Define test cases
+	testCases := []struct {
+		key      string
+		expected string
+	}{
+		{key: "b", expected: "$.a.b"},
+		{key: "user_id-2$", expected: "$.a.user_id-2$"},
+		{key: "é", expected: "$.a.é"},
+		{key: "b.c", expected: `$.a["b.c"]`},
+		{key: "x[0]", expected: `$.a["x[0]"]`},
+		{key: `q"uote`, expected: `$.a["q\"uote"]`},
+		{key: "", expected: `$.a[""]`},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.key, func(t *testing.T) {
+			path := KeyPath("$.a", testCase.key)
+			if path != testCase.expected {
+				t.Errorf("Expected %s, got %s", testCase.expected, path)
+			}
+
+			// The path leads back to the key
+			root, err := ParseJSON(lex(`{"a": {` + strconv.Quote(testCase.key) + `: 1}}`))
+			if err != nil {
+				t.Fatalf("Expected no parse error, got %v", err)
+			}
+			if node, err := Lookup(root, path); err != nil || node.Raw != "1" {
+				t.Errorf("Expected the number 1 at %s, got %v (%v)", path, node, err)
+			}
+		})
+	}
+}
+
+func TestFindingPath(t *testing.T) {
+	rootNode, err := ParseJSON(lex(`{"users": [{"id": 1}, {"id": 2, "tags": {"x": 1, "x": 2}}]}`))
+	if err != nil {
+		t.Fatalf("Expected no parse error, got %v", err)
+	}
+
+	warnings := CheckDuplicateKeys(rootNode)
+	if len(warnings) != 1 || warnings[0].Path != "$.users[1].tags.x" {
+		t.Errorf("Expected a duplicate key at $.users[1].tags.x, got %+v", warnings)
+	}
+
+	errs := CheckRequiredKeys(rootNode, []string{"version"})
+	if len(errs) != 1 || errs[0].Path != "$" {
+		t.Errorf("Expected a missing key at $, got %+v", errs)
+	}
+}
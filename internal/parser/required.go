@@ -0,0 +1,41 @@
+package parser
+
+import "fmt"
+
+// RuleRequiredKeys is the name of the lint rule reporting top-level keys missing from the root object
+const RuleRequiredKeys = "required-keys"
+
+// CheckRequiredKeys returns an error for each of the keys missing from the root object, positioned at the root.
+// If the root isn't an object, a single error is returned instead.
+func CheckRequiredKeys(node *ASTNode, keys []string) []ParseError {
+	if len(keys) == 0 {
+		return nil
+	}
+
+	if node.Type != "Object" {
+		return []ParseError{{
+			Severity: SeverityError,
+			Rule:     RuleRequiredKeys,
+			Message:  fmt.Sprintf("Expected the root to be an Object containing the required keys, got %s", node.Type),
+			Pos:      node.Pos,
+			Path:     "$",
+		}}
+	}
+
+	present, _ := ObjectMembers(node)
+
+	var errs []ParseError
+	for _, key := range keys {
+		if _, ok := present[key]; !ok {
+			errs = append(errs, ParseError{
+				Severity: SeverityError,
+				Rule:     RuleRequiredKeys,
+				Message:  fmt.Sprintf("Missing required key '%s'", key),
+				Pos:      node.Pos,
+				Path:     "$",
+			})
+		}
+	}
+
+	return errs
+}
@@ -0,0 +1,51 @@
+package parser
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCheckRequiredKeys(t *testing.T) {
+	// Define test cases
+	testCases := []struct {
+		name           string
+		input          string
+		keys           []string
+		expectedErrors []string
+	}{
+		{name: "no required keys", input: `[]`},
+		{name: "all present", input: `{"a": 1, "b": {"c": 2}, "c": 3}`, keys: []string{"a", "b", "c"}},
+		{
+			name:           "some missing",
+			input:          "\n  {\"a\": 1, \"nested\": {\"b\": 2}}",
+			keys:           []string{"a", "b", "c"},
+			expectedErrors: []string{"Missing required key 'b' at line 2, Column 3:3", "Missing required key 'c' at line 2, Column 3:3"},
+		},
+		{
+			name:           "non-object root",
+			input:          `[{"a": 1}]`,
+			keys:           []string{"a"},
+			expectedErrors: []string{"Expected the root to be an Object containing the required keys, got Array at line 1, Column 1:1"},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			node, err := ParseJSON(lex(testCase.input))
+			if err != nil {
+				t.Fatalf("Expected no parse error, got %v", err)
+			}
+
+			var actualErrors []string
+			for _, finding := range CheckRequiredKeys(node, testCase.keys) {
+				if finding.Severity != SeverityError || finding.Rule != RuleRequiredKeys {
+					t.Errorf("Expected a %s error, got %s %s", RuleRequiredKeys, finding.Severity, finding.Rule)
+				}
+				actualErrors = append(actualErrors, finding.Error())
+			}
+			if !reflect.DeepEqual(testCase.expectedErrors, actualErrors) {
+				t.Errorf("Expected errors %q, got %q", testCase.expectedErrors, actualErrors)
+			}
+		})
+	}
+}
@@ -0,0 +1,57 @@
+package parser
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pszponder/json-linter_go/internal/lexer"
+)
+
+// RuleExpectType is the name of the lint rule reporting a root value which isn't of the expected type
+const RuleExpectType = "expect-type"
+
+// rootTypes lists the types the root can be expected to be, named like the AST node types but in lowercase,
+// w/ the token types starting a value of that type (see ParseOptions.AllowedRootTypes)
+var rootTypes = []struct {
+	name     string
+	tokTypes []lexer.TokenType
+}{
+	{"object", []lexer.TokenType{lexer.LBRACE}},
+	{"array", []lexer.TokenType{lexer.LBRACKET}},
+	{"string", []lexer.TokenType{lexer.STR}},
+	{"number", []lexer.TokenType{lexer.NUM}},
+	{"boolean", []lexer.TokenType{lexer.TRUE, lexer.FALSE}},
+	{"null", []lexer.TokenType{lexer.NULL}},
+}
+
+// ParseRootType returns the token types starting a value of the named root type (ex. "boolean"),
+// which can restrict ParseOptions.AllowedRootTypes. An empty name means any type, returning no token types.
+func ParseRootType(name string) ([]lexer.TokenType, error) {
+	if name == "" {
+		return nil, nil
+	}
+
+	names := make([]string, 0, len(rootTypes))
+	for _, rootType := range rootTypes {
+		if rootType.name == name {
+			return rootType.tokTypes, nil
+		}
+		names = append(names, rootType.name)
+	}
+	return nil, fmt.Errorf("unknown root type %q, expected one of %s", name, strings.Join(names, ", "))
+}
+
+// CheckRootType returns an error positioned at the root if it isn't of the expected type (see ParseRootType).
+func CheckRootType(node *ASTNode, expected string) []ParseError {
+	if expected == "" || strings.EqualFold(node.Type, expected) {
+		return nil
+	}
+
+	return []ParseError{{
+		Severity: SeverityError,
+		Rule:     RuleExpectType,
+		Message:  fmt.Sprintf("Expected the root to be %s, got %s", expected, strings.ToLower(node.Type)),
+		Pos:      node.Pos,
+		Path:     "$",
+	}}
+}
@@ -0,0 +1,88 @@
+package parser
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/pszponder/json-linter_go/internal/lexer"
+)
+
+func TestParseRootType(t *testing.T) {
+	// Define test cases
+	testCases := []struct {
+		name             string
+		expectedTokTypes []lexer.TokenType
+		expectErr        bool
+	}{
+		{name: ""},
+		{name: "object", expectedTokTypes: []lexer.TokenType{lexer.LBRACE}},
+		{name: "boolean", expectedTokTypes: []lexer.TokenType{lexer.TRUE, lexer.FALSE}},
+		{name: "null", expectedTokTypes: []lexer.TokenType{lexer.NULL}},
+		{name: "list", expectErr: true},
+		{name: "Object", expectErr: true},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			tokTypes, err := ParseRootType(testCase.name)
+			if (err != nil) != testCase.expectErr {
+				t.Fatalf("Expected error: %v, got %v", testCase.expectErr, err)
+			}
+			if !reflect.DeepEqual(testCase.expectedTokTypes, tokTypes) {
+				t.Errorf("Expected token types %v, got %v", testCase.expectedTokTypes, tokTypes)
+			}
+
+			// A value of the type is accepted when the root is restricted to it
+			if len(tokTypes) > 0 {
+				opts := ParseOptions{AllowedRootTypes: tokTypes}
+				if err := opts.checkRootType(lexer.Token{TokType: tokTypes[0]}); err != nil {
+					t.Errorf("Expected the root type to be allowed, got %v", err)
+				}
+			}
+		})
+	}
+}
+
+func TestCheckRootType(t *testing.T) {
+	// Define test cases
+	testCases := []struct {
+		name           string
+		input          string
+		expected       string
+		expectedErrors []string
+	}{
+		{name: "no expected type", input: `[]`},
+		{name: "object", input: `{"a": 1}`, expected: "object"},
+		{name: "array", input: `[1, 2]`, expected: "array"},
+		{name: "string", input: `"a"`, expected: "string"},
+		{name: "number", input: `-1.5`, expected: "number"},
+		{name: "boolean", input: `false`, expected: "boolean"},
+		{name: "null", input: `null`, expected: "null"},
+		{name: "object, got array", input: "\n  [{\"a\": 1}]", expected: "object", expectedErrors: []string{"Expected the root to be object, got array at line 2, Column 3:3"}},
+		{name: "array, got object", input: `{"a": [1]}`, expected: "array", expectedErrors: []string{"Expected the root to be array, got object at line 1, Column 1:1"}},
+		{name: "string, got number", input: `12`, expected: "string", expectedErrors: []string{"Expected the root to be string, got number at line 1, Column 1:2"}},
+		{name: "number, got string", input: `"12"`, expected: "number", expectedErrors: []string{"Expected the root to be number, got string at line 1, Column 1:4"}},
+		{name: "boolean, got null", input: `null`, expected: "boolean", expectedErrors: []string{"Expected the root to be boolean, got null at line 1, Column 1:4"}},
+		{name: "null, got boolean", input: `true`, expected: "null", expectedErrors: []string{"Expected the root to be null, got boolean at line 1, Column 1:4"}},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			node, err := ParseJSON(lex(testCase.input))
+			if err != nil {
+				t.Fatalf("Expected no parse error, got %v", err)
+			}
+
+			var actualErrors []string
+			for _, finding := range CheckRootType(node, testCase.expected) {
+				if finding.Severity != SeverityError || finding.Rule != RuleExpectType || finding.Path != "$" {
+					t.Errorf("Expected a %s error at $, got %s %s at %s", RuleExpectType, finding.Severity, finding.Rule, finding.Path)
+				}
+				actualErrors = append(actualErrors, finding.Error())
+			}
+			if !reflect.DeepEqual(testCase.expectedErrors, actualErrors) {
+				t.Errorf("Expected errors %q, got %q", testCase.expectedErrors, actualErrors)
+			}
+		})
+	}
+}
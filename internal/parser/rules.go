@@ -0,0 +1,83 @@
+package parser
+
+import (
+	"fmt"
+	"sort"
+)
+
+// RuleLevel determines how the findings of a lint rule are reported
+type RuleLevel string
+
+const (
+	RuleOff   RuleLevel = "off"   // The findings are dropped
+	RuleWarn  RuleLevel = "warn"  // The findings are reported as warnings
+	RuleError RuleLevel = "error" // The findings are reported as errors, making the document invalid
+)
+
+// ParseRuleLevel returns the RuleLevel named by name (ex. "warn")
+func ParseRuleLevel(name string) (RuleLevel, error) {
+	switch level := RuleLevel(name); level {
+	case RuleOff, RuleWarn, RuleError:
+		return level, nil
+	default:
+		return "", fmt.Errorf("unknown rule level %q, expected %q, %q or %q", name, RuleOff, RuleWarn, RuleError)
+	}
+}
+
+// ParseRuleLevels parses a rule config, a JSON object mapping rule names to levels (ex. {"duplicate-keys": "error"}).
+// The names of the rules aren't checked, as the rules are defined by several packages.
+func ParseRuleLevels(data []byte) (map[string]RuleLevel, error) {
+	doc, err := DecodeWithOptions(data, DecodeOptions{DuplicateKeys: DuplicateKeyError})
+	if err != nil {
+		return nil, err
+	}
+	object, ok := doc.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("expected an object mapping rule names to levels")
+	}
+
+	// Report the invalid levels in a stable order
+	rules := make([]string, 0, len(object))
+	for rule := range object {
+		rules = append(rules, rule)
+	}
+	sort.Strings(rules)
+
+	levels := make(map[string]RuleLevel, len(object))
+	for _, rule := range rules {
+		name, ok := object[rule].(string)
+		if !ok {
+			return nil, fmt.Errorf("rule %q: expected a level (%q, %q or %q)", rule, RuleOff, RuleWarn, RuleError)
+		}
+		level, err := ParseRuleLevel(name)
+		if err != nil {
+			return nil, fmt.Errorf("rule %q: %v", rule, err)
+		}
+		levels[rule] = level
+	}
+	return levels, nil
+}
+
+// ApplyRuleLevels reports the findings of each rule at its level, dropping the findings of the rules turned off.
+// The findings of rules w/o a level keep their severity, and syntax errors (findings w/o a Rule) are kept as is.
+func ApplyRuleLevels(findings []ParseError, levels map[string]RuleLevel) []ParseError {
+	if len(levels) == 0 {
+		return findings
+	}
+
+	var kept []ParseError
+	for _, finding := range findings {
+		if finding.Rule != "" {
+			switch levels[finding.Rule] {
+			case RuleOff:
+				continue
+			case RuleWarn:
+				finding.Severity = SeverityWarning
+			case RuleError:
+				finding.Severity = SeverityError
+			}
+		}
+		kept = append(kept, finding)
+	}
+	return kept
+}
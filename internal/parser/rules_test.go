@@ -0,0 +1,93 @@
+package parser
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseRuleLevels(t *testing.T) {
+	// Define test cases
+	testCases := []struct {
+		name           string
+		input          string
+		expectedLevels map[string]RuleLevel
+		expectedErr    string
+	}{
+		{
+			name:           "levels",
+			input:          `{"duplicate-keys": "warn", "empty-container": "error", "schema": "off"}`,
+			expectedLevels: map[string]RuleLevel{RuleDuplicateKeys: RuleWarn, RuleEmptyContainer: RuleError, "schema": RuleOff},
+		},
+		{name: "empty", input: `{}`, expectedLevels: map[string]RuleLevel{}},
+		{name: "not an object", input: `["warn"]`, expectedErr: "expected an object mapping rule names to levels"},
+		{name: "unknown level", input: `{"schema": "info"}`, expectedErr: `rule "schema": unknown rule level "info", expected "off", "warn" or "error"`},
+		{name: "not a string", input: `{"schema": true}`, expectedErr: `rule "schema": expected a level ("off", "warn" or "error")`},
+		{name: "duplicate rule", input: `{"schema": "off", "schema": "warn"}`, expectedErr: "Duplicate key 'schema' at line 1, Column 19:26"},
+		{name: "invalid JSON", input: `{"schema": "off",}`, expectedErr: "Invalid JSON Object, trailing comma not allowed at Line 1, Column 17:17"},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			levels, err := ParseRuleLevels([]byte(testCase.input))
+
+			if testCase.expectedErr != "" {
+				if err == nil || err.Error() != testCase.expectedErr {
+					t.Fatalf("Expected error %q, got %v", testCase.expectedErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if !reflect.DeepEqual(testCase.expectedLevels, levels) {
+				t.Errorf("Expected levels %v, got %v", testCase.expectedLevels, levels)
+			}
+		})
+	}
+}
+
+func TestApplyRuleLevels(t *testing.T) {
+	findings := []ParseError{
+		{Message: "syntax", Severity: SeverityError},
+		{Message: "empty", Rule: RuleEmptyContainer, Severity: SeverityWarning},
+		{Message: "duplicate", Rule: RuleDuplicateKeys, Severity: SeverityError},
+		{Message: "newline", Rule: RuleFinalNewline, Severity: SeverityWarning},
+	}
+
+	// Define test cases
+	testCases := []struct {
+		name             string
+		levels           map[string]RuleLevel
+		expectedFindings []string
+	}{
+		{name: "no levels", expectedFindings: []string{"error: syntax", "warning: empty", "error: duplicate", "warning: newline"}},
+		{
+			name:             "raise & lower",
+			levels:           map[string]RuleLevel{RuleEmptyContainer: RuleError, RuleDuplicateKeys: RuleWarn},
+			expectedFindings: []string{"error: syntax", "error: empty", "warning: duplicate", "warning: newline"},
+		},
+		{
+			name:             "off",
+			levels:           map[string]RuleLevel{RuleEmptyContainer: RuleOff, RuleFinalNewline: RuleOff},
+			expectedFindings: []string{"error: syntax", "error: duplicate"},
+		},
+		{
+			name:             "syntax errors kept",
+			levels:           map[string]RuleLevel{"": RuleOff},
+			expectedFindings: []string{"error: syntax", "warning: empty", "error: duplicate", "warning: newline"},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			var actualFindings []string
+			for _, finding := range ApplyRuleLevels(findings, testCase.levels) {
+				actualFindings = append(actualFindings, finding.Severity.String()+": "+finding.Message)
+			}
+
+			if !reflect.DeepEqual(testCase.expectedFindings, actualFindings) {
+				t.Errorf("Expected findings %q, got %q", testCase.expectedFindings, actualFindings)
+			}
+		})
+	}
+}
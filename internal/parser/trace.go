@@ -0,0 +1,17 @@
+package parser
+
+import (
+	"fmt"
+
+	"github.com/pszponder/json-linter_go/internal/lexer"
+)
+
+// trace writes the decision taken by the parser for the value located at path, along w/ the current token,
+// if tracing is enabled (ex. "trace: enter object $.a, at LBRACE '{' at line 1, Column 7:7")
+func (p *Parser) trace(decision string, path string, tok lexer.Token) {
+	if p.opts.Trace == nil {
+		return
+	}
+	fmt.Fprintf(p.opts.Trace, "trace: %s %s, at %v '%s' at line %d, Column %d:%d\n",
+		decision, path, tok.TokType, tok.Lexeme, tok.TokPos.Line, tok.TokPos.ColStart, tok.TokPos.ColEnd)
+}
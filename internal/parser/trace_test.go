@@ -0,0 +1,44 @@
+package parser
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestParseTrace(t *testing.T) {
+	var trace strings.Builder
+	if _, err := ParseJSONWithOptions(context.Background(), lex(`{"a": [1]}`), ParseOptions{Trace: &trace}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	expected := []string{
+		"trace: parse value $, at LBRACE '{' at line 1, Column 1:1",
+		"trace: enter object $, at LBRACE '{' at line 1, Column 1:1",
+		"trace: read key $.a, at STR 'a' at line 1, Column 2:4",
+		"trace: expect ':' $.a, at COLON ':' at line 1, Column 5:5",
+		"trace: parse value $.a, at LBRACKET '[' at line 1, Column 7:7",
+		"trace: enter array $.a, at LBRACKET '[' at line 1, Column 7:7",
+		"trace: parse value $.a[0], at NUM '1' at line 1, Column 8:8",
+		"trace: expect ',' or ']' $.a, at RBRACKET ']' at line 1, Column 9:9",
+		"trace: exit array $.a, at RBRACKET ']' at line 1, Column 9:9",
+		"trace: expect ',' or '}' $, at RBRACE '}' at line 1, Column 10:10",
+		"trace: exit object $, at RBRACE '}' at line 1, Column 10:10",
+	}
+	if actual := strings.Split(strings.TrimSuffix(trace.String(), "\n"), "\n"); strings.Join(actual, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("Expected trace:\n%s\ngot:\n%s", strings.Join(expected, "\n"), trace.String())
+	}
+}
+
+func TestParseTraceInvalid(t *testing.T) {
+	// The trace ends w/ the decision which failed
+	var trace strings.Builder
+	if _, err := ParseJSONWithOptions(context.Background(), lex(`[1 2]`), ParseOptions{Trace: &trace}); err == nil {
+		t.Fatalf("Expected an error, got nil")
+	}
+
+	expected := "trace: expect ',' or ']' $, at NUM '2' at line 1, Column 4:4\n"
+	if !strings.HasSuffix(trace.String(), expected) {
+		t.Errorf("Expected the trace to end w/ %q, got %q", expected, trace.String())
+	}
+}
@@ -0,0 +1,34 @@
+package parser
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	"github.com/pszponder/json-linter_go/internal/lexer"
+)
+
+// ValidateAt parses a single JSON value starting at the byte offset within data, ignoring any content after the value.
+// This allows validating JSON embedded in a larger input (ex. a JSON blob inside a log line).
+// Whitespace before the value is skipped, and positions reported in errors are relative to the offset.
+//
+// Returns:
+// the byte offset just past the end of the value within data
+func ValidateAt(data []byte, offset int) (int, error) {
+	if offset < 0 || offset > len(data) {
+		return 0, fmt.Errorf("Offset %d is out of range for input of length %d", offset, len(data))
+	}
+
+	tokens := lexer.LexReader(bytes.NewReader(data[offset:]))
+	if len(tokens) == 0 {
+		return 0, fmt.Errorf("No Tokens provided")
+	}
+
+	// Parse only the value at the start of the tokens, the remaining tokens belong to the surrounding content
+	p := &Parser{tokens: tokens}
+	if _, err := p.parseValue(context.Background(), "$"); err != nil {
+		return 0, err
+	}
+
+	return offset + tokens[p.index-1].EndOffset(), nil
+}
@@ -0,0 +1,78 @@
+package parser
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidateAt(t *testing.T) {
+	// Define test cases, the value to validate starts at the first occurrence of start
+	testCases := []struct {
+		data          string
+		start         string
+		expectedValue string
+	}{
+		{
+			data:          `level=info payload={"user": "bob", "ids": [1, 2]} took=3ms`,
+			start:         `{`,
+			expectedValue: `{"user": "bob", "ids": [1, 2]}`,
+		},
+		{
+			data:          `2024-01-01 ["nested", {"a": [true, null]}], "trailing": {`,
+			start:         `[`,
+			expectedValue: `["nested", {"a": [true, null]}]`,
+		},
+		{
+			data:          `name="jürgen"; age=42`,
+			start:         `"`,
+			expectedValue: `"jürgen"`,
+		},
+		{
+			data:          `count: -1.5e3 items`,
+			start:         `-`,
+			expectedValue: `-1.5e3`,
+		},
+		{
+			// Whitespace before the value is skipped
+			data:          `value:   null!`,
+			start:         ` `,
+			expectedValue: `   null`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.data, func(t *testing.T) {
+			offset := strings.Index(testCase.data, testCase.start)
+
+			endOffset, err := ValidateAt([]byte(testCase.data), offset)
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+
+			if actualValue := testCase.data[offset:endOffset]; actualValue != testCase.expectedValue {
+				t.Errorf("Expected value %q, got %q", testCase.expectedValue, actualValue)
+			}
+		})
+	}
+}
+
+func TestValidateAtInvalid(t *testing.T) {
+	testCases := []struct {
+		data   string
+		offset int
+	}{
+		{data: `payload={"user": } done`, offset: 8},
+		{data: `payload=[1, 2,] done`, offset: 8},
+		{data: `payload=`, offset: 8},
+		{data: `payload={}`, offset: -1},
+		{data: `payload={}`, offset: 11},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.data, func(t *testing.T) {
+			if _, err := ValidateAt([]byte(testCase.data), testCase.offset); err == nil {
+				t.Errorf("Expected an error validating at offset %d, got nil", testCase.offset)
+			}
+		})
+	}
+}
@@ -0,0 +1,132 @@
+package parser
+
+import (
+	"sort"
+	"strings"
+)
+
+// WalkFunc is called by Walk for each node in the AST, along w/ the number of objects & arrays enclosing the node.
+// Returning false skips the node's children.
+type WalkFunc func(node *ASTNode, depth int) bool
+
+// Walk traverses the AST in depth-first (document) order, calling fn for each node
+func Walk(node *ASTNode, fn WalkFunc) {
+	walk(node, 0, fn)
+}
+
+// walk calls fn for the node at the specified depth, then for each of its children
+func walk(node *ASTNode, depth int, fn WalkFunc) {
+	if !fn(node, depth) {
+		return
+	}
+
+	for _, child := range node.Children {
+		walk(child, depth+1, fn)
+	}
+}
+
+// PathWalkFunc is called by WalkPaths for each value in the AST, along w/ its path (ex. "$.users[3].email").
+// Returning false skips the value's children.
+type PathWalkFunc func(node *ASTNode, path string) bool
+
+// WalkPaths traverses the values of the AST in depth-first (document) order, calling fn for each value.
+// The Key nodes of objects aren't visited, their name being part of the path of their value instead.
+func WalkPaths(node *ASTNode, fn PathWalkFunc) {
+	walkPaths(node, "$", fn)
+}
+
+// walkPaths calls fn for the node located at path, then for each of its values
+func walkPaths(node *ASTNode, path string, fn PathWalkFunc) {
+	if !fn(node, path) {
+		return
+	}
+
+	switch node.Type {
+	case "Object":
+		for _, member := range Members(node) {
+			walkPaths(member.Value, KeyPath(path, member.Key), fn)
+		}
+	case "Array":
+		for i, child := range node.Children {
+			walkPaths(child, IndexPath(path, i), fn)
+		}
+	}
+}
+
+// Member is a key of an object along w/ its value
+type Member struct {
+	Key     string
+	KeyNode *ASTNode
+	Value   *ASTNode
+}
+
+// Members returns the members of the object node in document order, duplicate keys included
+func Members(node *ASTNode) []Member {
+	// Children alternate between Key and value nodes
+	members := make([]Member, 0, len(node.Children)/2)
+	for i := 0; i+1 < len(node.Children); i += 2 {
+		members = append(members, Member{Key: node.Children[i].Value.(string), KeyNode: node.Children[i], Value: node.Children[i+1]})
+	}
+	return members
+}
+
+// ObjectMembers returns the value of each key of the object node, along w/ the distinct keys in document order.
+// For duplicate keys, the last value wins (same as Decode).
+func ObjectMembers(node *ASTNode) (map[string]*ASTNode, []string) {
+	values := make(map[string]*ASTNode, len(node.Children)/2)
+	var keys []string
+	for _, member := range Members(node) {
+		if _, ok := values[member.Key]; !ok {
+			keys = append(keys, member.Key)
+		}
+		values[member.Key] = member.Value
+	}
+	return values, keys
+}
+
+// MaxDepth returns the maximum nesting depth of objects & arrays in the AST.
+// A scalar root has a depth of 0, while [[1]] has a depth of 2.
+func MaxDepth(node *ASTNode) int {
+	maxDepth := 0
+
+	Walk(node, func(node *ASTNode, depth int) bool {
+		// A container adds a level of nesting to the containers enclosing it
+		if (node.Type == "Object" || node.Type == "Array") && depth+1 > maxDepth {
+			maxDepth = depth + 1
+		}
+		return true
+	})
+
+	return maxDepth
+}
+
+// KeyPaths returns the distinct paths of the object keys in the AST, sorted (ex. "server.port" or "users[].email").
+// The indices of arrays are collapsed to "[]", so the keys of every element share the same paths.
+func KeyPaths(node *ASTNode) []string {
+	seen := make(map[string]bool)
+	collectKeyPaths(node, "", seen)
+
+	paths := make([]string, 0, len(seen))
+	for path := range seen {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+// collectKeyPaths adds the paths of the keys within the node located at path to seen
+func collectKeyPaths(node *ASTNode, path string, seen map[string]bool) {
+	switch node.Type {
+	case "Object":
+		for _, member := range Members(node) {
+			// The paths are written w/o the leading "$", keys w/ other characters than isPathKey ones being bracket-quoted (ex. ["a.b"].c)
+			keyPath := strings.TrimPrefix(KeyPath(path, member.Key), ".")
+			seen[keyPath] = true
+			collectKeyPaths(member.Value, keyPath, seen)
+		}
+	case "Array":
+		for _, element := range node.Children {
+			collectKeyPaths(element, path+"[]", seen)
+		}
+	}
+}
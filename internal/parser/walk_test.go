@@ -0,0 +1,140 @@
+package parser
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestWalk(t *testing.T) {
+	rootNode, err := ParseJSON(lex(`{"a": [1, {"b": null}], "c": true}`))
+	if err != nil {
+		t.Fatalf("Expected no parse error, got %v", err)
+	}
+
+	var visited []string
+	Walk(rootNode, func(node *ASTNode, depth int) bool {
+		visited = append(visited, strings.Repeat(".", depth)+node.Type)
+		return node.Type != "Object" || depth == 0 // Skip the children of nested objects
+	})
+
+	expected := []string{"Object", ".Key", ".Array", "..Number", "..Object", ".Key", ".Boolean"}
+	if strings.Join(visited, " ") != strings.Join(expected, " ") {
+		t.Errorf("Expected nodes %v, got %v", expected, visited)
+	}
+}
+
+func TestWalkPaths(t *testing.T) {
+	rootNode, err := ParseJSON(lex(`{"a": [1, {"b": null}], "c.d": {"e": true}}`))
+	if err != nil {
+		t.Fatalf("Expected no parse error, got %v", err)
+	}
+
+	var visited []string
+	WalkPaths(rootNode, func(node *ASTNode, path string) bool {
+		visited = append(visited, path+"="+node.Type)
+		return path != `$["c.d"]` // Skip the children of the quoted key
+	})
+
+	expected := []string{"$=Object", "$.a=Array", "$.a[0]=Number", "$.a[1]=Object", "$.a[1].b=Null", `$["c.d"]=Object`}
+	if !reflect.DeepEqual(expected, visited) {
+		t.Errorf("Expected values %v, got %v", expected, visited)
+	}
+}
+
+func TestObjectMembers(t *testing.T) {
+	rootNode, err := ParseJSON(lex(`{"b": 1, "a": 2, "b": 3}`))
+	if err != nil {
+		t.Fatalf("Expected no parse error, got %v", err)
+	}
+
+	// Every member is listed, duplicates included
+	var members []string
+	for _, member := range Members(rootNode) {
+		members = append(members, member.Key+"="+member.Value.Raw)
+	}
+	if expected := []string{"b=1", "a=2", "b=3"}; !reflect.DeepEqual(expected, members) {
+		t.Errorf("Expected members %v, got %v", expected, members)
+	}
+
+	// While the last value wins for the distinct keys
+	values, keys := ObjectMembers(rootNode)
+	if expected := []string{"b", "a"}; !reflect.DeepEqual(expected, keys) {
+		t.Errorf("Expected keys %v, got %v", expected, keys)
+	}
+	if values["b"].Raw != "3" {
+		t.Errorf("Expected the last value of 'b', got %s", values["b"].Raw)
+	}
+}
+
+func TestMaxDepth(t *testing.T) {
+	// Define test cases
+	testCases := []struct {
+		input         string
+		expectedDepth int
+	}{
+		{input: `1`, expectedDepth: 0},
+		{input: `"scalar"`, expectedDepth: 0},
+		{input: `{}`, expectedDepth: 1},
+		{input: `[]`, expectedDepth: 1},
+		{input: `[[1]]`, expectedDepth: 2},
+		{input: `[1, [2, [3]], {}]`, expectedDepth: 3},
+		{input: `{"a": [{"b": {}}], "c": 1}`, expectedDepth: 4},
+		{input: `[[], [[[]]], []]`, expectedDepth: 4},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.input, func(t *testing.T) {
+			rootNode, err := ParseJSON(lex(testCase.input))
+			if err != nil {
+				t.Fatalf("Expected no parse error, got %v", err)
+			}
+
+			if depth := MaxDepth(rootNode); depth != testCase.expectedDepth {
+				t.Errorf("Expected depth %d, got %d", testCase.expectedDepth, depth)
+			}
+		})
+	}
+}
+
+func TestKeyPaths(t *testing.T) {
+	// Define test cases
+	testCases := []struct {
+		name          string
+		input         string
+		expectedPaths []string
+	}{
+		{name: "scalar", input: `1`, expectedPaths: []string{}},
+		{name: "empty object", input: `{}`, expectedPaths: []string{}},
+		{
+			name:          "nested objects",
+			input:         `{"server": {"port": 80, "host": "localhost"}, "debug": true}`,
+			expectedPaths: []string{"debug", "server", "server.host", "server.port"},
+		},
+		{
+			name:          "array elements are collapsed",
+			input:         `{"users": [{"name": "a", "email": "b"}, {"name": "c", "roles": [{"id": 1}]}]}`,
+			expectedPaths: []string{"users", "users[].email", "users[].name", "users[].roles", "users[].roles[].id"},
+		},
+		{name: "root array", input: `[[{"a": 1}], {"b": {"a": 2}}]`, expectedPaths: []string{"[].b", "[].b.a", "[][].a"}},
+		{name: "duplicate keys", input: `{"a": {"b": 1}, "a": {"c": 2}}`, expectedPaths: []string{"a", "a.b", "a.c"}},
+		{
+			name:          "dotted keys are quoted",
+			input:         `{"a.b": {"c": 1}, "a": {"b": {"c": 2}}}`,
+			expectedPaths: []string{`["a.b"]`, `["a.b"].c`, "a", "a.b", "a.b.c"},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			rootNode, err := ParseJSON(lex(testCase.input))
+			if err != nil {
+				t.Fatalf("Expected no parse error, got %v", err)
+			}
+
+			if paths := KeyPaths(rootNode); !reflect.DeepEqual(paths, testCase.expectedPaths) {
+				t.Errorf("Expected key paths %q, got %q", testCase.expectedPaths, paths)
+			}
+		})
+	}
+}
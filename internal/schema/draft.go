@@ -0,0 +1,37 @@
+package schema
+
+import "fmt"
+
+// Draft identifies a version of JSON Schema, which determines how some keywords are interpreted
+type Draft string
+
+const (
+	Draft07     Draft = "draft-07" // "items" may be an array of schemas validating elements by position, see "additionalItems"
+	Draft202012 Draft = "2020-12"  // "prefixItems" validates elements by position, "items" validates the remaining elements
+
+	// DefaultDraft is the draft used when none is specified
+	DefaultDraft = Draft07
+)
+
+// draftURIs maps each supported draft to the URI identifying it in "$schema"
+var draftURIs = map[Draft]string{
+	Draft07:     "http://json-schema.org/draft-07/schema#",
+	Draft202012: "https://json-schema.org/draft/2020-12/schema",
+}
+
+// ParseDraft returns the Draft named by name (ex. "draft-07" or "2020-12").
+// An empty name selects DefaultDraft.
+func ParseDraft(name string) (Draft, error) {
+	if name == "" {
+		return DefaultDraft, nil
+	}
+	if _, ok := draftURIs[Draft(name)]; !ok {
+		return "", fmt.Errorf("unknown schema draft %q, expected %q or %q", name, Draft07, Draft202012)
+	}
+	return Draft(name), nil
+}
+
+// URI returns the URI identifying the draft in "$schema"
+func (d Draft) URI() string {
+	return draftURIs[d]
+}
@@ -0,0 +1,141 @@
+package schema
+
+import (
+	"encoding/json"
+
+	"github.com/pszponder/json-linter_go/internal/parser"
+)
+
+// Infer returns a best-effort schema describing the value represented by the AST node.
+//
+// Objects require the keys present in the sample, array items are described by merging the schemas of every element
+// (differing types become a union, ex. ["integer", "string"]), and integers are distinguished from other numbers.
+func Infer(node *parser.ASTNode) *Schema {
+	switch node.Type {
+	case "Object":
+		s := &Schema{Types: []string{"object"}, Properties: map[string]*Schema{}, Required: []string{}}
+		for _, member := range parser.Members(node) {
+			value := Infer(member.Value)
+			if existing, ok := s.Properties[member.Key]; ok {
+				// Duplicate key, describe every value
+				s.Properties[member.Key] = merge(existing, value)
+				continue
+			}
+			s.Properties[member.Key] = value
+			s.Required = append(s.Required, member.Key)
+		}
+		return s
+	case "Array":
+		s := &Schema{Types: []string{"array"}}
+		for _, child := range node.Children {
+			if s.Items == nil {
+				s.Items = Infer(child)
+			} else {
+				s.Items = merge(s.Items, Infer(child))
+			}
+		}
+		return s
+	case "Number":
+		if node.IsInteger {
+			return &Schema{Types: []string{"integer"}}
+		}
+		return &Schema{Types: []string{"number"}}
+	case "String":
+		return &Schema{Types: []string{"string"}}
+	case "Boolean":
+		return &Schema{Types: []string{"boolean"}}
+	default:
+		return &Schema{Types: []string{"null"}}
+	}
+}
+
+// InferDocument returns the indented JSON encoding of the schema inferred from the root of a document,
+// declaring the (2020-12) JSON Schema dialect via "$schema".
+func InferDocument(root *parser.ASTNode) ([]byte, error) {
+	doc, err := json.Marshal(Infer(root))
+	if err != nil {
+		return nil, err
+	}
+
+	// Add "$schema" to the encoded root schema
+	var obj map[string]json.RawMessage
+	if err := json.Unmarshal(doc, &obj); err != nil {
+		return nil, err
+	}
+	obj["$schema"], _ = json.Marshal(Draft202012.URI())
+
+	return json.MarshalIndent(obj, "", "  ")
+}
+
+// merge returns a schema describing the values of both schemas
+func merge(a *Schema, b *Schema) *Schema {
+	merged := &Schema{}
+	merged.addTypes(a.Types...)
+	merged.addTypes(b.Types...)
+
+	// Integers are numbers, so the union of both is just a number
+	if merged.hasType("number") && merged.hasType("integer") {
+		types := merged.Types[:0]
+		for _, typ := range merged.Types {
+			if typ != "integer" {
+				types = append(types, typ)
+			}
+		}
+		merged.Types = types
+	}
+
+	merged.Properties, merged.Required = mergeProperties(a, b)
+	merged.Items = mergeItems(a.Items, b.Items)
+
+	return merged
+}
+
+// mergeProperties merges the members of two object schemas.
+// A key is only required if both schemas require it.
+func mergeProperties(a *Schema, b *Schema) (map[string]*Schema, []string) {
+	if a.Properties == nil && b.Properties == nil {
+		return nil, nil
+	}
+	if a.Properties == nil {
+		return b.Properties, b.Required
+	}
+	if b.Properties == nil {
+		return a.Properties, a.Required
+	}
+
+	properties := make(map[string]*Schema, len(a.Properties))
+	for key, value := range a.Properties {
+		properties[key] = value
+	}
+	for key, value := range b.Properties {
+		if existing, ok := properties[key]; ok {
+			properties[key] = merge(existing, value)
+		} else {
+			properties[key] = value
+		}
+	}
+
+	required := []string{}
+	for _, key := range a.Required {
+		for _, other := range b.Required {
+			if key == other {
+				required = append(required, key)
+				break
+			}
+		}
+	}
+
+	return properties, required
+}
+
+// mergeItems merges the item schemas of two array schemas, either of which may be absent (empty array)
+func mergeItems(a *Schema, b *Schema) *Schema {
+	switch {
+	case a == nil:
+		return b
+	case b == nil:
+		return a
+	default:
+		return merge(a, b)
+	}
+}
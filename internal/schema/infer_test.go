@@ -0,0 +1,84 @@
+package schema
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/pszponder/json-linter_go/internal/lexer"
+	"github.com/pszponder/json-linter_go/internal/parser"
+)
+
+// parse is a helper which parses the input string into an AST
+func parse(t *testing.T, input string) *parser.ASTNode {
+	t.Helper()
+
+	node, err := parser.ParseJSON(lexer.LexReader(bytes.NewReader([]byte(input))))
+	if err != nil {
+		t.Fatalf("Failed to parse %s: %v", input, err)
+	}
+	return node
+}
+
+func TestInfer(t *testing.T) {
+	// Define test cases
+	testCases := []struct {
+		input          string
+		expectedSchema string
+	}{
+		{input: `"a"`, expectedSchema: `{"type":"string"}`},
+		{input: `1`, expectedSchema: `{"type":"integer"}`},
+		{input: `1.5`, expectedSchema: `{"type":"number"}`},
+		{input: `true`, expectedSchema: `{"type":"boolean"}`},
+		{input: `null`, expectedSchema: `{"type":"null"}`},
+		{input: `[]`, expectedSchema: `{"type":"array"}`},
+		{input: `{}`, expectedSchema: `{"properties":{},"required":[],"type":"object"}`},
+		{
+			input:          `{"name": "jl", "version": 2, "tags": ["a", "b"]}`,
+			expectedSchema: `{"properties":{"name":{"type":"string"},"tags":{"items":{"type":"string"},"type":"array"},"version":{"type":"integer"}},"required":["name","version","tags"],"type":"object"}`,
+		},
+		// Differing element types become a union
+		{input: `[1, "a", null]`, expectedSchema: `{"items":{"type":["integer","null","string"]},"type":"array"}`},
+		// Integers & other numbers merge into numbers
+		{input: `[1, 2.5]`, expectedSchema: `{"items":{"type":"number"},"type":"array"}`},
+		// Only the keys present in every element are required
+		{
+			input:          `[{"a": 1, "b": true}, {"a": 2}]`,
+			expectedSchema: `{"items":{"properties":{"a":{"type":"integer"},"b":{"type":"boolean"}},"required":["a"],"type":"object"},"type":"array"}`,
+		},
+		{
+			input:          `[[1], [], ["a"]]`,
+			expectedSchema: `{"items":{"items":{"type":["integer","string"]},"type":"array"},"type":"array"}`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.input, func(t *testing.T) {
+			schema, err := json.Marshal(Infer(parse(t, testCase.input)))
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if string(schema) != testCase.expectedSchema {
+				t.Errorf("Expected schema %s, got %s", testCase.expectedSchema, schema)
+			}
+		})
+	}
+}
+
+func TestInferDocument(t *testing.T) {
+	doc, err := InferDocument(parse(t, `{"a": 1}`))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	var obj map[string]interface{}
+	if err := json.Unmarshal(doc, &obj); err != nil {
+		t.Fatalf("Expected a JSON document, got %s", doc)
+	}
+	if obj["$schema"] != Draft202012.URI() {
+		t.Errorf("Expected $schema %q, got %v", Draft202012.URI(), obj["$schema"])
+	}
+	if obj["type"] != "object" {
+		t.Errorf("Expected type object, got %v", obj["type"])
+	}
+}
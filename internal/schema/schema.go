@@ -0,0 +1,58 @@
+// Package schema is responsible for working w/ JSON Schemas describing the documents validated by the linter.
+package schema
+
+import (
+	"encoding/json"
+	"sort"
+)
+
+// Schema is a (minimal) JSON Schema, describing the types & structure of a value
+type Schema struct {
+	Types      []string           // Sorted JSON Schema types (ex. "object", "integer"), more than one means a union
+	Properties map[string]*Schema // Schemas of the members, for objects
+	Required   []string           // Keys every object contains, in document order
+	Items      *Schema            // Schema of the elements, for non-empty arrays
+}
+
+// hasType reports whether the schema allows the type
+func (s *Schema) hasType(typ string) bool {
+	for _, t := range s.Types {
+		if t == typ {
+			return true
+		}
+	}
+	return false
+}
+
+// MarshalJSON encodes the schema as a JSON Schema object.
+// A single type is encoded as a string, a union as an array of types.
+func (s *Schema) MarshalJSON() ([]byte, error) {
+	obj := make(map[string]interface{})
+
+	if len(s.Types) == 1 {
+		obj["type"] = s.Types[0]
+	} else {
+		obj["type"] = s.Types
+	}
+	if s.Properties != nil {
+		obj["properties"] = s.Properties
+	}
+	if s.Required != nil {
+		obj["required"] = s.Required
+	}
+	if s.Items != nil {
+		obj["items"] = s.Items
+	}
+
+	return json.Marshal(obj)
+}
+
+// addTypes adds the types to the (sorted) set of types of the schema
+func (s *Schema) addTypes(types ...string) {
+	for _, typ := range types {
+		if !s.hasType(typ) {
+			s.Types = append(s.Types, typ)
+		}
+	}
+	sort.Strings(s.Types)
+}
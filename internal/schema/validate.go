@@ -0,0 +1,223 @@
+package schema
+
+import (
+	"fmt"
+	"math"
+	"os"
+	"strings"
+
+	"github.com/pszponder/json-linter_go/internal/parser"
+)
+
+// RuleSchema is the name of the lint rule reporting values which don't match the schema
+const RuleSchema = "schema"
+
+// Load reads & decodes the JSON Schema located at path
+func Load(path string) (interface{}, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("Could not load schema: %v", err)
+	}
+
+	schemaDoc, err := parser.Decode(data)
+	if err != nil {
+		return nil, fmt.Errorf("Could not load schema %s: %v", path, err)
+	}
+	return schemaDoc, nil
+}
+
+// Validate returns an error for each value of the AST which doesn't match the (decoded) schema,
+// interpreting the keywords according to the draft (an empty draft means DefaultDraft).
+//
+// Only a subset of JSON Schema is supported: boolean schemas, "type", "properties", "required",
+// "items", "prefixItems" (2020-12) & "additionalItems" (draft-07). Other keywords are ignored.
+// Returns an error if the schema itself is malformed.
+func Validate(schemaDoc interface{}, node *parser.ASTNode, draft Draft) ([]parser.ParseError, error) {
+	if draft == "" {
+		draft = DefaultDraft
+	}
+	v := &validator{draft: draft}
+	if err := v.validate(schemaDoc, node, "$"); err != nil {
+		return nil, err
+	}
+	return v.errs, nil
+}
+
+// validator collects the errors found while validating a document against a schema
+type validator struct {
+	draft Draft
+	errs  []parser.ParseError
+}
+
+// fail records an error for the node located at path
+func (v *validator) fail(node *parser.ASTNode, path string, format string, args ...interface{}) {
+	v.errs = append(v.errs, parser.ParseError{
+		Severity: parser.SeverityError,
+		Rule:     RuleSchema,
+		Message:  fmt.Sprintf(format, args...),
+		Pos:      node.Pos,
+		Path:     path,
+	})
+}
+
+// validate validates the node located at path against the schema
+func (v *validator) validate(schema interface{}, node *parser.ASTNode, path string) error {
+	switch s := schema.(type) {
+	case bool:
+		if !s {
+			v.fail(node, path, "Value of %s is not allowed by the schema", path)
+		}
+		return nil
+	case map[string]interface{}:
+		if err := v.validateType(s, node, path); err != nil {
+			return err
+		}
+		switch node.Type {
+		case "Object":
+			return v.validateObject(s, node, path)
+		case "Array":
+			return v.validateArray(s, node, path)
+		}
+		return nil
+	default:
+		return fmt.Errorf("Invalid schema at %s, expected an object or a boolean", path)
+	}
+}
+
+// validateType checks the "type" keyword, which is either a type or a list of types
+func (v *validator) validateType(schema map[string]interface{}, node *parser.ASTNode, path string) error {
+	typeKeyword, ok := schema["type"]
+	if !ok {
+		return nil
+	}
+
+	var types []string
+	switch t := typeKeyword.(type) {
+	case string:
+		types = []string{t}
+	case []interface{}:
+		for _, item := range t {
+			typ, ok := item.(string)
+			if !ok {
+				return fmt.Errorf("Invalid schema at %s, \"type\" must list strings", path)
+			}
+			types = append(types, typ)
+		}
+	default:
+		return fmt.Errorf("Invalid schema at %s, \"type\" must be a string or an array", path)
+	}
+
+	for _, typ := range types {
+		if matchesType(node, typ) {
+			return nil
+		}
+	}
+	v.fail(node, path, "Value of %s must be of type '%s', got %s", path, strings.Join(types, "' or '"), node.Type)
+	return nil
+}
+
+// matchesType reports whether the node is of the JSON Schema type.
+// As in JSON Schema, numbers w/ a zero fraction (ex. 1.0) are integers.
+func matchesType(node *parser.ASTNode, typ string) bool {
+	switch typ {
+	case "integer":
+		if node.Type != "Number" {
+			return false
+		}
+		num := node.Value.(float64)
+		return !math.IsInf(num, 0) && num == math.Trunc(num)
+	case "number":
+		return node.Type == "Number"
+	default:
+		return strings.ToLower(node.Type) == typ
+	}
+}
+
+// validateObject checks the "required" & "properties" keywords
+func (v *validator) validateObject(schema map[string]interface{}, node *parser.ASTNode, path string) error {
+	members, keys := parser.ObjectMembers(node) // The last value wins for duplicate keys
+
+	if required, ok := schema["required"]; ok {
+		list, ok := required.([]interface{})
+		if !ok {
+			return fmt.Errorf("Invalid schema at %s, \"required\" must be an array", path)
+		}
+		for _, item := range list {
+			key, ok := item.(string)
+			if !ok {
+				return fmt.Errorf("Invalid schema at %s, \"required\" must list strings", path)
+			}
+			if _, ok := members[key]; !ok {
+				v.fail(node, path, "Missing required key '%s' in %s", key, path)
+			}
+		}
+	}
+
+	if properties, ok := schema["properties"]; ok {
+		propertySchemas, ok := properties.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("Invalid schema at %s, \"properties\" must be an object", path)
+		}
+		for _, key := range keys {
+			if propertySchema, ok := propertySchemas[key]; ok {
+				if err := v.validate(propertySchema, members[key], parser.KeyPath(path, key)); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// validateArray checks the keywords applying to array elements, which differ between drafts
+func (v *validator) validateArray(schema map[string]interface{}, node *parser.ASTNode, path string) error {
+	// Schemas validating the leading elements by position, then the schema validating the remaining elements
+	var positional []interface{}
+	var rest interface{}
+
+	items, hasItems := schema["items"]
+	switch v.draft {
+	case Draft202012:
+		if prefixItems, ok := schema["prefixItems"]; ok {
+			list, ok := prefixItems.([]interface{})
+			if !ok {
+				return fmt.Errorf("Invalid schema at %s, \"prefixItems\" must be an array", path)
+			}
+			positional = list
+		}
+		if hasItems {
+			if _, ok := items.([]interface{}); ok {
+				return fmt.Errorf("Invalid schema at %s, \"items\" must be a schema in draft %s (use \"prefixItems\")", path, v.draft)
+			}
+			rest = items
+		}
+	default:
+		// Draft-07: an array of "items" validates by position, the remaining elements are validated by "additionalItems"
+		if list, ok := items.([]interface{}); ok {
+			positional = list
+			rest = schema["additionalItems"]
+		} else if hasItems {
+			rest = items
+		}
+	}
+
+	for i, element := range node.Children {
+		elementPath := parser.IndexPath(path, i)
+
+		var elementSchema interface{}
+		if i < len(positional) {
+			elementSchema = positional[i]
+		} else if rest != nil {
+			elementSchema = rest
+		} else {
+			continue
+		}
+
+		if err := v.validate(elementSchema, element, elementPath); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,150 @@
+package schema
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/pszponder/json-linter_go/internal/parser"
+)
+
+// decodeSchema is a helper which decodes the schema string
+func decodeSchema(t *testing.T, schema string) interface{} {
+	t.Helper()
+
+	schemaDoc, err := parser.Decode([]byte(schema))
+	if err != nil {
+		t.Fatalf("Failed to decode schema %s: %v", schema, err)
+	}
+	return schemaDoc
+}
+
+func TestValidate(t *testing.T) {
+	// Define test cases
+	testCases := []struct {
+		name           string
+		schema         string
+		input          string
+		expectedErrors []string
+	}{
+		{name: "true schema", schema: `true`, input: `[1]`},
+		{name: "false schema", schema: `false`, input: `1`, expectedErrors: []string{"Value of $ is not allowed by the schema at line 1, Column 1:1"}},
+		{name: "matching type", schema: `{"type": "object"}`, input: `{}`},
+		{name: "integer w/ zero fraction", schema: `{"type": "integer"}`, input: `1.0`},
+		{name: "type union", schema: `{"type": ["string", "null"]}`, input: `null`},
+		{
+			name:           "mismatched type",
+			schema:         `{"type": ["string", "null"]}`,
+			input:          `1.5`,
+			expectedErrors: []string{"Value of $ must be of type 'string' or 'null', got Number at line 1, Column 1:3"},
+		},
+		{
+			name:   "properties & required",
+			schema: `{"type": "object", "required": ["name", "version"], "properties": {"name": {"type": "string"}, "tags": {"items": {"type": "string"}}}}`,
+			input:  `{"name": 1, "tags": ["a", 2]}`,
+			expectedErrors: []string{
+				"Missing required key 'version' in $ at line 1, Column 1:1",
+				"Value of $.name must be of type 'string', got Number at line 1, Column 10:10",
+				"Value of $.tags[1] must be of type 'string', got Number at line 1, Column 27:27",
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			findings, err := Validate(decodeSchema(t, testCase.schema), parse(t, testCase.input), DefaultDraft)
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+
+			var actualErrors []string
+			for _, finding := range findings {
+				actualErrors = append(actualErrors, finding.Error())
+
+				// The path is also part of the message
+				if !strings.Contains(finding.Message, finding.Path) {
+					t.Errorf("Expected path %q in the message %q", finding.Path, finding.Message)
+				}
+			}
+			if !reflect.DeepEqual(testCase.expectedErrors, actualErrors) {
+				t.Errorf("Expected errors %q, got %q", testCase.expectedErrors, actualErrors)
+			}
+		})
+	}
+}
+
+func TestValidateDrafts(t *testing.T) {
+	// Schemas validating the first element as an integer & the other elements as strings, written for each draft
+	draft07Schema := `{"items": [{"type": "integer"}], "additionalItems": {"type": "string"}}`
+	draft202012Schema := `{"prefixItems": [{"type": "integer"}], "items": {"type": "string"}}`
+
+	// Define test cases
+	testCases := []struct {
+		name             string
+		schema           string
+		draft            Draft
+		input            string
+		expectedErrors   int
+		expectedErrorMsg string // Expected error for a schema which is malformed in the draft
+	}{
+		{name: "draft-07 tuple", schema: draft07Schema, draft: Draft07, input: `[1, "a", "b"]`},
+		{name: "draft-07 tuple mismatch", schema: draft07Schema, draft: Draft07, input: `[1, 2]`, expectedErrors: 1},
+		{name: "2020-12 tuple", schema: draft202012Schema, draft: Draft202012, input: `[1, "a", "b"]`},
+		{name: "2020-12 tuple mismatch", schema: draft202012Schema, draft: Draft202012, input: `[1, 2]`, expectedErrors: 1},
+		// draft-07 ignores "prefixItems", so "items" applies to every element (including the integer)
+		{name: "2020-12 schema in draft-07", schema: draft202012Schema, draft: Draft07, input: `[1, "a", "b"]`, expectedErrors: 1},
+		// An array of "items" is only allowed before 2020-12
+		{
+			name:             "draft-07 schema in 2020-12",
+			schema:           draft07Schema,
+			draft:            Draft202012,
+			input:            `[1, "a"]`,
+			expectedErrorMsg: `"items" must be a schema in draft 2020-12`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			findings, err := Validate(decodeSchema(t, testCase.schema), parse(t, testCase.input), testCase.draft)
+
+			if testCase.expectedErrorMsg != "" {
+				if err == nil || !strings.Contains(err.Error(), testCase.expectedErrorMsg) {
+					t.Errorf("Expected error containing %q, got %v", testCase.expectedErrorMsg, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if len(findings) != testCase.expectedErrors {
+				t.Errorf("Expected %d errors, got %v", testCase.expectedErrors, findings)
+			}
+		})
+	}
+}
+
+func TestParseDraft(t *testing.T) {
+	// Define test cases
+	testCases := []struct {
+		name          string
+		expectedDraft Draft
+		expectErr     bool
+	}{
+		{name: "", expectedDraft: Draft07},
+		{name: "draft-07", expectedDraft: Draft07},
+		{name: "2020-12", expectedDraft: Draft202012},
+		{name: "draft-04", expectErr: true},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			draft, err := ParseDraft(testCase.name)
+			if (err != nil) != testCase.expectErr {
+				t.Fatalf("Expected error: %v, got %v", testCase.expectErr, err)
+			}
+			if draft != testCase.expectedDraft {
+				t.Errorf("Expected draft %q, got %q", testCase.expectedDraft, draft)
+			}
+		})
+	}
+}